@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountsJSON  bool
+	accountsClear bool
+
+	accountsCmd = &cobra.Command{
+		Use:   "accounts",
+		Short: "List and pin the Cloudflare accounts a credential can access",
+		Long: `List the Cloudflare accounts the selected stored credential can access,
+and pin one of them so zone listings only show its zones.
+
+The global --account flag picks the stored credential; the commands below
+work with the Cloudflare accounts behind it.
+
+Examples:
+  # List Cloudflare accounts, marking the pinned one
+  cfctl accounts list
+
+  # Only list zones of one Cloudflare account by default
+  cfctl accounts pin 023e105f4ecef8ad9ca31a8372d0c353
+
+  # List zones of the pinned account, or of another one
+  cfctl accounts zones
+  cfctl accounts zones 372e67954025e0ba6aaa6d586b9e0b59
+
+  # List zones of every account again
+  cfctl accounts pin --clear`,
+	}
+
+	accountsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List Cloudflare accounts",
+		Args:  cobra.NoArgs,
+		RunE:  runAccountsList,
+	}
+
+	accountsPinCmd = &cobra.Command{
+		Use:   "pin [account-id]",
+		Short: "Pin the Cloudflare account zones are listed from",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runAccountsPin,
+	}
+
+	accountsZonesCmd = &cobra.Command{
+		Use:   "zones [account-id]",
+		Short: "List zones of a Cloudflare account (default: the pinned one)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runAccountsZones,
+	}
+)
+
+func init() {
+	accountsListCmd.Flags().BoolVar(&accountsJSON, "json", false, "output as JSON")
+	accountsPinCmd.Flags().BoolVar(&accountsClear, "clear", false, "unpin and list zones of every account")
+	accountsZonesCmd.Flags().BoolVar(&accountsJSON, "json", false, "output as JSON")
+
+	accountsCmd.AddCommand(accountsListCmd, accountsPinCmd, accountsZonesCmd)
+	rootCmd.AddCommand(accountsCmd)
+}
+
+func runAccountsList(cmd *cobra.Command, args []string) error {
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := client.ListAccounts(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if accountsJSON {
+		return printJSON(accounts)
+	}
+
+	if len(accounts) == 0 {
+		infof("No Cloudflare accounts are accessible with %s\n", account.Name)
+		return nil
+	}
+
+	for _, a := range accounts {
+		marker := " "
+		if a.ID == account.AccountID {
+			marker = "*"
+		}
+		fmt.Printf("%s %s  %s (%s)\n", marker, a.ID, a.Name, a.Type)
+	}
+	return nil
+}
+
+func runAccountsPin(cmd *cobra.Command, args []string) error {
+	if accountsClear == (len(args) == 1) {
+		return fmt.Errorf("pass either an account ID or --clear")
+	}
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return err
+	}
+
+	if accountsClear {
+		if err := cfg.SetAccountID(account.Name, ""); err != nil {
+			return err
+		}
+		infof("✓ %s lists zones of every Cloudflare account\n", account.Name)
+		return nil
+	}
+
+	// Only pin accounts the credential can actually reach
+	accounts, err := client.ListAccounts(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if a.ID == args[0] {
+			if err := cfg.SetAccountID(account.Name, a.ID); err != nil {
+				return err
+			}
+			infof("✓ %s now lists zones of %s (%s)\n", account.Name, a.Name, a.ID)
+			return nil
+		}
+	}
+	return fmt.Errorf("cloudflare account %s is not accessible with %s", args[0], account.Name)
+}
+
+func runAccountsZones(cmd *cobra.Command, args []string) error {
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return err
+	}
+
+	accountID := account.AccountID
+	if len(args) == 1 {
+		accountID = args[0]
+	}
+
+	zones, err := client.ListZones(context.Background(), accountID)
+	if err != nil {
+		return err
+	}
+
+	if accountsJSON {
+		return printJSON(zones)
+	}
+
+	if len(zones) == 0 {
+		infof("No zones found\n")
+		return nil
+	}
+
+	for _, z := range zones {
+		fmt.Printf("%s  %-32s %-10s %s\n", z.ID, z.Name, z.Status, z.Account.Name)
+	}
+	return nil
+}
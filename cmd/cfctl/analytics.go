@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	analyticsWindow string
+	analyticsJSON   bool
+
+	analyticsCmd = &cobra.Command{
+		Use:   "analytics <zone>",
+		Short: "Show cache analytics for a zone",
+		Long: `Show cache hit ratio, bandwidth saved and requests served from cache.
+
+Examples:
+  cfctl analytics example.com
+  cfctl analytics example.com --window 7d --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAnalytics,
+	}
+)
+
+func init() {
+	analyticsCmd.Flags().StringVarP(&analyticsWindow, "window", "w", "24h", "time window: 24h, 7d or 30d")
+	analyticsCmd.Flags().BoolVar(&analyticsJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(analyticsCmd)
+}
+
+func runAnalytics(cmd *cobra.Command, args []string) error {
+	window, err := api.ParseAnalyticsWindow(analyticsWindow)
+	if err != nil {
+		return err
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	stats, err := client.GetCacheAnalytics(ctx, zone.ID, window)
+	if err != nil {
+		return err
+	}
+
+	if analyticsJSON {
+		return printJSON(stats)
+	}
+
+	byteRatio := 0.0
+	if stats.Bytes > 0 {
+		byteRatio = float64(stats.CachedBytes) / float64(stats.Bytes)
+	}
+
+	fmt.Printf("Zone:              %s (last %s)\n", zone.Name, window)
+	fmt.Printf("Requests:          %d\n", stats.Requests)
+	fmt.Printf("Served from cache: %d\n", stats.CachedRequests)
+	fmt.Printf("Cache hit ratio:   %.1f%%\n", stats.HitRatio*100)
+	fmt.Printf("Bandwidth:         %s\n", utils.FormatBytes(stats.Bytes))
+	fmt.Printf("Bandwidth saved:   %s (%.1f%%)\n", utils.FormatBytes(stats.CachedBytes), byteRatio*100)
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/certs"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	certsExpiringWithin string
+	certsJSON           bool
+
+	certsCmd = &cobra.Command{
+		Use:   "certs",
+		Short: "Show edge certificates and SSL/TLS settings",
+		Long: `Show the edge certificate packs of zones with their hosts, issuer and
+expiry, along with each zone's SSL mode.
+
+Examples:
+  # Certificates and SSL mode of one zone
+  cfctl certs list example.com
+
+  # Certificates expiring in the next 30 days across every zone, for alerts
+  cfctl certs list --expiring-within 30d --json`,
+	}
+
+	certsListCmd = &cobra.Command{
+		Use:   "list [zone]",
+		Short: "List edge certificate packs of a zone, or of every zone",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runCertsList,
+	}
+)
+
+func init() {
+	certsListCmd.Flags().StringVar(&certsExpiringWithin, "expiring-within", "", "only packs expiring within this window (e.g. 30d, 2w, 36h)")
+	certsListCmd.Flags().BoolVar(&certsJSON, "json", false, "output as JSON")
+
+	certsCmd.AddCommand(certsListCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+// zoneCertificates is the JSON output of "certs list" for one zone
+type zoneCertificates struct {
+	Zone  string                       `json:"zone"`
+	SSL   *cloudflare.SSLSettings      `json:"ssl,omitempty"`
+	Packs []cloudflare.CertificatePack `json:"certificate_packs"`
+}
+
+func runCertsList(cmd *cobra.Command, args []string) error {
+	var within time.Duration
+	if certsExpiringWithin != "" {
+		var err error
+		if within, err = certs.ParseWithin(certsExpiringWithin); err != nil {
+			return err
+		}
+	}
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var zones []cloudflare.Zone
+	if len(args) == 1 {
+		zone, err := resolveZone(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		zones = []cloudflare.Zone{*zone}
+	} else {
+		account, err := selectedAccount(cfg)
+		if err != nil {
+			return err
+		}
+		if zones, err = client.ListZones(ctx, account.AccountID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	results := []zoneCertificates{}
+	failed := 0
+	for _, zone := range zones {
+		packs, err := client.ListCertificatePacks(ctx, zone.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", zone.Name, err)
+			failed++
+			continue
+		}
+
+		result := zoneCertificates{Zone: zone.Name, Packs: packs}
+		if within > 0 {
+			result.Packs = certs.ExpiringWithin(packs, now, within)
+			if len(result.Packs) == 0 {
+				continue
+			}
+		} else {
+			certs.SortByExpiry(result.Packs)
+			// The SSL mode is only shown in the full listing
+			if result.SSL, err = client.GetSSLSettings(ctx, zone.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %s: %v\n", zone.Name, err)
+			}
+		}
+		results = append(results, result)
+	}
+
+	if certsJSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		printCertificates(results, now)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d zones could not be checked", failed, len(zones))
+	}
+	return nil
+}
+
+func printCertificates(results []zoneCertificates, now time.Time) {
+	if len(results) == 0 {
+		if certsExpiringWithin != "" {
+			infof("✓ No certificates expire within %s\n", certsExpiringWithin)
+		} else {
+			infof("No zones found\n")
+		}
+		return
+	}
+
+	for _, result := range results {
+		fmt.Println(result.Zone)
+		if result.SSL != nil {
+			fmt.Printf("   SSL %s, minimum TLS %s, TLS 1.3 %s, Always Use HTTPS %s\n",
+				certs.SSLModeLabel(result.SSL.Mode), result.SSL.MinTLSVersion, result.SSL.TLS13, enabledState(result.SSL.AlwaysUseHTTPS))
+		}
+		if len(result.Packs) == 0 {
+			fmt.Println("   no certificate packs")
+		}
+		for _, pack := range result.Packs {
+			expires := "not issued"
+			if e := pack.Expires(); !e.IsZero() {
+				expires = fmt.Sprintf("expires %s (%d days)", e.Local().Format("2006-01-02"), certs.DaysLeft(e, now))
+			}
+			fmt.Printf("   %s [%s, %s] %s, %s\n      %s\n", pack.Type, pack.Status, pack.ID, pack.Issuer(), expires, strings.Join(pack.Hosts, ", "))
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/firewall"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	firewallZone        string
+	firewallAccountID   string
+	firewallJSON        bool
+	firewallExpression  string
+	firewallAction      string
+	firewallDescription string
+	firewallEnabled     bool
+	firewallMode        string
+	firewallNotes       string
+
+	firewallCmd = &cobra.Command{
+		Use:   "firewall",
+		Short: "Manage WAF custom rules and IP Access rules",
+		Long: `Manage the WAF custom rules (http_request_firewall_custom phase) and IP
+Access rules of a zone (--zone) or of a whole Cloudflare account
+(--account-id). Account-level custom rules require an Enterprise plan.
+
+IP Access rules match an IPv4 or IPv6 address, a CIDR range (/16 or /24,
+IPv6 /32, /48 or /64), an ASN (AS13335) or a two-letter country code.
+
+Examples:
+  # List custom rules as JSON
+  cfctl firewall rules list --zone example.com --json
+
+  # Challenge requests to the login page from outside one country
+  cfctl firewall rules create --zone example.com --action managed_challenge \
+    --expression 'http.request.uri.path eq "/login" and ip.geoip.country ne "DE"' \
+    --description "Challenge foreign logins"
+
+  # Block an IP range for every zone of an account
+  cfctl firewall access create 198.51.100.0/24 --mode block --account-id 023e105f4ecef8ad9ca31a8372d0c353
+
+  # Allow an office IP on one zone
+  cfctl firewall access create 203.0.113.10 --mode allow --notes office --zone example.com`,
+	}
+
+	firewallRulesCmd = &cobra.Command{
+		Use:   "rules",
+		Short: "Manage WAF custom rules",
+	}
+
+	firewallRulesListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List custom rules in evaluation order",
+		Args:  cobra.NoArgs,
+		RunE:  runFirewallRulesList,
+	}
+
+	firewallRulesCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Add a custom rule",
+		Args:  cobra.NoArgs,
+		RunE:  runFirewallRulesCreate,
+	}
+
+	firewallRulesUpdateCmd = &cobra.Command{
+		Use:   "update <rule-id>",
+		Short: "Change a custom rule; only the given flags are changed",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runFirewallRulesUpdate,
+	}
+
+	firewallRulesDeleteCmd = &cobra.Command{
+		Use:   "delete <rule-id>",
+		Short: "Delete a custom rule",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runFirewallRulesDelete,
+	}
+
+	firewallAccessCmd = &cobra.Command{
+		Use:   "access",
+		Short: "Manage IP Access rules",
+	}
+
+	firewallAccessListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List IP Access rules",
+		Args:  cobra.NoArgs,
+		RunE:  runFirewallAccessList,
+	}
+
+	firewallAccessCreateCmd = &cobra.Command{
+		Use:   "create <ip|cidr|asn|country>",
+		Short: "Add an IP Access rule",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runFirewallAccessCreate,
+	}
+
+	firewallAccessUpdateCmd = &cobra.Command{
+		Use:   "update <rule-id>",
+		Short: "Change the mode or notes of an IP Access rule",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runFirewallAccessUpdate,
+	}
+
+	firewallAccessDeleteCmd = &cobra.Command{
+		Use:   "delete <rule-id>",
+		Short: "Delete an IP Access rule",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runFirewallAccessDelete,
+	}
+)
+
+func init() {
+	firewallCmd.PersistentFlags().StringVar(&firewallZone, "zone", "", "zone name or ID")
+	firewallCmd.PersistentFlags().StringVar(&firewallAccountID, "account-id", "", "Cloudflare account ID, for account-level rules")
+
+	for _, cmd := range []*cobra.Command{firewallRulesListCmd, firewallRulesCreateCmd, firewallRulesUpdateCmd, firewallAccessListCmd, firewallAccessCreateCmd, firewallAccessUpdateCmd} {
+		cmd.Flags().BoolVar(&firewallJSON, "json", false, "output as JSON")
+	}
+
+	for _, cmd := range []*cobra.Command{firewallRulesCreateCmd, firewallRulesUpdateCmd} {
+		cmd.Flags().StringVar(&firewallExpression, "expression", "", "rules language expression the rule matches")
+		cmd.Flags().StringVar(&firewallAction, "action", "", "block, managed_challenge, js_challenge, challenge, log or skip")
+		cmd.Flags().StringVar(&firewallDescription, "description", "", "rule description")
+		cmd.Flags().BoolVar(&firewallEnabled, "enabled", true, "whether the rule is enabled")
+	}
+	_ = firewallRulesCreateCmd.MarkFlagRequired("expression")
+	_ = firewallRulesCreateCmd.MarkFlagRequired("action")
+
+	for _, cmd := range []*cobra.Command{firewallAccessCreateCmd, firewallAccessUpdateCmd} {
+		cmd.Flags().StringVar(&firewallMode, "mode", "", "block, managed_challenge, js_challenge, challenge or allow")
+		cmd.Flags().StringVar(&firewallNotes, "notes", "", "notes shown next to the rule")
+	}
+	_ = firewallAccessCreateCmd.MarkFlagRequired("mode")
+
+	firewallRulesCmd.AddCommand(firewallRulesListCmd, firewallRulesCreateCmd, firewallRulesUpdateCmd, firewallRulesDeleteCmd)
+	firewallAccessCmd.AddCommand(firewallAccessListCmd, firewallAccessCreateCmd, firewallAccessUpdateCmd, firewallAccessDeleteCmd)
+	firewallCmd.AddCommand(firewallRulesCmd, firewallAccessCmd)
+	rootCmd.AddCommand(firewallCmd)
+}
+
+// setupFirewall creates an API client and resolves --zone or --account-id
+// to a firewall scope and a name to report it by
+func setupFirewall(ctx context.Context) (*api.Client, api.Scope, string, error) {
+	if (firewallZone == "") == (firewallAccountID == "") {
+		return nil, api.Scope{}, "", fmt.Errorf("pass either --zone or --account-id")
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return nil, api.Scope{}, "", err
+	}
+
+	if firewallAccountID != "" {
+		return client, api.AccountScope(firewallAccountID), "account " + firewallAccountID, nil
+	}
+
+	zone, err := resolveZone(ctx, client, firewallZone)
+	if err != nil {
+		return nil, api.Scope{}, "", err
+	}
+	return client, api.ZoneScope(zone.ID), zone.Name, nil
+}
+
+func runFirewallRulesList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.ListFirewallRules(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rules)
+	}
+
+	if len(rules) == 0 {
+		infof("No custom rules configured for %s\n", name)
+		return nil
+	}
+
+	for i, rule := range rules {
+		fmt.Printf("%d. %s [%s, %s] (%s)\n   %s\n", i+1, rule.Description, rule.Action, enabledState(rule.Enabled), rule.ID, rule.Expression)
+	}
+	return nil
+}
+
+func runFirewallRulesCreate(cmd *cobra.Command, args []string) error {
+	if err := firewall.ValidateAction(firewallAction); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, err := client.CreateFirewallRule(ctx, scope, cloudflare.FirewallRule{
+		Description: firewallDescription,
+		Expression:  firewallExpression,
+		Action:      firewallAction,
+		Enabled:     firewallEnabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rule)
+	}
+	infof("✓ Created custom rule %s on %s\n", rule.ID, name)
+	return nil
+}
+
+func runFirewallRulesUpdate(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	if flags.Changed("action") {
+		if err := firewall.ValidateAction(firewallAction); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.ListFirewallRules(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	var rule *cloudflare.FirewallRule
+	for i := range rules {
+		if rules[i].ID == args[0] {
+			rule = &rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("no custom rule %s on %s", args[0], name)
+	}
+
+	if flags.Changed("expression") {
+		rule.Expression = firewallExpression
+	}
+	if flags.Changed("action") {
+		rule.Action = firewallAction
+	}
+	if flags.Changed("description") {
+		rule.Description = firewallDescription
+	}
+	if flags.Changed("enabled") {
+		rule.Enabled = firewallEnabled
+	}
+
+	if err := client.UpdateFirewallRule(ctx, scope, *rule); err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rule)
+	}
+	infof("✓ Updated custom rule %s on %s\n", rule.ID, name)
+	return nil
+}
+
+func runFirewallRulesDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteFirewallRule(ctx, scope, args[0]); err != nil {
+		return err
+	}
+
+	infof("✓ Deleted custom rule %s from %s\n", args[0], name)
+	return nil
+}
+
+func runFirewallAccessList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.ListAccessRules(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rules)
+	}
+
+	if len(rules) == 0 {
+		infof("No IP Access rules apply to %s\n", name)
+		return nil
+	}
+
+	for _, rule := range rules {
+		fmt.Printf("%-9s %-8s %-40s %-7s %s  %s\n", firewall.ModeLabel(rule.Mode), rule.Target, rule.Value, rule.Scope, rule.ID, rule.Notes)
+	}
+	return nil
+}
+
+func runFirewallAccessCreate(cmd *cobra.Command, args []string) error {
+	mode, err := firewall.ParseMode(firewallMode)
+	if err != nil {
+		return err
+	}
+	target, value, err := firewall.ParseTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, err := client.CreateAccessRule(ctx, scope, cloudflare.AccessRule{
+		Mode:   mode,
+		Target: target,
+		Value:  value,
+		Notes:  firewallNotes,
+	})
+	if err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rule)
+	}
+	infof("✓ %s %s %s on %s (%s)\n", firewall.ModeLabel(mode), target, value, name, rule.ID)
+	return nil
+}
+
+func runFirewallAccessUpdate(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	if !flags.Changed("mode") && !flags.Changed("notes") {
+		return fmt.Errorf("pass --mode or --notes")
+	}
+
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.ListAccessRules(ctx, scope)
+	if err != nil {
+		return err
+	}
+
+	var rule *cloudflare.AccessRule
+	for i := range rules {
+		if rules[i].ID == args[0] {
+			rule = &rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		return fmt.Errorf("no IP Access rule %s on %s", args[0], name)
+	}
+
+	if flags.Changed("mode") {
+		if rule.Mode, err = firewall.ParseMode(firewallMode); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("notes") {
+		rule.Notes = firewallNotes
+	}
+
+	if err := client.UpdateAccessRule(ctx, scope, *rule); err != nil {
+		return err
+	}
+
+	if firewallJSON {
+		return printJSON(rule)
+	}
+	infof("✓ Updated IP Access rule %s on %s\n", rule.ID, name)
+	return nil
+}
+
+func runFirewallAccessDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, scope, name, err := setupFirewall(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteAccessRule(ctx, scope, args[0]); err != nil {
+		return err
+	}
+
+	infof("✓ Deleted IP Access rule %s from %s\n", args[0], name)
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/session"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// loadConfig loads the configuration honouring the global --config and
+// --account flags
+func loadConfig() (*config.Config, error) {
+	setSudoUserEnv()
+
+	// Handle config file override
+	if configFile != "" {
+		os.Setenv("CFCTL_CONFIG", configFile)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override default account if specified
+	if accountName != "" {
+		cfg.Defaults.Account = accountName
+	}
+
+	return cfg, nil
+}
+
+// selectedAccount returns the account chosen with --account, falling back to
+// the configured default
+func selectedAccount(cfg *config.Config) (*cloudflare.Account, error) {
+	if accountName != "" {
+		return cfg.GetAccount(accountName)
+	}
+	return cfg.GetDefaultAccount()
+}
+
+// newClient creates an API client for the selected account
+func newClient(cfg *config.Config) (*api.Client, error) {
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return session.NewClient(cfg, account)
+}
+
+// setupClient loads the configuration and creates an API client in one step
+func setupClient() (*config.Config, *api.Client, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load configuration: %w", err)
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, client, nil
+}
+
+// resolveZone looks up a zone by name or ID
+func resolveZone(ctx context.Context, client *api.Client, nameOrID string) (*cloudflare.Zone, error) {
+	if nameOrID == "" {
+		return nil, fmt.Errorf("zone is required")
+	}
+	return client.FindZone(ctx, nameOrID)
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// infof prints progress output unless --quiet is set
+func infof(format string, args ...interface{}) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/incident"
+	"github.com/spf13/cobra"
+)
+
+var (
+	incidentDryRun bool
+	incidentJSON   bool
+
+	incidentCmd = &cobra.Command{
+		Use:   "incident",
+		Short: "Harden a zone during an attack and roll back afterwards",
+		Long: `Incident mode applies a hardened security profile to a zone and records
+the settings it replaced, so ending the incident restores them exactly.
+
+The profile is set in the "incident" section of the config file. Without
+one, incident mode turns on "I'm Under Attack" mode and the browser
+integrity check, and sets the challenge passage to 30 minutes. Disabled WAF
+custom rules listed under enable_rules are switched on as well.
+
+Examples:
+  # Show what would change, then start the incident
+  cfctl incident start example.com --dry-run
+  cfctl incident start example.com
+
+  # List zones in incident mode
+  cfctl incident status
+
+  # Restore the settings recorded when the incident started
+  cfctl incident end example.com`,
+	}
+
+	incidentStartCmd = &cobra.Command{
+		Use:   "start <zone>",
+		Short: "Snapshot a zone's security settings and apply the incident profile",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runIncidentStart,
+	}
+
+	incidentEndCmd = &cobra.Command{
+		Use:   "end <zone>",
+		Short: "Restore the settings recorded when the incident started",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runIncidentEnd,
+	}
+
+	incidentStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "List zones in incident mode",
+		Args:  cobra.NoArgs,
+		RunE:  runIncidentStatus,
+	}
+)
+
+func init() {
+	incidentStartCmd.Flags().BoolVar(&incidentDryRun, "dry-run", false, "show the changes without applying them")
+	incidentStatusCmd.Flags().BoolVar(&incidentJSON, "json", false, "output as JSON")
+
+	incidentCmd.AddCommand(incidentStartCmd, incidentEndCmd, incidentStatusCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
+
+func runIncidentStart(cmd *cobra.Command, args []string) error {
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	store, err := incident.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	profile := incident.ProfileFrom(cfg.Incident)
+	if incidentDryRun {
+		infof("Incident mode on %s would set:\n", zone.Name)
+		for _, id := range profile.SettingIDs() {
+			current, err := client.GetZoneSetting(ctx, zone.ID, id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("  %-20s %s → %v\n", id, incident.FormatValue(current), profile.Settings[id])
+		}
+		for _, rule := range profile.EnableRules {
+			fmt.Printf("  enable rule          %s\n", rule)
+		}
+		return nil
+	}
+
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := incident.Start(ctx, client, store, *zone, account.Name, profile)
+	if err != nil {
+		return err
+	}
+
+	infof("✓ Incident mode started on %s\n", zone.Name)
+	for _, change := range snapshot.Changes() {
+		infof("  %-20s %s → %s\n", change.Setting, change.From, change.To)
+	}
+	for _, rule := range snapshot.Rules {
+		infof("  enabled rule         %s\n", rule.Label())
+	}
+	infof("Run `cfctl incident end %s` to restore the previous settings\n", zone.Name)
+	return nil
+}
+
+func runIncidentEnd(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	store, err := incident.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	// Look the zone up in the snapshots first so no API call is needed
+	snapshots, err := store.List()
+	if err != nil {
+		return err
+	}
+	zoneID := ""
+	for _, s := range snapshots {
+		if s.Zone == args[0] || s.ZoneID == args[0] {
+			zoneID = s.ZoneID
+		}
+	}
+	if zoneID == "" {
+		return fmt.Errorf("%w on %s", incident.ErrNotActive, args[0])
+	}
+
+	snapshot, err := incident.End(context.Background(), client, store, zoneID)
+	if err != nil {
+		return fmt.Errorf("%w (the snapshot was kept; run the command again to retry)", err)
+	}
+
+	infof("✓ Incident mode ended on %s\n", snapshot.Zone)
+	for _, change := range snapshot.Changes() {
+		infof("  %-20s restored to %s\n", change.Setting, change.From)
+	}
+	for _, rule := range snapshot.Rules {
+		infof("  %-20s restored to disabled\n", rule.Label())
+	}
+	return nil
+}
+
+func runIncidentStatus(cmd *cobra.Command, args []string) error {
+	store, err := incident.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if incidentJSON {
+		return printJSON(snapshots)
+	}
+
+	if len(snapshots) == 0 {
+		infof("No zones are in incident mode\n")
+		return nil
+	}
+
+	for _, s := range snapshots {
+		fmt.Printf("%s since %s (account %s)\n", s.Zone, s.StartedAt.Local().Format("2006-01-02 15:04"), s.Account)
+		for _, change := range s.Changes() {
+			fmt.Printf("   %-20s %s → %s\n", change.Setting, change.From, change.To)
+		}
+		for _, rule := range s.Rules {
+			fmt.Printf("   enabled rule         %s\n", rule.Label())
+		}
+	}
+	return nil
+}
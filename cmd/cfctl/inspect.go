@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/inspect"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/internal/warmup"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectHeaders []string
+	inspectJSON    bool
+
+	inspectCmd = &cobra.Command{
+		Use:   "inspect <url>",
+		Short: "Show whether a URL is served from Cloudflare's cache",
+		Long: `Request a URL and explain its caching behaviour from the response headers
+(CF-Cache-Status, Age, CF-Ray, Cache-Control and cache tag headers).
+
+Note that the request itself may populate the cache; run it twice to see
+whether a MISS turns into a HIT.
+
+Examples:
+  cfctl inspect https://example.com/app.css
+  cfctl inspect https://example.com/ --header "Accept-Encoding: br" --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runInspect,
+	}
+)
+
+func init() {
+	inspectCmd.Flags().StringArrayVarP(&inspectHeaders, "header", "H", nil, "extra request header (\"Name: value\")")
+	inspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(inspectCmd)
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	url := args[0]
+	if err := utils.ValidateURL(url); err != nil {
+		return err
+	}
+
+	headers, err := warmup.ParseHeaders(inspectHeaders)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := inspect.URL(ctx, url, inspect.Options{Headers: headers})
+	if err != nil {
+		return err
+	}
+
+	if inspectJSON {
+		return printJSON(report)
+	}
+
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Printf("%-18s %s\n", label+":", value)
+		}
+	}
+
+	row("URL", report.URL)
+	row("HTTP status", fmt.Sprintf("%d", report.StatusCode))
+	row("CF-Cache-Status", report.CacheStatus)
+	if report.Age > 0 {
+		row("Age", (time.Duration(report.Age) * time.Second).String())
+	}
+	row("CF-Ray", report.Ray)
+	row("Data center", report.Colo)
+	row("Cache-Control", report.CacheControl)
+	row("CDN-Cache-Control", report.CDNCacheControl)
+	row("Expires", report.Expires)
+	row("Vary", report.Vary)
+	row("ETag", report.ETag)
+	row("Last-Modified", report.LastModified)
+	row("Cache tags", strings.Join(report.CacheTags, ", "))
+
+	fmt.Println()
+	if report.Cached {
+		fmt.Println("✓ Cached")
+	} else {
+		fmt.Println("✗ Not cached")
+	}
+	for _, reason := range report.Reasons {
+		fmt.Printf("  • %s\n", reason)
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/kv"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	kvAccountID  string
+	kvPrefix     string
+	kvCursor     string
+	kvLimit      int
+	kvAll        bool
+	kvFile       string
+	kvMetadata   string
+	kvTTL        string
+	kvExpiration string
+	kvJSON       bool
+	kvYes        bool
+
+	kvCmd = &cobra.Command{
+		Use:   "kv",
+		Short: "Browse and edit Workers KV namespaces",
+		Long: `Read and write the keys of Workers KV namespaces in an account.
+
+Namespaces can be given by ID or by title.
+
+Examples:
+  # Find a namespace and the keys under a prefix
+  cfctl kv namespaces
+  cfctl kv keys CONFIG --prefix feature/
+
+  # Read a value, then change it for a day with metadata
+  cfctl kv get CONFIG feature/beta
+  cfctl kv put CONFIG feature/beta '{"enabled":true}' --ttl 24h --metadata '{"owner":"web"}'
+
+  # Write many keys at once from a JSON file
+  cfctl kv bulk-put CONFIG flags.json`,
+	}
+
+	kvNamespacesCmd = &cobra.Command{
+		Use:   "namespaces",
+		Short: "List KV namespaces of an account",
+		Args:  cobra.NoArgs,
+		RunE:  runKVNamespaces,
+	}
+
+	kvKeysCmd = &cobra.Command{
+		Use:   "keys <namespace>",
+		Short: "List keys of a namespace, one page at a time unless --all is given",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runKVKeys,
+	}
+
+	kvGetCmd = &cobra.Command{
+		Use:   "get <namespace> <key>",
+		Short: "Print the value of a key",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runKVGet,
+	}
+
+	kvPutCmd = &cobra.Command{
+		Use:   "put <namespace> <key> [value]",
+		Short: "Write a value, given as an argument or with --file",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE:  runKVPut,
+	}
+
+	kvDeleteCmd = &cobra.Command{
+		Use:   "delete <namespace> <key>",
+		Short: "Delete a key and its value",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runKVDelete,
+	}
+
+	kvBulkPutCmd = &cobra.Command{
+		Use:   "bulk-put <namespace> <file>",
+		Short: `Write the pairs of a JSON file ("-" reads stdin)`,
+		Long: `Write many keys at once. The file is either an array in the format of
+the bulk API or an object mapping keys to values:
+
+  [{"key": "greeting", "value": "hello", "expiration_ttl": 3600, "metadata": {"lang": "en"}}]
+  {"greeting": "hello", "limits": {"rps": 10}}
+
+Values that aren't strings are stored as their JSON text.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runKVBulkPut,
+	}
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{kvNamespacesCmd, kvKeysCmd, kvGetCmd, kvPutCmd, kvDeleteCmd, kvBulkPutCmd} {
+		cmd.Flags().StringVar(&kvAccountID, "account-id", "", "Cloudflare account ID (default: the pinned account)")
+	}
+	for _, cmd := range []*cobra.Command{kvNamespacesCmd, kvKeysCmd, kvGetCmd} {
+		cmd.Flags().BoolVar(&kvJSON, "json", false, "output as JSON")
+	}
+	kvKeysCmd.Flags().StringVar(&kvPrefix, "prefix", "", "only keys starting with this prefix")
+	kvKeysCmd.Flags().StringVar(&kvCursor, "cursor", "", "continue a listing from the cursor of the previous page")
+	kvKeysCmd.Flags().IntVar(&kvLimit, "limit", 1000, "keys per page, 10 to 1000")
+	kvKeysCmd.Flags().BoolVar(&kvAll, "all", false, "list every page")
+	kvPutCmd.Flags().StringVar(&kvFile, "file", "", `read the value from a file ("-" reads stdin)`)
+	kvPutCmd.Flags().StringVar(&kvMetadata, "metadata", "", "JSON metadata stored with the key")
+	kvPutCmd.Flags().StringVar(&kvTTL, "ttl", "", "expire the key after this long (e.g. 90s, 12h, 7d)")
+	kvPutCmd.Flags().StringVar(&kvExpiration, "expiration", "", "expire the key at this time (Unix seconds or RFC 3339)")
+	kvDeleteCmd.Flags().BoolVarP(&kvYes, "yes", "y", false, "skip the confirmation prompt")
+
+	kvCmd.AddCommand(kvNamespacesCmd, kvKeysCmd, kvGetCmd, kvPutCmd, kvDeleteCmd, kvBulkPutCmd)
+	rootCmd.AddCommand(kvCmd)
+}
+
+// resolveNamespace finds a namespace of the account by ID or title
+func resolveNamespace(ctx context.Context, client *api.Client, accountID, idOrTitle string) (*cloudflare.KVNamespace, error) {
+	namespaces, err := client.ListKVNamespaces(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces {
+		if ns.ID == idOrTitle || ns.Title == idOrTitle {
+			return &ns, nil
+		}
+	}
+	return nil, fmt.Errorf("KV namespace not found: %s (see `cfctl kv namespaces`)", idOrTitle)
+}
+
+// setupKV creates an API client and resolves the namespace a command works on
+func setupKV(ctx context.Context, namespace string) (*api.Client, string, *cloudflare.KVNamespace, error) {
+	client, accountID, err := setupAccountClient(ctx, kvAccountID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	ns, err := resolveNamespace(ctx, client, accountID, namespace)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return client, accountID, ns, nil
+}
+
+func runKVNamespaces(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, kvAccountID)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := client.ListKVNamespaces(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if kvJSON {
+		return printJSON(namespaces)
+	}
+
+	if len(namespaces) == 0 {
+		infof("No KV namespaces in account %s\n", accountID)
+		return nil
+	}
+
+	for _, ns := range namespaces {
+		fmt.Printf("%s (%s)\n", ns.Title, ns.ID)
+	}
+	return nil
+}
+
+func runKVKeys(cmd *cobra.Command, args []string) error {
+	if kvLimit < 10 || kvLimit > 1000 {
+		return fmt.Errorf("--limit must be between 10 and 1000")
+	}
+
+	ctx := context.Background()
+	client, accountID, ns, err := setupKV(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	page, err := client.ListKVKeys(ctx, accountID, ns.ID, kvPrefix, kvCursor, kvLimit)
+	if err != nil {
+		return err
+	}
+	for kvAll && page.Cursor != "" {
+		next, err := client.ListKVKeys(ctx, accountID, ns.ID, kvPrefix, page.Cursor, kvLimit)
+		if err != nil {
+			return err
+		}
+		page.Keys = append(page.Keys, next.Keys...)
+		page.Cursor = next.Cursor
+	}
+
+	if kvJSON {
+		return printJSON(page)
+	}
+
+	if len(page.Keys) == 0 {
+		infof("No keys in %s\n", ns.Title)
+		return nil
+	}
+
+	now := time.Now()
+	for _, key := range page.Keys {
+		fmt.Println(key.Name)
+		if key.Expiration != 0 {
+			fmt.Printf("   expires %s\n", kv.FormatExpiration(key.Expiration, now))
+		}
+		if len(key.Metadata) > 0 {
+			fmt.Printf("   metadata %s\n", key.Metadata)
+		}
+	}
+	if page.Cursor != "" {
+		infof("More keys follow; continue with --cursor %s, or use --all\n", page.Cursor)
+	}
+	return nil
+}
+
+// kvEntry is the JSON output of "kv get"
+type kvEntry struct {
+	Key        string          `json:"key"`
+	Value      string          `json:"value"`
+	Base64     bool            `json:"base64,omitempty"` // the value is binary and base64 encoded
+	Expiration int64           `json:"expiration,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+}
+
+func runKVGet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, ns, err := setupKV(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	value, err := client.GetKVValue(ctx, accountID, ns.ID, args[1])
+	if err != nil {
+		return err
+	}
+
+	if !kvJSON {
+		// The value is printed as stored so it can be piped to a file
+		_, err := os.Stdout.Write(value)
+		return err
+	}
+
+	key, err := client.GetKVKey(ctx, accountID, ns.ID, args[1])
+	if err != nil {
+		return err
+	}
+	entry := kvEntry{Key: key.Name, Expiration: key.Expiration, Metadata: key.Metadata}
+	if kv.IsText(value) {
+		entry.Value = string(value)
+	} else {
+		entry.Value = base64.StdEncoding.EncodeToString(value)
+		entry.Base64 = true
+	}
+	return printJSON(entry)
+}
+
+func runKVPut(cmd *cobra.Command, args []string) error {
+	if err := kv.ValidateKey(args[1]); err != nil {
+		return err
+	}
+
+	var value []byte
+	switch {
+	case len(args) == 3 && kvFile != "":
+		return fmt.Errorf("give the value as an argument or with --file, not both")
+	case len(args) == 3:
+		value = []byte(args[2])
+	case kvFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read value: %w", err)
+		}
+		value = data
+	case kvFile != "":
+		data, err := os.ReadFile(kvFile)
+		if err != nil {
+			return fmt.Errorf("read value: %w", err)
+		}
+		value = data
+	default:
+		return fmt.Errorf("a value is required, as an argument or with --file")
+	}
+
+	var opts api.KVWriteOptions
+	if kvTTL != "" && kvExpiration != "" {
+		return fmt.Errorf("use --ttl or --expiration, not both")
+	}
+	if kvTTL != "" {
+		ttl, err := kv.ParseTTL(kvTTL)
+		if err != nil {
+			return err
+		}
+		opts.ExpirationTTL = ttl
+	}
+	if kvExpiration != "" {
+		expiration, err := kv.ParseExpiration(kvExpiration, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Expiration = expiration
+	}
+	if kvMetadata != "" {
+		metadata, err := kv.ParseMetadata(kvMetadata)
+		if err != nil {
+			return err
+		}
+		opts.Metadata = metadata
+	}
+
+	ctx := context.Background()
+	client, accountID, ns, err := setupKV(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.PutKVValue(ctx, accountID, ns.ID, args[1], value, opts); err != nil {
+		return err
+	}
+
+	infof("✓ Wrote %s to %s (%d bytes)\n", args[1], ns.Title, len(value))
+	return nil
+}
+
+func runKVDelete(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, ns, err := setupKV(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !kvYes {
+		fmt.Printf("Delete %s from %s? [y/N]: ", args[1], ns.Title)
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+			return fmt.Errorf("not confirmed; nothing was deleted")
+		}
+	}
+
+	if err := client.DeleteKVValue(ctx, accountID, ns.ID, args[1]); err != nil {
+		return err
+	}
+
+	infof("✓ Deleted %s from %s\n", args[1], ns.Title)
+	return nil
+}
+
+func runKVBulkPut(cmd *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if args[1] != "-" {
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read bulk file: %w", err)
+	}
+
+	pairs, err := kv.ParseBulk(data)
+	if err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return fmt.Errorf("the bulk file holds no pairs")
+	}
+
+	ctx := context.Background()
+	client, accountID, ns, err := setupKV(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	unsuccessful, err := client.BulkPutKV(ctx, accountID, ns.ID, pairs)
+	if err != nil {
+		return err
+	}
+	if len(unsuccessful) > 0 {
+		for _, key := range unsuccessful {
+			fmt.Fprintf(os.Stderr, "✗ %s\n", key)
+		}
+		return fmt.Errorf("%d of %d keys were not written", len(unsuccessful), len(pairs))
+	}
+
+	infof("✓ Wrote %d keys to %s\n", len(pairs), ns.Title)
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/siyamsarker/cfctl/internal/config"
 	"github.com/siyamsarker/cfctl/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -48,15 +47,8 @@ Documentation: https://github.com/siyamsarker/cfctl
 Report bugs: https://github.com/siyamsarker/cfctl/issues`,
 		Version: version,
 		Run: func(cmd *cobra.Command, args []string) {
-			setSudoUserEnv()
-
-			// Handle config file override
-			if configFile != "" {
-				os.Setenv("CFCTL_CONFIG", configFile)
-			}
-
 			// Load configuration
-			cfg, err := config.Load()
+			cfg, err := loadConfig()
 			if err != nil {
 				if !quiet {
 					fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
@@ -64,11 +56,6 @@ Report bugs: https://github.com/siyamsarker/cfctl/issues`,
 				os.Exit(1)
 			}
 
-			// Override default account if specified
-			if accountName != "" {
-				cfg.Defaults.Account = accountName
-			}
-
 			// Handle no-color flag (can be extended to disable colors in UI)
 			if noColor {
 				// Set environment variable that UI can check
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/certs"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	originHostnames []string
+	originValidity  string
+	originKeyType   string
+	originCertOut   string
+	originKeyOut    string
+	originForce     bool
+	originJSON      bool
+	originYes       bool
+
+	originCertCmd = &cobra.Command{
+		Use:   "origin-cert",
+		Short: "Issue, list and revoke Cloudflare Origin CA certificates",
+		Long: `Manage Origin CA certificates, which secure the connection between
+Cloudflare and your origin server with SSL mode "Full (strict)".
+
+The private key and certificate signing request are generated locally; only
+the CSR is sent to Cloudflare. The key is written readable by you alone.
+
+Examples:
+  # Issue a 15 year certificate for a domain and its subdomains
+  cfctl origin-cert create --hostnames example.com,*.example.com
+
+  # Issue an ECDSA certificate valid for one year to chosen files
+  cfctl origin-cert create --hostnames api.example.com --validity 1y \
+    --key-type ecdsa --cert /etc/ssl/api.pem --key /etc/ssl/api.key
+
+  # List the certificates issued for a zone, then revoke one
+  cfctl origin-cert list example.com
+  cfctl origin-cert revoke <certificate-id>`,
+	}
+
+	originCertCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Generate a key and CSR locally and have the Origin CA sign it",
+		Args:  cobra.NoArgs,
+		RunE:  runOriginCertCreate,
+	}
+
+	originCertListCmd = &cobra.Command{
+		Use:   "list <zone>",
+		Short: "List Origin CA certificates issued for a zone",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runOriginCertList,
+	}
+
+	originCertRevokeCmd = &cobra.Command{
+		Use:   "revoke <certificate-id>",
+		Short: "Revoke an Origin CA certificate",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runOriginCertRevoke,
+	}
+)
+
+func init() {
+	originCertCreateCmd.Flags().StringSliceVar(&originHostnames, "hostnames", nil, "hostnames the certificate covers, wildcards allowed (required)")
+	originCertCreateCmd.Flags().StringVar(&originValidity, "validity", "15y", "certificate lifetime: 7d, 30d, 90d, 1y, 2y, 3y or 15y")
+	originCertCreateCmd.Flags().StringVar(&originKeyType, "key-type", certs.KeyRSA, "private key type: rsa or ecdsa")
+	originCertCreateCmd.Flags().StringVar(&originCertOut, "cert", "", "certificate file to write (default: <hostname>.pem)")
+	originCertCreateCmd.Flags().StringVar(&originKeyOut, "key", "", "private key file to write (default: <hostname>.key)")
+	originCertCreateCmd.Flags().BoolVar(&originForce, "force", false, "overwrite existing certificate and key files")
+	originCertCreateCmd.Flags().BoolVar(&originJSON, "json", false, "output as JSON")
+	_ = originCertCreateCmd.MarkFlagRequired("hostnames")
+	originCertListCmd.Flags().BoolVar(&originJSON, "json", false, "output as JSON")
+	originCertRevokeCmd.Flags().BoolVarP(&originYes, "yes", "y", false, "skip the confirmation prompt")
+
+	originCertCmd.AddCommand(originCertCreateCmd, originCertListCmd, originCertRevokeCmd)
+	rootCmd.AddCommand(originCertCmd)
+}
+
+func runOriginCertCreate(cmd *cobra.Command, args []string) error {
+	if err := utils.ValidateHostnames(originHostnames); err != nil {
+		return err
+	}
+	validity, err := certs.ParseValidity(originValidity)
+	if err != nil {
+		return err
+	}
+	requestType, err := certs.RequestType(originKeyType)
+	if err != nil {
+		return err
+	}
+
+	certPath, keyPath := certs.DefaultPaths(originHostnames)
+	if originCertOut != "" {
+		certPath = originCertOut
+	}
+	if originKeyOut != "" {
+		keyPath = originKeyOut
+	}
+	// Check before anything is issued so a certificate is never orphaned
+	for _, path := range []string{certPath, keyPath} {
+		if err := certs.CheckWritable(path, originForce); err != nil {
+			return err
+		}
+	}
+
+	key, err := certs.GenerateKey(originKeyType)
+	if err != nil {
+		return fmt.Errorf("generate private key: %w", err)
+	}
+	keyPEM, err := certs.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	csr, err := certs.CreateCSR(key, originHostnames)
+	if err != nil {
+		return err
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	cert, err := client.CreateOriginCertificate(context.Background(), string(csr), originHostnames, requestType, validity)
+	if err != nil {
+		return err
+	}
+
+	if err := certs.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return fmt.Errorf("%w (revoke the unused certificate with `cfctl origin-cert revoke %s`)", err, cert.ID)
+	}
+	if err := certs.WriteFile(certPath, []byte(cert.Certificate), 0644); err != nil {
+		return fmt.Errorf("%w (revoke the unused certificate with `cfctl origin-cert revoke %s`)", err, cert.ID)
+	}
+
+	if originJSON {
+		// The PEM is in the certificate file
+		cert.Certificate = ""
+		return printJSON(map[string]interface{}{
+			"certificate": cert,
+			"cert_file":   certPath,
+			"key_file":    keyPath,
+		})
+	}
+
+	infof("✓ Issued Origin CA certificate %s for %s\n", cert.ID, strings.Join(cert.Hostnames, ", "))
+	if !cert.ExpiresOn.IsZero() {
+		infof("  Expires %s\n", cert.ExpiresOn.Local().Format("2006-01-02"))
+	}
+	infof("  Certificate: %s\n  Private key: %s (mode 0600)\n", certPath, keyPath)
+	return nil
+}
+
+func runOriginCertList(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	issued, err := client.ListOriginCertificates(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	if originJSON {
+		return printJSON(issued)
+	}
+
+	if len(issued) == 0 {
+		infof("No Origin CA certificates issued for %s\n", zone.Name)
+		return nil
+	}
+
+	now := time.Now()
+	for _, cert := range issued {
+		expires := "unknown expiry"
+		if !cert.ExpiresOn.IsZero() {
+			expires = fmt.Sprintf("expires %s (%d days)", cert.ExpiresOn.Local().Format("2006-01-02"), certs.DaysLeft(cert.ExpiresOn, now))
+		}
+		fmt.Printf("%s [%s] %s\n   %s\n", cert.ID, cert.RequestType, expires, strings.Join(cert.Hostnames, ", "))
+	}
+	return nil
+}
+
+func runOriginCertRevoke(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	if !originYes {
+		fmt.Printf("Revoking %s stops Cloudflare trusting origins that still use it.\nRevoke? [y/N]: ", args[0])
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+			return fmt.Errorf("not confirmed; nothing was revoked")
+		}
+	}
+
+	if err := client.RevokeOriginCertificate(context.Background(), args[0]); err != nil {
+		return err
+	}
+
+	infof("✓ Revoked Origin CA certificate %s\n", args[0])
+	return nil
+}
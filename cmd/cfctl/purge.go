@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/schedule"
+	"github.com/siyamsarker/cfctl/internal/sitemap"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/internal/warmup"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeZone       string
+	purgeURLs       []string
+	purgeHosts      []string
+	purgeTags       []string
+	purgePrefixes   []string
+	purgeEverything bool
+	purgeYes        bool
+	purgeAt         string
+	purgeCron       string
+	purgeSavePreset string
+
+	warmAfterPurge  bool
+	warmSitemap     string
+	warmConcurrency int
+	warmHeaders     []string
+	warmJSON        bool
+
+	purgeCmd = &cobra.Command{
+		Use:   "purge",
+		Short: "Purge cached content for a zone",
+		Long: `Purge cached content for a zone without launching the interactive UI.
+
+Exactly one purge type must be given. Flags can be repeated or take
+comma-separated values.
+
+Examples:
+  cfctl purge --zone example.com --url https://example.com/app.css
+  cfctl purge --zone example.com --tag header,footer
+  cfctl purge --zone example.com --everything --yes
+
+  # Purge URLs and warm them again afterwards
+  cfctl purge --zone example.com --url https://example.com/ --warm
+
+  # Save the purge as a preset for "cfctl purge preset homepage"
+  cfctl purge --zone example.com --tag homepage,hero --save-preset homepage
+
+  # Queue the purge for "cfctl scheduler run" instead of purging now
+  cfctl purge --zone example.com --tag homepage --at "2026-05-01 09:00"
+  cfctl purge --zone example.com --prefix example.com/news --cron "0 */6 * * *"`,
+		Args: cobra.NoArgs,
+		RunE: runPurge,
+	}
+)
+
+func init() {
+	purgeCmd.PersistentFlags().StringVarP(&purgeZone, "zone", "z", "", "zone name or ID (required)")
+	purgeCmd.Flags().StringSliceVar(&purgeURLs, "url", nil, "URLs to purge")
+	purgeCmd.Flags().StringSliceVar(&purgeHosts, "host", nil, "hostnames to purge")
+	purgeCmd.Flags().StringSliceVar(&purgeTags, "tag", nil, "cache tags to purge (Enterprise)")
+	purgeCmd.Flags().StringSliceVar(&purgePrefixes, "prefix", nil, "URL prefixes to purge")
+	purgeCmd.Flags().BoolVar(&purgeEverything, "everything", false, "purge all cached content")
+	purgeCmd.Flags().BoolVarP(&purgeYes, "yes", "y", false, "skip confirmation for --everything")
+	purgeCmd.Flags().StringVar(&purgeAt, "at", "", "schedule the purge for a time (RFC 3339, \"YYYY-MM-DD HH:MM\" or \"+30m\")")
+	purgeCmd.Flags().StringVar(&purgeSavePreset, "save-preset", "", "save the purge as a named preset after it succeeds")
+	purgeCmd.Flags().StringVar(&purgeCron, "cron", "", "schedule the purge on a cron expression (\"min hour dom month dow\")")
+
+	addWarmFlags(purgeCmd)
+
+	rootCmd.AddCommand(purgeCmd)
+}
+
+// addWarmFlags registers the post-purge warm-up flags on a purge command
+func addWarmFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&warmAfterPurge, "warm", false, "fetch purged URLs afterwards to warm the cache")
+	cmd.Flags().StringVar(&warmSitemap, "warm-sitemap", "", "also warm every URL listed in this sitemap")
+	cmd.Flags().IntVar(&warmConcurrency, "warm-concurrency", 0, "parallel warm-up requests (default from config)")
+	cmd.Flags().StringArrayVar(&warmHeaders, "warm-header", nil, "extra warm-up request header (\"Name: value\")")
+	cmd.Flags().BoolVar(&warmJSON, "warm-json", false, "print warm-up results as JSON")
+}
+
+// buildPurgeRequest turns the purge flags into a validated request
+func buildPurgeRequest() (cloudflare.PurgeRequest, error) {
+	var req cloudflare.PurgeRequest
+	kinds := 0
+
+	if len(purgeURLs) > 0 {
+		if err := utils.ValidateURLs(purgeURLs); err != nil {
+			return req, err
+		}
+		req.Files = purgeURLs
+		kinds++
+	}
+	if len(purgeHosts) > 0 {
+		if err := utils.ValidateHostnames(purgeHosts); err != nil {
+			return req, err
+		}
+		req.Hosts = purgeHosts
+		kinds++
+	}
+	if len(purgeTags) > 0 {
+		if err := utils.ValidateTags(purgeTags); err != nil {
+			return req, err
+		}
+		req.Tags = purgeTags
+		kinds++
+	}
+	if len(purgePrefixes) > 0 {
+		if err := utils.ValidatePrefixes(purgePrefixes); err != nil {
+			return req, err
+		}
+		req.Prefixes = purgePrefixes
+		kinds++
+	}
+	if purgeEverything {
+		if !purgeYes {
+			return req, fmt.Errorf("--everything clears the entire cache; pass --yes to confirm")
+		}
+		req.PurgeEverything = true
+		kinds++
+	}
+
+	switch kinds {
+	case 0:
+		return req, fmt.Errorf("specify one of --url, --host, --tag, --prefix or --everything")
+	case 1:
+		return req, nil
+	default:
+		return req, fmt.Errorf("only one purge type can be used per command")
+	}
+}
+
+func runPurge(cmd *cobra.Command, args []string) error {
+	req, err := buildPurgeRequest()
+	if err != nil {
+		return err
+	}
+
+	if purgeAt != "" || purgeCron != "" {
+		return schedulePurge(req)
+	}
+
+	if purgeSavePreset != "" {
+		if err := config.ValidatePresetName(purgeSavePreset); err != nil {
+			return err
+		}
+	}
+
+	if warmAfterPurge && len(req.Files) == 0 && warmSitemap == "" {
+		return fmt.Errorf("--warm needs --url targets or --warm-sitemap")
+	}
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, purgeZone)
+	if err != nil {
+		return err
+	}
+
+	if err := client.PurgeCache(ctx, zone.ID, req); err != nil {
+		return err
+	}
+	infof("✓ Cache purged for %s\n", zone.Name)
+
+	if purgeSavePreset != "" {
+		if err := cfg.SavePreset(presetFromRequest(purgeSavePreset, zone.Name, req)); err != nil {
+			return fmt.Errorf("save preset: %w", err)
+		}
+		infof("✓ Saved preset %s\n", purgeSavePreset)
+	}
+
+	if warmAfterPurge {
+		return runWarmup(ctx, cfg, req.Files)
+	}
+	return nil
+}
+
+// schedulePurge adds the purge to the schedule queue instead of running it
+func schedulePurge(req cloudflare.PurgeRequest) error {
+	if purgeAt != "" && purgeCron != "" {
+		return fmt.Errorf("--at and --cron cannot be combined")
+	}
+	if warmAfterPurge {
+		return fmt.Errorf("--warm cannot be used with scheduled purges")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return err
+	}
+
+	spec := purgejob.FromRequest(purgeZone, req)
+
+	var job schedule.Job
+	if purgeCron != "" {
+		job, err = schedule.NewRecurring(account.Name, spec, purgeCron)
+	} else {
+		var at time.Time
+		if at, err = schedule.ParseTime(purgeAt); err != nil {
+			return err
+		}
+		job, err = schedule.NewOnce(account.Name, spec, at)
+	}
+	if err != nil {
+		return err
+	}
+
+	queue, err := schedule.DefaultQueue()
+	if err != nil {
+		return err
+	}
+	if err := queue.Add(job); err != nil {
+		return err
+	}
+
+	infof("✓ Scheduled job %s: %s in %s, next run %s\n",
+		job.ID, spec.Summary(), spec.Zone, job.NextRun.Format("2006-01-02 15:04 MST"))
+	return nil
+}
+
+// runWarmup fetches the given URLs (plus any --warm-sitemap URLs) and reports
+// the CF-Cache-Status of each
+func runWarmup(ctx context.Context, cfg *config.Config, urls []string) error {
+	headers := make(map[string]string)
+	for k, v := range cfg.Warmup.Headers {
+		headers[k] = v
+	}
+	extra, err := warmup.ParseHeaders(warmHeaders)
+	if err != nil {
+		return err
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+
+	targets := append([]string{}, urls...)
+	if warmSitemap != "" {
+		entries, err := sitemap.Load(ctx, &http.Client{Timeout: 30 * time.Second}, warmSitemap)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, sitemap.URLs(entries)...)
+	}
+
+	concurrency := warmConcurrency
+	if concurrency == 0 {
+		concurrency = cfg.Warmup.Concurrency
+	}
+
+	infof("Warming %s...\n", utils.FormatCount(len(targets), "URL", "URLs"))
+	results := warmup.Warm(ctx, targets, warmup.Options{
+		Concurrency: concurrency,
+		Headers:     headers,
+		Timeout:     time.Duration(cfg.Warmup.Timeout) * time.Second,
+	})
+
+	if warmJSON {
+		return printJSON(results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+			fmt.Printf("  %-8s %s (%s)\n", "ERROR", r.URL, r.Error)
+		case !r.OK():
+			failed++
+			fmt.Printf("  %-8s %s (HTTP %d)\n", "FAILED", r.URL, r.StatusCode)
+		default:
+			status := r.CacheStatus
+			if status == "" {
+				status = "NONE"
+			}
+			fmt.Printf("  %-8s %s\n", status, r.URL)
+		}
+	}
+	infof("%s\n", warmup.FormatSummary(warmup.Summary(results)))
+
+	if failed > 0 {
+		return fmt.Errorf("%s could not be warmed", utils.FormatCount(failed, "URL", "URLs"))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/gitdiff"
+	"github.com/siyamsarker/cfctl/internal/pathmap"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gitFrom   string
+	gitTo     string
+	gitRepo   string
+	gitDryRun bool
+
+	pathBaseURL  string
+	pathIncludes []string
+	pathRewrites []string
+
+	purgeGitCmd = &cobra.Command{
+		Use:   "git",
+		Short: "Purge the URLs of files changed between two git revisions",
+		Long: `Purge the URLs of files changed between two git revisions.
+
+Changed paths are mapped to URLs using the "paths" section of the config
+file: paths must match one of the include globs (if any), then each rewrite
+rule is applied in order and the result is appended to the base URL. By
+default a leading "public/" is stripped and "index.html" maps to its
+directory.
+
+Examples:
+  cfctl purge git --zone example.com --from v1.2.0 --to v1.3.0 --base-url https://example.com
+  cfctl purge git --zone example.com --from HEAD~1 --include "dist/**" --rewrite "^dist/=>" --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runPurgeGit,
+	}
+)
+
+func init() {
+	purgeGitCmd.Flags().StringVar(&gitFrom, "from", "", "base revision (required)")
+	purgeGitCmd.Flags().StringVar(&gitTo, "to", "HEAD", "target revision")
+	purgeGitCmd.Flags().StringVar(&gitRepo, "repo", ".", "path to the git repository")
+	purgeGitCmd.Flags().BoolVar(&gitDryRun, "dry-run", false, "list the URLs that would be purged without purging")
+	addPathFlags(purgeGitCmd)
+	addWarmFlags(purgeGitCmd)
+	_ = purgeGitCmd.MarkFlagRequired("from")
+
+	purgeCmd.AddCommand(purgeGitCmd)
+}
+
+// addPathFlags registers the file path to URL mapping flags
+func addPathFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&pathBaseURL, "base-url", "", "public URL the files are served from (default from config)")
+	cmd.Flags().StringSliceVar(&pathIncludes, "include", nil, "only map paths matching these globs (replaces config)")
+	cmd.Flags().StringArrayVar(&pathRewrites, "rewrite", nil, "path rewrite \"regex=>replacement\" (replaces config rules)")
+}
+
+// newPathMapper builds a path mapper from the config, overridden by flags
+func newPathMapper(cfg *config.Config) (*pathmap.Mapper, error) {
+	settings := cfg.Paths
+
+	if pathBaseURL != "" {
+		settings.BaseURL = pathBaseURL
+	}
+	if settings.BaseURL == "" {
+		return nil, fmt.Errorf("--base-url is required (or set paths.base_url in the config)")
+	}
+	if len(pathIncludes) > 0 {
+		settings.Include = pathIncludes
+	}
+	if len(pathRewrites) > 0 {
+		settings.Rewrites = nil
+		for _, value := range pathRewrites {
+			rule, err := pathmap.ParseRewrite(value)
+			if err != nil {
+				return nil, err
+			}
+			settings.Rewrites = append(settings.Rewrites, rule)
+		}
+	}
+
+	return pathmap.New(settings.BaseURL, settings)
+}
+
+func runPurgeGit(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	mapper, err := newPathMapper(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	files, err := gitdiff.ChangedFiles(ctx, gitRepo, gitFrom, gitTo)
+	if err != nil {
+		return err
+	}
+
+	urls := mapper.URLs(files)
+	infof("%s changed between %s and %s, %s to purge\n",
+		utils.FormatCount(len(files), "file", "files"), gitFrom, gitTo,
+		utils.FormatCount(len(urls), "URL", "URLs"))
+	if len(urls) == 0 {
+		return nil
+	}
+
+	if gitDryRun {
+		for _, u := range urls {
+			fmt.Println(u)
+		}
+		return nil
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	zone, err := resolveZone(ctx, client, purgeZone)
+	if err != nil {
+		return err
+	}
+
+	req := cloudflare.PurgeRequest{Files: urls}
+	err = client.PurgeCacheBatched(ctx, zone.ID, req, func(done, total int) {
+		infof("  batch %d/%d purged\n", done, total)
+	})
+	if err != nil {
+		return err
+	}
+	infof("✓ Purged %s for %s\n", utils.FormatCount(len(urls), "URL", "URLs"), zone.Name)
+
+	if warmAfterPurge {
+		return runWarmup(ctx, cfg, urls)
+	}
+	return nil
+}
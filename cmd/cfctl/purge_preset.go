@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	presetJSON   bool
+	presetRemove bool
+
+	purgePresetCmd = &cobra.Command{
+		Use:   "preset [name]",
+		Short: "Run a saved purge preset",
+		Long: `Run a named purge preset. Presets are saved from the success screen of
+any purge in the interactive UI, or with "cfctl purge ... --save-preset".
+
+Without a name, the saved presets are listed.
+
+Examples:
+  cfctl purge preset
+  cfctl purge preset homepage-tags
+  cfctl purge preset homepage-tags --remove`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runPurgePreset,
+	}
+)
+
+func init() {
+	purgePresetCmd.Flags().BoolVar(&presetJSON, "json", false, "list presets as JSON")
+	purgePresetCmd.Flags().BoolVar(&presetRemove, "remove", false, "delete the preset instead of running it")
+	purgePresetCmd.Flags().BoolVarP(&purgeYes, "yes", "y", false, "skip confirmation for everything presets")
+	purgeCmd.AddCommand(purgePresetCmd)
+}
+
+func runPurgePreset(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("load configuration: %w", err)
+		}
+		return listPresets(cfg)
+	}
+
+	if presetRemove {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("load configuration: %w", err)
+		}
+		if err := cfg.RemovePreset(args[0]); err != nil {
+			return err
+		}
+		infof("✓ Removed preset %s\n", args[0])
+		return nil
+	}
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	preset, err := cfg.GetPreset(args[0])
+	if err != nil {
+		return err
+	}
+	if preset.Type == config.PresetEverything && !purgeYes {
+		return fmt.Errorf("preset %s purges everything in %s; pass --yes to confirm", preset.Name, preset.Zone)
+	}
+
+	spec := purgejob.FromPreset(*preset)
+	if purgeZone != "" {
+		spec.Zone = purgeZone
+	}
+	if err := spec.Validate(); err != nil {
+		return fmt.Errorf("preset %s: %w", preset.Name, err)
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, spec.Zone)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range spec.Requests() {
+		if err := client.PurgeCacheBatched(ctx, zone.ID, req, nil); err != nil {
+			return err
+		}
+	}
+	infof("✓ Purged %s for %s (preset %s)\n", preset.Describe(), zone.Name, preset.Name)
+	return nil
+}
+
+func listPresets(cfg *config.Config) error {
+	if presetJSON {
+		presets := cfg.Presets
+		if presets == nil {
+			presets = []config.Preset{}
+		}
+		return printJSON(presets)
+	}
+
+	if len(cfg.Presets) == 0 {
+		infof("No presets saved\n")
+		return nil
+	}
+	for _, p := range cfg.Presets {
+		fmt.Printf("%-24s  %-24s  %s\n", p.Name, p.Zone, p.Describe())
+	}
+	return nil
+}
+
+// presetFromRequest converts a single-type purge request into a preset
+func presetFromRequest(name, zone string, req cloudflare.PurgeRequest) config.Preset {
+	preset := config.Preset{Name: name, Zone: zone}
+	switch {
+	case len(req.Files) > 0:
+		preset.Type, preset.Targets = config.PresetURL, req.Files
+	case len(req.Hosts) > 0:
+		preset.Type, preset.Targets = config.PresetHostname, req.Hosts
+	case len(req.Tags) > 0:
+		preset.Type, preset.Targets = config.PresetTag, req.Tags
+	case len(req.Prefixes) > 0:
+		preset.Type, preset.Targets = config.PresetPrefix, req.Prefixes
+	case req.PurgeEverything:
+		preset.Type = config.PresetEverything
+	}
+	return preset
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/sitemap"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sitemapSince  string
+	sitemapGlobs  []string
+	sitemapDryRun bool
+
+	purgeSitemapCmd = &cobra.Command{
+		Use:   "sitemap <url-or-file>",
+		Short: "Purge every URL listed in a sitemap",
+		Long: `Purge every URL listed in a sitemap.xml. Sitemap indexes are followed and
+gzipped sitemaps are supported. URLs are purged in batches of 30.
+
+Examples:
+  cfctl purge sitemap https://example.com/sitemap.xml --zone example.com
+  cfctl purge sitemap ./public/sitemap.xml.gz --zone example.com --since 2024-05-01
+  cfctl purge sitemap https://example.com/sitemap.xml --zone example.com --glob "/blog/**" --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPurgeSitemap,
+	}
+)
+
+func init() {
+	purgeSitemapCmd.Flags().StringVar(&sitemapSince, "since", "", "only URLs with a lastmod on or after this date (YYYY-MM-DD)")
+	purgeSitemapCmd.Flags().StringSliceVar(&sitemapGlobs, "glob", nil, "only URLs whose path matches a glob (\"*\" within a segment, \"**\" across)")
+	purgeSitemapCmd.Flags().BoolVar(&sitemapDryRun, "dry-run", false, "list the URLs that would be purged without purging")
+	addWarmFlags(purgeSitemapCmd)
+
+	purgeCmd.AddCommand(purgeSitemapCmd)
+}
+
+func runPurgeSitemap(cmd *cobra.Command, args []string) error {
+	filter := sitemap.Filter{Globs: sitemapGlobs}
+	if sitemapSince != "" {
+		since, err := sitemap.ParseLastMod(sitemapSince)
+		if err != nil {
+			return fmt.Errorf("--since: expected YYYY-MM-DD")
+		}
+		filter.Since = since
+	}
+
+	ctx := context.Background()
+	entries, err := sitemap.Load(ctx, &http.Client{Timeout: 30 * time.Second}, args[0])
+	if err != nil {
+		return err
+	}
+
+	filtered, err := filter.Apply(entries)
+	if err != nil {
+		return err
+	}
+
+	urls := utils.Dedupe(sitemap.URLs(filtered))
+	for i, u := range urls {
+		if err := utils.ValidateURL(u); err != nil {
+			return fmt.Errorf("sitemap URL %d: %w", i+1, err)
+		}
+	}
+
+	infof("%s in sitemap, %d selected\n", utils.FormatCount(len(entries), "URL", "URLs"), len(urls))
+	if len(urls) == 0 {
+		return nil
+	}
+
+	if sitemapDryRun {
+		for _, u := range urls {
+			fmt.Println(u)
+		}
+		return nil
+	}
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	zone, err := resolveZone(ctx, client, purgeZone)
+	if err != nil {
+		return err
+	}
+
+	req := cloudflare.PurgeRequest{Files: urls}
+	err = client.PurgeCacheBatched(ctx, zone.ID, req, func(done, total int) {
+		infof("  batch %d/%d purged\n", done, total)
+	})
+	if err != nil {
+		return err
+	}
+	infof("✓ Purged %s for %s\n", utils.FormatCount(len(urls), "URL", "URLs"), zone.Name)
+
+	if warmAfterPurge {
+		return runWarmup(ctx, cfg, urls)
+	}
+	return nil
+}
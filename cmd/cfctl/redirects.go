@@ -0,0 +1,435 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/siyamsarker/cfctl/internal/redirects"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	redirectsJSON          bool
+	redirectsFile          string
+	redirectsStatus        int
+	redirectsPreserveQuery bool
+	redirectsPageRule      bool
+	redirectsDryRun        bool
+	redirectsAccountID     string
+	redirectsReplace       bool
+	redirectsEnable        bool
+	redirectsDescription   string
+
+	redirectsCmd = &cobra.Command{
+		Use:   "redirects",
+		Short: "Manage Page Rules, Single Redirects and Bulk Redirects",
+		Long: `Manage the redirects of a zone: forwarding Page Rules, Single Redirects
+(http_request_dynamic_redirect phase) and account-level Bulk Redirect lists.
+
+CSV files hold one mapping per row:
+
+  source,target[,status[,preserve_query_string]]
+
+Sources are a host and optional path (example.com/old), or a full URL to
+match one scheme only. Status defaults to 301.
+
+Examples:
+  # List Single Redirects and Page Rules
+  cfctl redirects list example.com
+
+  # Add a Single Redirect
+  cfctl redirects add example.com example.com/old https://example.com/new --status 308
+
+  # Create or update a Single Redirect per CSV row
+  cfctl redirects import example.com -f moves.csv --dry-run
+
+  # Load thousands of mappings into a Bulk Redirect list and turn it on
+  cfctl redirects bulk import site_moves -f moves.csv --replace --enable`,
+	}
+
+	redirectsListCmd = &cobra.Command{
+		Use:   "list <zone>",
+		Short: "List Single Redirects and Page Rules",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRedirectsList,
+	}
+
+	redirectsAddCmd = &cobra.Command{
+		Use:   "add <zone> <source> <target>",
+		Short: "Add a Single Redirect",
+		Args:  cobra.ExactArgs(3),
+		RunE:  runRedirectsAdd,
+	}
+
+	redirectsDeleteCmd = &cobra.Command{
+		Use:   "delete <zone> <rule-id>",
+		Short: "Delete a Single Redirect, or a Page Rule with --page-rule",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runRedirectsDelete,
+	}
+
+	redirectsImportCmd = &cobra.Command{
+		Use:   "import <zone>",
+		Short: "Create or update Single Redirects from a CSV file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRedirectsImport,
+	}
+
+	redirectsBulkCmd = &cobra.Command{
+		Use:   "bulk",
+		Short: "Manage account-level Bulk Redirect lists",
+	}
+
+	redirectsBulkListsCmd = &cobra.Command{
+		Use:   "lists",
+		Short: "List Bulk Redirect lists",
+		Args:  cobra.NoArgs,
+		RunE:  runRedirectsBulkLists,
+	}
+
+	redirectsBulkShowCmd = &cobra.Command{
+		Use:   "show <list>",
+		Short: "List the redirects of a Bulk Redirect list",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRedirectsBulkShow,
+	}
+
+	redirectsBulkImportCmd = &cobra.Command{
+		Use:   "import <list>",
+		Short: "Add redirects from a CSV file to a Bulk Redirect list, creating it if needed",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRedirectsBulkImport,
+	}
+)
+
+func init() {
+	redirectsListCmd.Flags().BoolVar(&redirectsJSON, "json", false, "output as JSON")
+	redirectsAddCmd.Flags().IntVar(&redirectsStatus, "status", redirects.DefaultStatus, "redirect status code (301, 302, 307 or 308)")
+	redirectsAddCmd.Flags().BoolVar(&redirectsPreserveQuery, "preserve-query", false, "keep the query string of the request")
+	redirectsDeleteCmd.Flags().BoolVar(&redirectsPageRule, "page-rule", false, "delete a Page Rule instead of a Single Redirect")
+	redirectsImportCmd.Flags().StringVarP(&redirectsFile, "file", "f", "", "CSV file to import (default: stdin)")
+	redirectsImportCmd.Flags().IntVar(&redirectsStatus, "status", redirects.DefaultStatus, "status code for rows without one")
+	redirectsImportCmd.Flags().BoolVar(&redirectsDryRun, "dry-run", false, "show the changes without applying them")
+
+	redirectsBulkCmd.PersistentFlags().StringVar(&redirectsAccountID, "account-id", "", "Cloudflare account of the lists (default: the pinned account)")
+	redirectsBulkListsCmd.Flags().BoolVar(&redirectsJSON, "json", false, "output as JSON")
+	redirectsBulkShowCmd.Flags().BoolVar(&redirectsJSON, "json", false, "output as JSON")
+	redirectsBulkImportCmd.Flags().StringVarP(&redirectsFile, "file", "f", "", "CSV file to import (default: stdin)")
+	redirectsBulkImportCmd.Flags().IntVar(&redirectsStatus, "status", redirects.DefaultStatus, "status code for rows without one")
+	redirectsBulkImportCmd.Flags().BoolVar(&redirectsReplace, "replace", false, "replace every redirect in the list instead of adding to it")
+	redirectsBulkImportCmd.Flags().BoolVar(&redirectsEnable, "enable", false, "add the account rule that applies the list if it's missing")
+	redirectsBulkImportCmd.Flags().StringVar(&redirectsDescription, "description", "", "description for a newly created list")
+
+	redirectsBulkCmd.AddCommand(redirectsBulkListsCmd, redirectsBulkShowCmd, redirectsBulkImportCmd)
+	redirectsCmd.AddCommand(redirectsListCmd, redirectsAddCmd, redirectsDeleteCmd, redirectsImportCmd, redirectsBulkCmd)
+	rootCmd.AddCommand(redirectsCmd)
+}
+
+func runRedirectsList(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.ListRedirectRules(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+	pageRules, err := client.ListPageRules(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	if redirectsJSON {
+		return printJSON(struct {
+			RedirectRules []cloudflare.RedirectRule `json:"redirect_rules"`
+			PageRules     []cloudflare.PageRule     `json:"page_rules"`
+		}{rules, pageRules})
+	}
+
+	if len(rules) == 0 && len(pageRules) == 0 {
+		infof("No redirects or Page Rules configured for %s\n", zone.Name)
+		return nil
+	}
+
+	if len(rules) > 0 {
+		fmt.Println("Single Redirects:")
+		for i, rule := range rules {
+			fmt.Printf("%d. %s → %s %d [%s] (%s)\n", i+1, redirects.RuleSource(rule), redirects.RuleTarget(rule), rule.StatusCode, enabledState(rule.Enabled), rule.ID)
+		}
+	}
+
+	if len(pageRules) > 0 {
+		if len(rules) > 0 {
+			fmt.Println()
+		}
+		fmt.Println("Page Rules:")
+		for i, rule := range pageRules {
+			state := enabledState(rule.Status == "active")
+			if target, status, ok := rule.Forwarding(); ok {
+				fmt.Printf("%d. %s → %s %d [%s] (%s)\n", i+1, rule.URL, target, status, state, rule.ID)
+				continue
+			}
+			fmt.Printf("%d. %s: %s [%s] (%s)\n", i+1, rule.URL, pageRuleActions(rule), state, rule.ID)
+		}
+	}
+	return nil
+}
+
+func runRedirectsAdd(cmd *cobra.Command, args []string) error {
+	mapping := cloudflare.BulkRedirect{
+		SourceURL:           args[1],
+		TargetURL:           args[2],
+		StatusCode:          redirectsStatus,
+		PreserveQueryString: redirectsPreserveQuery,
+	}
+	if err := redirects.Validate(mapping); err != nil {
+		return err
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.CreateRedirectRule(ctx, zone.ID, redirects.Rule(mapping)); err != nil {
+		return err
+	}
+
+	infof("✓ Redirecting %s to %s (%d)\n", mapping.SourceURL, mapping.TargetURL, mapping.StatusCode)
+	return nil
+}
+
+func runRedirectsDelete(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if redirectsPageRule {
+		if err := client.DeletePageRule(ctx, zone.ID, args[1]); err != nil {
+			return err
+		}
+		infof("✓ Deleted Page Rule %s\n", args[1])
+		return nil
+	}
+
+	if err := client.DeleteRedirectRule(ctx, zone.ID, args[1]); err != nil {
+		return err
+	}
+	infof("✓ Deleted redirect rule %s\n", args[1])
+	return nil
+}
+
+func runRedirectsImport(cmd *cobra.Command, args []string) error {
+	mappings, err := readRedirectsCSV()
+	if err != nil {
+		return err
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.ListRedirectRules(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	create, update := redirects.Merge(existing, mappings)
+	if len(create) == 0 && len(update) == 0 {
+		infof("All %d redirects are already in place on %s\n", len(mappings), zone.Name)
+		return nil
+	}
+
+	if redirectsDryRun {
+		for _, rule := range create {
+			fmt.Printf("+ %s → %s %d\n", redirects.RuleSource(rule), rule.TargetURL, rule.StatusCode)
+		}
+		for _, rule := range update {
+			fmt.Printf("~ %s → %s %d\n", redirects.RuleSource(rule), rule.TargetURL, rule.StatusCode)
+		}
+		infof("Dry run: %d to create, %d to update on %s\n", len(create), len(update), zone.Name)
+		return nil
+	}
+
+	for _, rule := range create {
+		if err := client.CreateRedirectRule(ctx, zone.ID, rule); err != nil {
+			return fmt.Errorf("%s: %w", redirects.RuleSource(rule), err)
+		}
+	}
+	for _, rule := range update {
+		if err := client.UpdateRedirectRule(ctx, zone.ID, rule); err != nil {
+			return fmt.Errorf("%s: %w", redirects.RuleSource(rule), err)
+		}
+	}
+
+	infof("✓ Created %d and updated %d redirects on %s\n", len(create), len(update), zone.Name)
+	return nil
+}
+
+func runRedirectsBulkLists(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, redirectsAccountID)
+	if err != nil {
+		return err
+	}
+
+	lists, err := client.ListBulkRedirectLists(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if redirectsJSON {
+		return printJSON(lists)
+	}
+
+	if len(lists) == 0 {
+		infof("No Bulk Redirect lists in account %s\n", accountID)
+		return nil
+	}
+
+	for _, l := range lists {
+		fmt.Printf("%s  %d redirects  %s\n", l.Name, l.NumItems, l.Description)
+	}
+	return nil
+}
+
+func runRedirectsBulkShow(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, redirectsAccountID)
+	if err != nil {
+		return err
+	}
+
+	list, err := client.FindBulkRedirectList(ctx, accountID, args[0])
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		return fmt.Errorf("no Bulk Redirect list named %s", args[0])
+	}
+
+	items, err := client.ListBulkRedirects(ctx, accountID, list.ID)
+	if err != nil {
+		return err
+	}
+
+	if redirectsJSON {
+		return printJSON(items)
+	}
+
+	if len(items) == 0 {
+		infof("%s has no redirects\n", list.Name)
+		return nil
+	}
+
+	for _, r := range items {
+		fmt.Printf("%s → %s %d\n", r.SourceURL, r.TargetURL, r.StatusCode)
+	}
+	return nil
+}
+
+func runRedirectsBulkImport(cmd *cobra.Command, args []string) error {
+	mappings, err := readRedirectsCSV()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, redirectsAccountID)
+	if err != nil {
+		return err
+	}
+
+	list, err := client.FindBulkRedirectList(ctx, accountID, args[0])
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		list, err = client.CreateBulkRedirectList(ctx, accountID, args[0], redirectsDescription)
+		if err != nil {
+			return err
+		}
+		infof("✓ Created Bulk Redirect list %s\n", list.Name)
+	}
+
+	if redirectsReplace {
+		err = client.ReplaceBulkRedirects(ctx, accountID, list.ID, mappings)
+	} else {
+		err = client.AddBulkRedirects(ctx, accountID, list.ID, mappings)
+	}
+	if err != nil {
+		return err
+	}
+	infof("✓ Imported %d redirects into %s\n", len(mappings), list.Name)
+
+	if redirectsEnable {
+		added, err := client.EnableBulkRedirectList(ctx, accountID, list.Name)
+		if err != nil {
+			return err
+		}
+		if added {
+			infof("✓ Enabled %s for the account\n", list.Name)
+		}
+	}
+	return nil
+}
+
+// readRedirectsCSV parses the mappings of --file, or stdin
+func readRedirectsCSV() ([]cloudflare.BulkRedirect, error) {
+	var r io.Reader = os.Stdin
+	if redirectsFile != "" && redirectsFile != "-" {
+		f, err := os.Open(redirectsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redirects: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return redirects.ParseCSV(r, redirectsStatus)
+}
+
+func pageRuleActions(rule cloudflare.PageRule) string {
+	ids := make([]string, len(rule.Actions))
+	for i, a := range rule.Actions {
+		ids[i] = a.ID
+	}
+	return strings.Join(ids, ", ")
+}
+
+func enabledState(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
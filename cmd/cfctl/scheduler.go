@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/history"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/schedule"
+	"github.com/siyamsarker/cfctl/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	schedulerInterval   time.Duration
+	schedulerOnce       bool
+	schedulerAttempts   int
+	schedulerRetryDelay time.Duration
+	schedulerJSON       bool
+	schedulerLimit      int
+
+	schedulerCmd = &cobra.Command{
+		Use:   "scheduler",
+		Short: "Run and manage scheduled purges",
+		Long: `Run and manage purges queued with "cfctl purge --at/--cron" or from the
+purge screens of the interactive UI.
+
+Jobs are stored in schedule.json next to the config file, and every run
+is recorded in history.jsonl.`,
+	}
+
+	schedulerRunCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Execute scheduled purges as they become due",
+		Long: `Check the schedule queue every --interval and execute due jobs, using the
+account each job was scheduled with. One-off jobs are removed after they
+run; cron jobs move on to their next run.
+
+Examples:
+  cfctl scheduler run
+  cfctl scheduler run --once   # run due jobs and exit, e.g. from system cron`,
+		Args: cobra.NoArgs,
+		RunE: runScheduler,
+	}
+
+	schedulerListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled purges",
+		Args:  cobra.NoArgs,
+		RunE:  runSchedulerList,
+	}
+
+	schedulerRemoveCmd = &cobra.Command{
+		Use:   "remove <job-id>",
+		Short: "Remove a scheduled purge",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSchedulerRemove,
+	}
+
+	schedulerHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Show recent scheduled purge results",
+		Args:  cobra.NoArgs,
+		RunE:  runSchedulerHistory,
+	}
+)
+
+func init() {
+	schedulerRunCmd.Flags().DurationVar(&schedulerInterval, "interval", 30*time.Second, "how often to check for due jobs")
+	schedulerRunCmd.Flags().BoolVar(&schedulerOnce, "once", false, "run due jobs once and exit")
+	schedulerRunCmd.Flags().IntVar(&schedulerAttempts, "attempts", 3, "attempts per job before the run is marked failed")
+	schedulerRunCmd.Flags().DurationVar(&schedulerRetryDelay, "retry-delay", 2*time.Second, "delay before the first retry (doubles each time)")
+
+	schedulerListCmd.Flags().BoolVar(&schedulerJSON, "json", false, "output as JSON")
+	schedulerHistoryCmd.Flags().BoolVar(&schedulerJSON, "json", false, "output as JSON")
+	schedulerHistoryCmd.Flags().IntVar(&schedulerLimit, "limit", 20, "number of entries to show (0 for all)")
+
+	schedulerCmd.AddCommand(schedulerRunCmd, schedulerListCmd, schedulerRemoveCmd, schedulerHistoryCmd)
+	rootCmd.AddCommand(schedulerCmd)
+}
+
+func runScheduler(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	queue, err := schedule.DefaultQueue()
+	if err != nil {
+		return err
+	}
+	hist, err := history.Default()
+	if err != nil {
+		return err
+	}
+
+	logger := log.New(os.Stderr, "cfctl scheduler: ", log.LstdFlags)
+	retry := purgejob.Retry{Attempts: schedulerAttempts, Delay: schedulerRetryDelay}
+	clients := make(map[string]*api.Client)
+
+	run := func(ctx context.Context, job schedule.Job) error {
+		attempts, err := executeScheduledJob(ctx, cfg, clients, job, retry)
+
+		entry := history.Entry{
+			Source:   "scheduler",
+			JobID:    job.ID,
+			Account:  job.Account,
+			Zone:     job.Spec.Zone,
+			Summary:  job.Spec.Summary(),
+			Status:   history.StatusSucceeded,
+			Attempts: attempts,
+		}
+		if err != nil {
+			entry.Status = history.StatusFailed
+			entry.Error = err.Error()
+			logger.Printf("job %s failed after %d attempt(s): %v", job.ID, attempts, err)
+		} else {
+			logger.Printf("job %s purged %s in %s", job.ID, job.Spec.Summary(), job.Spec.Zone)
+		}
+		if herr := hist.Append(entry); herr != nil {
+			logger.Printf("record history: %v", herr)
+		}
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if schedulerOnce {
+		_, err := schedule.RunDue(ctx, queue, time.Now(), run)
+		return err
+	}
+
+	logger.Printf("watching %s every %s", queue.Path(), schedulerInterval)
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := schedule.RunDue(ctx, queue, time.Now(), run); err != nil && ctx.Err() == nil {
+			logger.Printf("%v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Printf("shutting down")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// executeScheduledJob runs a job with a client for the account it was
+// scheduled with, caching clients between runs
+func executeScheduledJob(ctx context.Context, cfg *config.Config, clients map[string]*api.Client, job schedule.Job, retry purgejob.Retry) (int, error) {
+	client, ok := clients[job.Account]
+	if !ok {
+		account, err := cfg.GetDefaultAccount()
+		if job.Account != "" {
+			account, err = cfg.GetAccount(job.Account)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if client, err = session.NewClient(cfg, account); err != nil {
+			return 0, err
+		}
+		clients[job.Account] = client
+	}
+	return purgejob.Execute(ctx, client, job.Spec, retry)
+}
+
+func runSchedulerList(cmd *cobra.Command, args []string) error {
+	queue, err := schedule.DefaultQueue()
+	if err != nil {
+		return err
+	}
+	jobs, err := queue.List()
+	if err != nil {
+		return err
+	}
+
+	if schedulerJSON {
+		if jobs == nil {
+			jobs = []schedule.Job{}
+		}
+		return printJSON(jobs)
+	}
+
+	if len(jobs) == 0 {
+		infof("No scheduled purges\n")
+		return nil
+	}
+	for _, job := range jobs {
+		fmt.Printf("%-12s  %-16s  %-24s  %-20s  %s\n",
+			job.ID,
+			job.NextRun.Local().Format("2006-01-02 15:04"),
+			job.Spec.Zone,
+			job.Spec.Summary(),
+			job.Describe())
+	}
+	return nil
+}
+
+func runSchedulerRemove(cmd *cobra.Command, args []string) error {
+	queue, err := schedule.DefaultQueue()
+	if err != nil {
+		return err
+	}
+	job, err := queue.Remove(args[0])
+	if err != nil {
+		return err
+	}
+	infof("✓ Removed job %s (%s in %s)\n", job.ID, job.Spec.Summary(), job.Spec.Zone)
+	return nil
+}
+
+func runSchedulerHistory(cmd *cobra.Command, args []string) error {
+	hist, err := history.Default()
+	if err != nil {
+		return err
+	}
+	entries, err := hist.Read(schedulerLimit)
+	if err != nil {
+		return err
+	}
+
+	if schedulerJSON {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		return printJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		infof("No purge history\n")
+		return nil
+	}
+	for _, e := range entries {
+		line := fmt.Sprintf("%s  %-9s  %-24s  %s",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Status, e.Zone, e.Summary)
+		if e.Error != "" {
+			line += "  (" + e.Error + ")"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
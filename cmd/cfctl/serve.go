@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+// webhookSecretEnv holds the shared secret used to verify webhook signatures
+const webhookSecretEnv = "CFCTL_WEBHOOK_SECRET"
+
+var (
+	serveAddr            string
+	serveZone            string
+	serveAllowEverything bool
+	serveQueueSize       int
+	serveAttempts        int
+	serveRetryDelay      time.Duration
+
+	serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Run an HTTP server that purges cache from signed webhooks",
+		Long: `Run an HTTP server that accepts purge webhooks, for systems such as a
+CMS that can send webhooks but cannot call Cloudflare directly.
+
+Requests must be signed with the shared secret from $` + webhookSecretEnv + `:
+the ` + webhook.SignatureHeader + ` header carries "t=<unix seconds>,sha256=<hex>",
+where the hex is the HMAC-SHA256 of the Unix time, a ".", and the request
+body. Requests signed more than five minutes from the server's clock are
+rejected so captured requests can't be replayed.
+
+Endpoints:
+  POST /v1/purge      queue a purge, e.g.
+                      {"zone": "example.com", "urls": [...], "tags": [...], "prefixes": [...], "hosts": [...]}
+  GET  /v1/jobs/{id}  status of a queued purge
+  GET  /healthz       health and queue statistics
+
+Jobs run one at a time, in batches of 30, and failed purges are retried
+with exponential backoff.
+
+Examples:
+  CFCTL_WEBHOOK_SECRET=... cfctl serve --addr :8787 --zone example.com`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8787", "address to listen on")
+	serveCmd.Flags().StringVarP(&serveZone, "zone", "z", "", "zone used when a payload doesn't name one")
+	serveCmd.Flags().BoolVar(&serveAllowEverything, "allow-everything", false, "accept purge-everything payloads")
+	serveCmd.Flags().IntVar(&serveQueueSize, "queue-size", 100, "maximum number of queued jobs")
+	serveCmd.Flags().IntVar(&serveAttempts, "attempts", 3, "attempts per job before it is marked failed")
+	serveCmd.Flags().DurationVar(&serveRetryDelay, "retry-delay", 2*time.Second, "delay before the first retry (doubles each time)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	secret := os.Getenv(webhookSecretEnv)
+	if secret == "" {
+		return fmt.Errorf("%s must be set to the webhook secret", webhookSecretEnv)
+	}
+
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	logger := log.New(os.Stderr, "cfctl serve: ", log.LstdFlags)
+	srv, err := webhook.New(client, webhook.Options{
+		Secret:          []byte(secret),
+		DefaultZone:     serveZone,
+		AllowEverything: serveAllowEverything,
+		QueueSize:       serveQueueSize,
+		Retry:           purgejob.Retry{Attempts: serveAttempts, Delay: serveRetryDelay},
+		Logger:          logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go srv.Run(ctx)
+
+	httpServer := &http.Server{
+		Addr:              serveAddr,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+	logger.Printf("listening on %s", serveAddr)
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	case <-ctx.Done():
+		logger.Printf("shutting down")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
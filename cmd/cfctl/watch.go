@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/siyamsarker/cfctl/internal/ui"
+	"github.com/siyamsarker/cfctl/internal/watcher"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchZone     string
+	watchDebounce time.Duration
+	watchDryRun   bool
+	watchPlain    bool
+
+	watchCmd = &cobra.Command{
+		Use:   "watch <dir>",
+		Short: "Purge URLs automatically when files in a directory change",
+		Long: `Watch a build output directory and purge the URLs of changed files.
+
+Changes are collected until the directory has been quiet for the debounce
+interval, then mapped to URLs with the "paths" settings (relative to <dir>)
+and purged in batches.
+
+Examples:
+  cfctl watch ./dist --zone staging.example.com --base-url https://staging.example.com
+  cfctl watch ./public --zone staging.example.com --debounce 2s --plain`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWatch,
+	}
+)
+
+func init() {
+	watchCmd.Flags().StringVarP(&watchZone, "zone", "z", "", "zone name or ID (required)")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", 500*time.Millisecond, "quiet period before changes are purged")
+	watchCmd.Flags().BoolVar(&watchDryRun, "dry-run", false, "log mapped URLs without purging")
+	watchCmd.Flags().BoolVar(&watchPlain, "plain", false, "print plain log lines instead of the interactive view")
+	addPathFlags(watchCmd)
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	root := args[0]
+
+	cfg, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	mapper, err := newPathMapper(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	zone, err := resolveZone(ctx, client, watchZone)
+	if err != nil {
+		return err
+	}
+
+	w, err := watcher.New(root, watchDebounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	batches := make(chan []string)
+	errs := make(chan error)
+	go w.Run(ctx, batches, errs)
+
+	purge := func(ctx context.Context, files []string) ([]string, error) {
+		urls := mapper.URLs(files)
+		if len(urls) == 0 || watchDryRun {
+			return urls, nil
+		}
+		return urls, client.PurgeCacheBatched(ctx, zone.ID, cloudflare.PurgeRequest{Files: urls}, nil)
+	}
+
+	if watchPlain {
+		infof("Watching %s for changes (Ctrl+C to stop)\n", root)
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case err := <-errs:
+				fmt.Println(ui.FormatWatchEntry(ui.WatchEntry{Time: time.Now(), Err: err}))
+			case files := <-batches:
+				urls, err := purge(ctx, files)
+				fmt.Println(ui.FormatWatchEntry(ui.WatchEntry{Time: time.Now(), Files: files, URLs: urls, Err: err}))
+			}
+		}
+	}
+
+	p := tea.NewProgram(
+		ui.NewWatchModel(*zone, root, batches, errs, purge),
+		tea.WithAltScreen(),
+		tea.WithContext(ctx),
+	)
+	if _, err := p.Run(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/siyamsarker/cfctl/internal/workers"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	workersAccountID         string
+	workersCompatibilityDate string
+	workersScript            string
+	workersPattern           string
+	workersJSON              bool
+
+	workersCmd = &cobra.Command{
+		Use:   "workers",
+		Short: "Deploy Workers scripts and manage Worker routes",
+		Long: `Deploy Workers scripts to an account and route zone traffic to them.
+
+Scripts are uploaded in ES module format: the main module plus any modules
+it imports, such as WebAssembly or text files. Bindings and secrets of an
+existing script are kept when new code is deployed.
+
+Examples:
+  # List the account's scripts
+  cfctl workers scripts
+
+  # Deploy a bundle built into dist/
+  cfctl workers deploy edge-api dist/index.js dist/lib/add.wasm
+
+  # Send API traffic to the script
+  cfctl workers routes add example.com "example.com/api/*" --script edge-api
+  cfctl workers routes list example.com`,
+	}
+
+	workersScriptsCmd = &cobra.Command{
+		Use:   "scripts",
+		Short: "List Workers scripts of an account",
+		Args:  cobra.NoArgs,
+		RunE:  runWorkersScripts,
+	}
+
+	workersDeployCmd = &cobra.Command{
+		Use:   "deploy <script-name> <main-module> [module...]",
+		Short: "Upload a script bundle, creating or replacing the script",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  runWorkersDeploy,
+	}
+
+	workersRoutesCmd = &cobra.Command{
+		Use:   "routes",
+		Short: "Manage Worker routes of a zone",
+	}
+
+	workersRoutesListCmd = &cobra.Command{
+		Use:   "list <zone>",
+		Short: "List Worker routes",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkersRoutesList,
+	}
+
+	workersRoutesAddCmd = &cobra.Command{
+		Use:   "add <zone> <pattern>",
+		Short: "Route requests matching a pattern to a script (no --script disables Workers)",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runWorkersRoutesAdd,
+	}
+
+	workersRoutesUpdateCmd = &cobra.Command{
+		Use:   "update <zone> <route-id>",
+		Short: "Change a route; only the given flags are changed",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runWorkersRoutesUpdate,
+	}
+
+	workersRoutesDeleteCmd = &cobra.Command{
+		Use:   "delete <zone> <route-id>",
+		Short: "Delete a Worker route",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runWorkersRoutesDelete,
+	}
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{workersScriptsCmd, workersDeployCmd} {
+		cmd.Flags().StringVar(&workersAccountID, "account-id", "", "Cloudflare account ID (default: the pinned account)")
+	}
+	for _, cmd := range []*cobra.Command{workersScriptsCmd, workersDeployCmd, workersRoutesListCmd, workersRoutesAddCmd} {
+		cmd.Flags().BoolVar(&workersJSON, "json", false, "output as JSON")
+	}
+	workersDeployCmd.Flags().StringVar(&workersCompatibilityDate, "compatibility-date", "", "Workers runtime compatibility date, YYYY-MM-DD (default: today)")
+	for _, cmd := range []*cobra.Command{workersRoutesAddCmd, workersRoutesUpdateCmd} {
+		cmd.Flags().StringVar(&workersScript, "script", "", "script the route runs; empty disables Workers for the pattern")
+	}
+	workersRoutesUpdateCmd.Flags().StringVar(&workersPattern, "pattern", "", "new route pattern")
+
+	workersRoutesCmd.AddCommand(workersRoutesListCmd, workersRoutesAddCmd, workersRoutesUpdateCmd, workersRoutesDeleteCmd)
+	workersCmd.AddCommand(workersScriptsCmd, workersDeployCmd, workersRoutesCmd)
+	rootCmd.AddCommand(workersCmd)
+}
+
+func runWorkersScripts(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, workersAccountID)
+	if err != nil {
+		return err
+	}
+
+	scripts, err := client.ListWorkerScripts(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if workersJSON {
+		return printJSON(scripts)
+	}
+
+	if len(scripts) == 0 {
+		infof("No Workers scripts in account %s\n", accountID)
+		return nil
+	}
+
+	for _, script := range scripts {
+		fmt.Printf("%s (modified %s)\n", script.ID, script.ModifiedOn.Local().Format("2006-01-02 15:04"))
+		if len(script.Handlers) > 0 {
+			fmt.Printf("   handlers: %s\n", strings.Join(script.Handlers, ", "))
+		}
+	}
+	return nil
+}
+
+func runWorkersDeploy(cmd *cobra.Command, args []string) error {
+	bundle, err := workers.LoadBundle(args[1], args[2:], workersCompatibilityDate)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, workersAccountID)
+	if err != nil {
+		return err
+	}
+
+	script, err := client.UploadWorkerScript(ctx, accountID, args[0], bundle)
+	if err != nil {
+		return err
+	}
+
+	if workersJSON {
+		return printJSON(script)
+	}
+
+	infof("✓ Deployed %s (%d modules, %d bytes, compatibility date %s)\n", script.ID, len(bundle.Modules), workers.Size(bundle), bundle.CompatibilityDate)
+	return nil
+}
+
+func runWorkersRoutesList(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	routes, err := client.ListWorkerRoutes(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	if workersJSON {
+		return printJSON(routes)
+	}
+
+	if len(routes) == 0 {
+		infof("No Worker routes configured for %s\n", zone.Name)
+		return nil
+	}
+
+	for _, route := range routes {
+		fmt.Printf("%s → %s (%s)\n", route.Pattern, routeScript(route), route.ID)
+	}
+	return nil
+}
+
+func runWorkersRoutesAdd(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	if err := workers.ValidateRoutePattern(args[1], zone.Name); err != nil {
+		return err
+	}
+
+	route, err := client.CreateWorkerRoute(ctx, zone.ID, cloudflare.WorkerRoute{
+		Pattern: args[1],
+		Script:  workersScript,
+	})
+	if err != nil {
+		return err
+	}
+
+	if workersJSON {
+		return printJSON(route)
+	}
+
+	infof("✓ Added route %s → %s (%s)\n", route.Pattern, routeScript(*route), route.ID)
+	return nil
+}
+
+func runWorkersRoutesUpdate(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	routes, err := client.ListWorkerRoutes(ctx, zone.ID)
+	if err != nil {
+		return err
+	}
+	var route *cloudflare.WorkerRoute
+	for i := range routes {
+		if routes[i].ID == args[1] {
+			route = &routes[i]
+		}
+	}
+	if route == nil {
+		return fmt.Errorf("route not found: %s", args[1])
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("pattern") {
+		if err := workers.ValidateRoutePattern(workersPattern, zone.Name); err != nil {
+			return err
+		}
+		route.Pattern = workersPattern
+	}
+	if flags.Changed("script") {
+		route.Script = workersScript
+	}
+
+	if err := client.UpdateWorkerRoute(ctx, zone.ID, *route); err != nil {
+		return err
+	}
+
+	infof("✓ Updated route %s → %s\n", route.Pattern, routeScript(*route))
+	return nil
+}
+
+func runWorkersRoutesDelete(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteWorkerRoute(ctx, zone.ID, args[1]); err != nil {
+		return err
+	}
+
+	infof("✓ Deleted route %s\n", args[1])
+	return nil
+}
+
+// routeScript names the script a route runs
+func routeScript(route cloudflare.WorkerRoute) string {
+	if route.Script == "" {
+		return "(Workers disabled)"
+	}
+	return route.Script
+}
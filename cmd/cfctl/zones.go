@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/session"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/cobra"
+)
+
+var (
+	zonesAccountID string
+	zonesJumpStart bool
+	zonesJSON      bool
+	zonesYes       bool
+
+	zonesCmd = &cobra.Command{
+		Use:   "zones",
+		Short: "Create, pause, resume and delete zones",
+		Long: `Manage the lifecycle of zones.
+
+Examples:
+  # Add a zone, importing its existing DNS records
+  cfctl zones create example.com --jump-start
+
+  # Send traffic straight to the origin, then turn Cloudflare back on
+  cfctl zones pause example.com
+  cfctl zones resume example.com
+
+  # Re-check the name servers of a pending zone
+  cfctl zones check example.com
+
+  # Delete a zone (asks you to type its name unless --yes is given)
+  cfctl zones delete example.com`,
+	}
+
+	zonesCreateCmd = &cobra.Command{
+		Use:   "create <domain>",
+		Short: "Add a zone to a Cloudflare account",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runZonesCreate,
+	}
+
+	zonesPauseCmd = &cobra.Command{
+		Use:   "pause <zone>",
+		Short: "Pause Cloudflare on a zone (DNS only)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runZonesSetPaused(args[0], true)
+		},
+	}
+
+	zonesResumeCmd = &cobra.Command{
+		Use:   "resume <zone>",
+		Short: "Resume Cloudflare on a paused zone",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runZonesSetPaused(args[0], false)
+		},
+	}
+
+	zonesCheckCmd = &cobra.Command{
+		Use:   "check <zone>",
+		Short: "Trigger an activation check of a pending zone",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runZonesCheck,
+	}
+
+	zonesDeleteCmd = &cobra.Command{
+		Use:   "delete <zone>",
+		Short: "Delete a zone and all of its configuration",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runZonesDelete,
+	}
+)
+
+func init() {
+	zonesCreateCmd.Flags().StringVar(&zonesAccountID, "account-id", "", "Cloudflare account to add the zone to (default: the pinned account)")
+	zonesCreateCmd.Flags().BoolVar(&zonesJumpStart, "jump-start", false, "scan and import the domain's existing DNS records")
+	zonesCreateCmd.Flags().BoolVar(&zonesJSON, "json", false, "output as JSON")
+	zonesDeleteCmd.Flags().BoolVarP(&zonesYes, "yes", "y", false, "skip the typed-name confirmation")
+
+	zonesCmd.AddCommand(zonesCreateCmd, zonesPauseCmd, zonesResumeCmd, zonesCheckCmd, zonesDeleteCmd)
+	rootCmd.AddCommand(zonesCmd)
+}
+
+// cloudflareAccountID picks the Cloudflare account a command works on: the
+// given --account-id flag value, the pinned account, or the only account
+// the credential can access
+func cloudflareAccountID(ctx context.Context, client *api.Client, account *cloudflare.Account, flag string) (string, error) {
+	accountID, err := session.AccountID(ctx, client, account, flag)
+	var ambiguous *session.AmbiguousAccountError
+	if errors.As(err, &ambiguous) {
+		return "", fmt.Errorf("%w; pass --account-id or pin one with `cfctl accounts pin`", err)
+	}
+	return accountID, err
+}
+
+// setupAccountClient creates an API client and picks the Cloudflare account
+// an account-level command works on
+func setupAccountClient(ctx context.Context, accountFlag string) (*api.Client, string, error) {
+	cfg, client, err := setupClient()
+	if err != nil {
+		return nil, "", err
+	}
+
+	account, err := selectedAccount(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	accountID, err := cloudflareAccountID(ctx, client, account, accountFlag)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, accountID, nil
+}
+
+func runZonesCreate(cmd *cobra.Command, args []string) error {
+	domain := strings.ToLower(args[0])
+	if err := utils.ValidateHostname(domain); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, accountID, err := setupAccountClient(ctx, zonesAccountID)
+	if err != nil {
+		return err
+	}
+
+	zone, err := client.CreateZone(ctx, accountID, domain, zonesJumpStart)
+	if err != nil {
+		return err
+	}
+
+	if zonesJSON {
+		return printJSON(zone)
+	}
+
+	infof("✓ Added %s (%s), status %s\n", zone.Name, zone.ID, zone.Status)
+	if len(zone.NameServers) > 0 {
+		infof("Point the domain at these name servers at your registrar:\n")
+		for _, ns := range zone.NameServers {
+			fmt.Printf("  %s\n", ns)
+		}
+	}
+	return nil
+}
+
+func runZonesSetPaused(nameOrID string, paused bool) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.SetZonePaused(ctx, zone.ID, paused); err != nil {
+		return err
+	}
+
+	if paused {
+		infof("✓ Paused Cloudflare on %s; traffic now goes straight to the origin\n", zone.Name)
+	} else {
+		infof("✓ Resumed Cloudflare on %s\n", zone.Name)
+	}
+	return nil
+}
+
+func runZonesCheck(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if zone.Status == "active" {
+		infof("%s is already active\n", zone.Name)
+		return nil
+	}
+
+	if err := client.CheckActivation(ctx, zone.ID); err != nil {
+		return err
+	}
+
+	infof("✓ Activation check requested for %s; it may take a few minutes\n", zone.Name)
+	return nil
+}
+
+func runZonesDelete(cmd *cobra.Command, args []string) error {
+	_, client, err := setupClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZone(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !zonesYes {
+		fmt.Printf("This deletes %s and all of its DNS records and settings.\nType the zone name to confirm: ", zone.Name)
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("no confirmation given; pass --yes to delete without prompting")
+		}
+		if strings.TrimSpace(line) != zone.Name {
+			return fmt.Errorf("zone name doesn't match; nothing was deleted")
+		}
+	}
+
+	if err := client.DeleteZone(ctx, zone.ID); err != nil {
+		return err
+	}
+
+	infof("✓ Deleted %s\n", zone.Name)
+	return nil
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	cfv6 "github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// accountsPerPage is the page size used when listing accounts
+const accountsPerPage = 50
+
+// ListAccounts retrieves the Cloudflare accounts the credential can access
+func (c *Client) ListAccounts(ctx context.Context) ([]cloudflare.AccountInfo, error) {
+	var list []cloudflare.AccountInfo
+	for page := 1; ; page++ {
+		res, err := c.listAccountsPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range res {
+			list = append(list, cloudflare.AccountInfo{
+				ID:        a.ID,
+				Name:      a.Name,
+				Type:      string(a.Type),
+				CreatedOn: a.CreatedOn,
+			})
+		}
+		if len(res) < accountsPerPage {
+			return list, nil
+		}
+	}
+}
+
+// listAccountsPage fetches one page of accounts with its own timeout
+func (c *Client) listAccountsPage(ctx context.Context, page int) ([]accounts.Account, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.api.Accounts.List(ctx, accounts.AccountListParams{
+		Page:    cfv6.F(float64(page)),
+		PerPage: cfv6.F(float64(accountsPerPage)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list accounts: %w", err)
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccounts(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		// A full first page and one account on the second
+		count := accountsPerPage
+		if page == "2" {
+			count = 1
+		}
+		result := make([]map[string]interface{}, count)
+		for i := range result {
+			result[i] = map[string]interface{}{
+				"id":   fmt.Sprintf("acct-%s-%d", page, i),
+				"name": fmt.Sprintf("Account %s-%d", page, i),
+				"type": "standard",
+			}
+		}
+		writeResult(w, result)
+	}))
+
+	accounts, err := client.ListAccounts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, accounts, accountsPerPage+1)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, "acct-2-0", accounts[accountsPerPage].ID)
+	assert.Equal(t, "Account 2-0", accounts[accountsPerPage].Name)
+	assert.Equal(t, "standard", accounts[accountsPerPage].Type)
+}
+
+func TestListAccountsError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+	}))
+
+	_, err := client.ListAccounts(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "list accounts")
+}
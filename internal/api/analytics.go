@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// AnalyticsWindow is a supported time window for zone analytics
+type AnalyticsWindow string
+
+const (
+	Window24Hours AnalyticsWindow = "24h"
+	Window7Days   AnalyticsWindow = "7d"
+	Window30Days  AnalyticsWindow = "30d"
+)
+
+// AnalyticsWindows lists the supported windows in display order
+var AnalyticsWindows = []AnalyticsWindow{Window24Hours, Window7Days, Window30Days}
+
+// ParseAnalyticsWindow validates a window name such as "24h" or "7d"
+func ParseAnalyticsWindow(s string) (AnalyticsWindow, error) {
+	for _, w := range AnalyticsWindows {
+		if string(w) == s {
+			return w, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported window %q (use 24h, 7d or 30d)", s)
+}
+
+// Duration returns the length of the window
+func (w AnalyticsWindow) Duration() time.Duration {
+	switch w {
+	case Window7Days:
+		return 7 * 24 * time.Hour
+	case Window30Days:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+const hourlyCacheQuery = `query ($zoneTag: string, $since: Time, $until: Time, $limit: Int) {
+  viewer {
+    zones(filter: {zoneTag: $zoneTag}) {
+      series: httpRequests1hGroups(limit: $limit, filter: {datetime_geq: $since, datetime_lt: $until}, orderBy: [datetime_ASC]) {
+        dimensions { timestamp: datetime }
+        sum { requests cachedRequests bytes cachedBytes }
+      }
+    }
+  }
+}`
+
+const dailyCacheQuery = `query ($zoneTag: string, $since: Date, $until: Date, $limit: Int) {
+  viewer {
+    zones(filter: {zoneTag: $zoneTag}) {
+      series: httpRequests1dGroups(limit: $limit, filter: {date_geq: $since, date_lt: $until}, orderBy: [date_ASC]) {
+        dimensions { timestamp: date }
+        sum { requests cachedRequests bytes cachedBytes }
+      }
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// GraphQL executes a query against the Cloudflare GraphQL Analytics API and
+// decodes the "data" field into out
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	var raw []byte
+	body := graphQLRequest{Query: query, Variables: variables}
+	if err := c.api.Execute(ctx, http.MethodPost, "graphql", body, &raw); err != nil {
+		return fmt.Errorf("graphql: %w", err)
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return fmt.Errorf("graphql: decode response: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		msgs := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(msgs, "; "))
+	}
+
+	if out != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("graphql: decode data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetCacheAnalytics returns request and bandwidth totals with a time series
+// for the given zone and window. Windows up to 7 days use hourly buckets,
+// longer windows use daily buckets.
+func (c *Client) GetCacheAnalytics(ctx context.Context, zoneID string, window AnalyticsWindow) (*cloudflare.CacheAnalytics, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	until := time.Now().UTC().Truncate(time.Hour)
+	since := until.Add(-window.Duration())
+
+	query := hourlyCacheQuery
+	variables := map[string]interface{}{
+		"zoneTag": zoneID,
+		"since":   since.Format(time.RFC3339),
+		"until":   until.Format(time.RFC3339),
+		"limit":   int(window.Duration() / time.Hour),
+	}
+	timeLayout := time.RFC3339
+
+	if window == Window30Days {
+		query = dailyCacheQuery
+		until = until.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		since = until.Add(-window.Duration())
+		variables["since"] = since.Format("2006-01-02")
+		variables["until"] = until.Format("2006-01-02")
+		variables["limit"] = 31
+		timeLayout = "2006-01-02"
+	}
+
+	var data struct {
+		Viewer struct {
+			Zones []struct {
+				Series []struct {
+					Dimensions struct {
+						Timestamp string `json:"timestamp"`
+					} `json:"dimensions"`
+					Sum struct {
+						Requests       int64 `json:"requests"`
+						CachedRequests int64 `json:"cachedRequests"`
+						Bytes          int64 `json:"bytes"`
+						CachedBytes    int64 `json:"cachedBytes"`
+					} `json:"sum"`
+				} `json:"series"`
+			} `json:"zones"`
+		} `json:"viewer"`
+	}
+
+	if err := c.GraphQL(ctx, query, variables, &data); err != nil {
+		return nil, fmt.Errorf("get cache analytics: %w", err)
+	}
+
+	result := &cloudflare.CacheAnalytics{
+		ZoneID: zoneID,
+		Window: string(window),
+		Since:  since,
+		Until:  until,
+		Points: []cloudflare.AnalyticsPoint{},
+	}
+
+	if len(data.Viewer.Zones) == 0 {
+		return result, nil
+	}
+
+	for _, s := range data.Viewer.Zones[0].Series {
+		ts, err := time.Parse(timeLayout, s.Dimensions.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("get cache analytics: parse timestamp %q: %w", s.Dimensions.Timestamp, err)
+		}
+
+		result.Points = append(result.Points, cloudflare.AnalyticsPoint{
+			Time:           ts,
+			Requests:       s.Sum.Requests,
+			CachedRequests: s.Sum.CachedRequests,
+			Bytes:          s.Sum.Bytes,
+			CachedBytes:    s.Sum.CachedBytes,
+		})
+		result.Requests += s.Sum.Requests
+		result.CachedRequests += s.Sum.CachedRequests
+		result.Bytes += s.Sum.Bytes
+		result.CachedBytes += s.Sum.CachedBytes
+	}
+
+	if result.Requests > 0 {
+		result.HitRatio = float64(result.CachedRequests) / float64(result.Requests)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnalyticsWindow(t *testing.T) {
+	w, err := ParseAnalyticsWindow("7d")
+	require.NoError(t, err)
+	assert.Equal(t, Window7Days, w)
+
+	_, err = ParseAnalyticsWindow("90d")
+	assert.Error(t, err)
+}
+
+func TestGetCacheAnalytics(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+
+		var req graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "zone-1", req.Variables["zoneTag"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"zones":[{"series":[
+			{"dimensions":{"timestamp":"2024-01-01T00:00:00Z"},"sum":{"requests":100,"cachedRequests":80,"bytes":1000,"cachedBytes":900}},
+			{"dimensions":{"timestamp":"2024-01-01T01:00:00Z"},"sum":{"requests":100,"cachedRequests":40,"bytes":1000,"cachedBytes":100}}
+		]}]}},"errors":null}`))
+	}))
+
+	stats, err := client.GetCacheAnalytics(context.Background(), "zone-1", Window24Hours)
+	require.NoError(t, err)
+	require.Len(t, stats.Points, 2)
+	assert.Equal(t, int64(200), stats.Requests)
+	assert.Equal(t, int64(120), stats.CachedRequests)
+	assert.Equal(t, int64(1000), stats.CachedBytes)
+	assert.InDelta(t, 0.6, stats.HitRatio, 0.0001)
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":null,"errors":[{"message":"zone not authorized"}]}`))
+	}))
+
+	_, err := client.GetCacheAnalytics(context.Background(), "zone-1", Window7Days)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zone not authorized")
+}
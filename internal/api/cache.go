@@ -59,3 +59,88 @@ func (c *Client) PurgeCache(ctx context.Context, zoneID string, req cloudflare.P
 
 	return nil
 }
+
+// MaxPurgeBatchSize is the maximum number of URLs, hosts, tags or prefixes
+// Cloudflare accepts in a single purge request
+const MaxPurgeBatchSize = 30
+
+// SplitPurgeRequest splits a purge request into requests of at most size
+// items each. Purge-everything requests are returned unchanged.
+func SplitPurgeRequest(req cloudflare.PurgeRequest, size int) []cloudflare.PurgeRequest {
+	if size <= 0 {
+		size = MaxPurgeBatchSize
+	}
+
+	var items []string
+	var build func([]string) cloudflare.PurgeRequest
+	switch {
+	case len(req.Files) > 0:
+		items, build = req.Files, func(b []string) cloudflare.PurgeRequest { return cloudflare.PurgeRequest{Files: b} }
+	case len(req.Hosts) > 0:
+		items, build = req.Hosts, func(b []string) cloudflare.PurgeRequest { return cloudflare.PurgeRequest{Hosts: b} }
+	case len(req.Tags) > 0:
+		items, build = req.Tags, func(b []string) cloudflare.PurgeRequest { return cloudflare.PurgeRequest{Tags: b} }
+	case len(req.Prefixes) > 0:
+		items, build = req.Prefixes, func(b []string) cloudflare.PurgeRequest { return cloudflare.PurgeRequest{Prefixes: b} }
+	default:
+		return []cloudflare.PurgeRequest{req}
+	}
+
+	batches := make([]cloudflare.PurgeRequest, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		batches = append(batches, build(items[start:end]))
+	}
+	return batches
+}
+
+// PurgeCacheBatched purges a request of any size by splitting it into
+// batches Cloudflare accepts. progress, if not nil, is called after each
+// successful batch with the number of batches completed so far.
+func (c *Client) PurgeCacheBatched(ctx context.Context, zoneID string, req cloudflare.PurgeRequest, progress func(done, total int)) error {
+	batches := SplitPurgeRequest(req, MaxPurgeBatchSize)
+
+	for i, batch := range batches {
+		if err := c.PurgeCache(ctx, zoneID, batch); err != nil {
+			if len(batches) == 1 {
+				return err
+			}
+			return fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+		if progress != nil {
+			progress(i+1, len(batches))
+		}
+	}
+
+	return nil
+}
+
+// BatchResult is the outcome of one batch of a streamed purge
+type BatchResult struct {
+	Index   int // 1-based batch number
+	Total   int
+	Request cloudflare.PurgeRequest
+	Err     error
+}
+
+// PurgeCacheStream purges batches in turn, as returned by SplitPurgeRequest,
+// sending the result of every batch on the returned channel, which is
+// closed once each batch has reported. Unlike PurgeCacheBatched it carries
+// on past failed batches. When ctx is canceled the batches not yet sent fail
+// with the context error, so callers can retry them later.
+func (c *Client) PurgeCacheStream(ctx context.Context, zoneID string, batches []cloudflare.PurgeRequest) <-chan BatchResult {
+	// Buffered so the purge never blocks on a reader that has gone away
+	results := make(chan BatchResult, len(batches))
+	go func() {
+		defer close(results)
+		for i, batch := range batches {
+			err := ctx.Err()
+			if err == nil {
+				err = c.PurgeCache(ctx, zoneID, batch)
+			}
+			results <- BatchResult{Index: i + 1, Total: len(batches), Request: batch, Err: err}
+		}
+	}()
+
+	return results
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+const (
+	// CacheRulesPhase is the ruleset phase that holds a zone's Cache Rules
+	CacheRulesPhase = "http_request_cache_settings"
+
+	cacheRuleAction = "set_cache_settings"
+)
+
+// ruleset is the subset of a ruleset response that cfctl cares about
+type ruleset struct {
+	ID    string                 `json:"id"`
+	Phase string                 `json:"phase"`
+	Rules []cloudflare.CacheRule `json:"rules"`
+}
+
+// rulePosition moves a rule within its ruleset (1-based index)
+type rulePosition struct {
+	Index int `json:"index"`
+}
+
+type positionedCacheRule struct {
+	cloudflare.CacheRule
+	Position *rulePosition `json:"position,omitempty"`
+}
+
+func cacheRulesEntrypointPath(zoneID string) string {
+	return fmt.Sprintf("zones/%s/rulesets/phases/%s/entrypoint", zoneID, CacheRulesPhase)
+}
+
+// getCacheRuleset fetches the zone's cache settings entrypoint ruleset.
+// A nil ruleset with no error means the zone has no cache rules yet.
+func (c *Client) getCacheRuleset(ctx context.Context, zoneID string) (*ruleset, error) {
+	var rs ruleset
+	found, err := c.getEntrypoint(ctx, cacheRulesEntrypointPath(zoneID), &rs)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// ListCacheRules retrieves the Cache Rules for a zone in evaluation order
+func (c *Client) ListCacheRules(ctx context.Context, zoneID string) ([]cloudflare.CacheRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rs, err := c.getCacheRuleset(ctx, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("list cache rules: %w", err)
+	}
+	if rs == nil {
+		return []cloudflare.CacheRule{}, nil
+	}
+
+	return rs.Rules, nil
+}
+
+// CreateCacheRule appends a new Cache Rule to the zone, creating the
+// entrypoint ruleset if the zone does not have one yet
+func (c *Client) CreateCacheRule(ctx context.Context, zoneID string, rule cloudflare.CacheRule) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rule.ID = ""
+	rule.Action = cacheRuleAction
+
+	rs, err := c.getCacheRuleset(ctx, zoneID)
+	if err != nil {
+		return fmt.Errorf("create cache rule: %w", err)
+	}
+
+	if rs == nil {
+		body := map[string]interface{}{
+			"rules": []cloudflare.CacheRule{rule},
+		}
+		if err := c.doRaw(ctx, http.MethodPut, cacheRulesEntrypointPath(zoneID), body, nil); err != nil {
+			return fmt.Errorf("create cache rule: %w", err)
+		}
+		return nil
+	}
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules", zoneID, rs.ID)
+	if err := c.doRaw(ctx, http.MethodPost, path, rule, nil); err != nil {
+		return fmt.Errorf("create cache rule: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCacheRule replaces an existing Cache Rule identified by rule.ID
+func (c *Client) UpdateCacheRule(ctx context.Context, zoneID string, rule cloudflare.CacheRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("update cache rule: rule ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rs, err := c.getCacheRuleset(ctx, zoneID)
+	if err != nil {
+		return fmt.Errorf("update cache rule: %w", err)
+	}
+	if rs == nil {
+		return fmt.Errorf("update cache rule: zone has no cache rules")
+	}
+
+	rule.Action = cacheRuleAction
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules/%s", zoneID, rs.ID, rule.ID)
+	if err := c.doRaw(ctx, http.MethodPatch, path, rule, nil); err != nil {
+		return fmt.Errorf("update cache rule: %w", err)
+	}
+
+	return nil
+}
+
+// MoveCacheRule moves a Cache Rule to the given 1-based position
+func (c *Client) MoveCacheRule(ctx context.Context, zoneID, ruleID string, index int) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rs, err := c.getCacheRuleset(ctx, zoneID)
+	if err != nil {
+		return fmt.Errorf("move cache rule: %w", err)
+	}
+	if rs == nil {
+		return fmt.Errorf("move cache rule: zone has no cache rules")
+	}
+
+	if index < 1 || index > len(rs.Rules) {
+		return fmt.Errorf("move cache rule: position %d out of range (1-%d)", index, len(rs.Rules))
+	}
+
+	var current *cloudflare.CacheRule
+	for i := range rs.Rules {
+		if rs.Rules[i].ID == ruleID {
+			current = &rs.Rules[i]
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("move cache rule: rule not found: %s", ruleID)
+	}
+
+	body := positionedCacheRule{
+		CacheRule: *current,
+		Position:  &rulePosition{Index: index},
+	}
+	body.Action = cacheRuleAction
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules/%s", zoneID, rs.ID, ruleID)
+	if err := c.doRaw(ctx, http.MethodPatch, path, body, nil); err != nil {
+		return fmt.Errorf("move cache rule: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCacheRule removes a Cache Rule from the zone
+func (c *Client) DeleteCacheRule(ctx context.Context, zoneID, ruleID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rs, err := c.getCacheRuleset(ctx, zoneID)
+	if err != nil {
+		return fmt.Errorf("delete cache rule: %w", err)
+	}
+	if rs == nil {
+		return fmt.Errorf("delete cache rule: zone has no cache rules")
+	}
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules/%s", zoneID, rs.ID, ruleID)
+	if err := c.doRaw(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("delete cache rule: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceCacheRules overwrites all Cache Rules of the zone with the given
+// rules, in order. Rule IDs are discarded so rules exported from one zone
+// can be imported into another.
+func (c *Client) ReplaceCacheRules(ctx context.Context, zoneID string, rules []cloudflare.CacheRule) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cleaned := make([]cloudflare.CacheRule, len(rules))
+	for i, rule := range rules {
+		rule.ID = ""
+		rule.Action = cacheRuleAction
+		cleaned[i] = rule
+	}
+
+	body := map[string]interface{}{
+		"rules": cleaned,
+	}
+	if err := c.doRaw(ctx, http.MethodPut, cacheRulesEntrypointPath(zoneID), body, nil); err != nil {
+		return fmt.Errorf("replace cache rules: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		APIToken: "test-token",
+		BaseURL:  server.URL + "/",
+		Retries:  1,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"errors":   []interface{}{},
+		"messages": []interface{}{},
+		"result":   result,
+	})
+}
+
+func TestListCacheRules(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/rulesets/phases/http_request_cache_settings/entrypoint", r.URL.Path)
+		writeResult(w, map[string]interface{}{
+			"id":    "rs-1",
+			"phase": CacheRulesPhase,
+			"rules": []map[string]interface{}{
+				{"id": "r1", "expression": "true", "action": "set_cache_settings", "enabled": true,
+					"action_parameters": map[string]interface{}{"cache": true}},
+			},
+		})
+	}))
+
+	rules, err := client.ListCacheRules(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "r1", rules[0].ID)
+	assert.Equal(t, true, rules[0].ActionParameters["cache"])
+}
+
+func TestListCacheRulesNoEntrypoint(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10003,"message":"not found"}],"messages":[],"result":null}`))
+	}))
+
+	rules, err := client.ListCacheRules(context.Background(), "zone-1")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestCreateCacheRuleCreatesEntrypoint(t *testing.T) {
+	var putBody struct {
+		Rules []cloudflare.CacheRule `json:"rules"`
+	}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[],"messages":[],"result":null}`))
+		case http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&putBody))
+			writeResult(w, map[string]interface{}{"id": "rs-1"})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	err := client.CreateCacheRule(context.Background(), "zone-1", cloudflare.CacheRule{
+		ID:         "ignored",
+		Expression: `http.request.uri.path wildcard "/static/*"`,
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, putBody.Rules, 1)
+	assert.Empty(t, putBody.Rules[0].ID)
+	assert.Equal(t, "set_cache_settings", putBody.Rules[0].Action)
+}
+
+func TestMoveCacheRuleOutOfRange(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, map[string]interface{}{
+			"id":    "rs-1",
+			"rules": []map[string]interface{}{{"id": "r1", "expression": "true"}},
+		})
+	}))
+
+	err := client.MoveCacheRule(context.Background(), "zone-1", "r1", 3)
+	assert.Error(t, err)
+}
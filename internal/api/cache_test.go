@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPurgeRequest(t *testing.T) {
+	urls := make([]string, 65)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	batches := SplitPurgeRequest(cloudflare.PurgeRequest{Files: urls}, MaxPurgeBatchSize)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0].Files, 30)
+	assert.Len(t, batches[2].Files, 5)
+	assert.Equal(t, urls[60], batches[2].Files[0])
+
+	batches = SplitPurgeRequest(cloudflare.PurgeRequest{PurgeEverything: true}, MaxPurgeBatchSize)
+	assert.Equal(t, []cloudflare.PurgeRequest{{PurgeEverything: true}}, batches)
+}
+
+func TestPurgeCacheBatched(t *testing.T) {
+	var sizes []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/purge_cache", r.URL.Path)
+
+		var body struct {
+			Files []string `json:"files"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		sizes = append(sizes, len(body.Files))
+		writeResult(w, map[string]string{"id": "zone-1"})
+	}))
+
+	urls := make([]string, 31)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	var progress []int
+	err := client.PurgeCacheBatched(context.Background(), "zone-1", cloudflare.PurgeRequest{Files: urls},
+		func(done, total int) {
+			assert.Equal(t, 2, total)
+			progress = append(progress, done)
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int{30, 1}, sizes)
+	assert.Equal(t, []int{1, 2}, progress)
+}
+
+func TestPurgeCacheStream(t *testing.T) {
+	var calls int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":1012,"message":"bad request"}],"messages":[],"result":null}`))
+			return
+		}
+		writeResult(w, map[string]string{"id": "zone-1"})
+	}))
+
+	urls := make([]string, 65)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	var results []BatchResult
+	for r := range client.PurgeCacheStream(context.Background(), "zone-1", SplitPurgeRequest(cloudflare.PurgeRequest{Files: urls}, MaxPurgeBatchSize)) {
+		results = append(results, r)
+	}
+
+	require.Len(t, results, 3)
+	assert.Equal(t, 3, calls, "a failed batch must not stop the rest")
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, 2, results[1].Index)
+	assert.Equal(t, 3, results[1].Total)
+	assert.Equal(t, urls[30:60], results[1].Request.Files)
+}
+
+func TestPurgeCacheStreamCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		writeResult(w, map[string]string{"id": "zone-1"})
+	}))
+
+	urls := make([]string, 61)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	var results []BatchResult
+	for r := range client.PurgeCacheStream(ctx, "zone-1", SplitPurgeRequest(cloudflare.PurgeRequest{Files: urls}, MaxPurgeBatchSize)) {
+		results = append(results, r)
+	}
+
+	require.Len(t, results, 3)
+	assert.ErrorIs(t, results[2].Err, context.Canceled)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// ListCertificatePacks retrieves every edge certificate pack of a zone,
+// including packs that are still being issued or have expired
+func (c *Client) ListCertificatePacks(ctx context.Context, zoneID string) ([]cloudflare.CertificatePack, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var packs []cloudflare.CertificatePack
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("zones/%s/ssl/certificate_packs?status=all", zoneID), nil, &packs); err != nil {
+		return nil, fmt.Errorf("list certificate packs: %w", err)
+	}
+	if packs == nil {
+		return []cloudflare.CertificatePack{}, nil
+	}
+	return packs, nil
+}
+
+// GetSSLSettings reads the SSL mode and related TLS settings of a zone in
+// one request
+func (c *Client) GetSSLSettings(ctx context.Context, zoneID string) (*cloudflare.SSLSettings, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var settings []zoneSetting
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("zones/%s/settings", zoneID), nil, &settings); err != nil {
+		return nil, fmt.Errorf("get ssl settings: %w", err)
+	}
+
+	var ssl cloudflare.SSLSettings
+	for _, s := range settings {
+		var value string
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			// Only string valued settings are of interest here
+			continue
+		}
+		switch s.ID {
+		case "ssl":
+			ssl.Mode = value
+		case "min_tls_version":
+			ssl.MinTLSVersion = value
+		case "tls_1_3":
+			ssl.TLS13 = value
+		case "always_use_https":
+			ssl.AlwaysUseHTTPS = value == "on"
+		case "automatic_https_rewrites":
+			ssl.AutomaticHTTPSRewrites = value == "on"
+		}
+	}
+	return &ssl, nil
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCertificatePacks(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/ssl/certificate_packs", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("status"))
+		writeResult(w, []map[string]interface{}{{
+			"id":                    "pack-1",
+			"type":                  "universal",
+			"hosts":                 []string{"example.com", "*.example.com"},
+			"status":                "active",
+			"certificate_authority": "google",
+			"primary_certificate":   "cert-2",
+			"certificates": []map[string]interface{}{
+				{"id": "cert-1", "issuer": "GoogleTrustServices", "status": "active", "expires_on": "2026-03-01T00:00:00Z"},
+				{"id": "cert-2", "issuer": "LetsEncrypt", "status": "active", "expires_on": "2026-02-01T00:00:00Z"},
+			},
+		}})
+	}))
+
+	packs, err := client.ListCertificatePacks(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, packs, 1)
+	assert.Equal(t, []string{"example.com", "*.example.com"}, packs[0].Hosts)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), packs[0].Expires())
+	assert.Equal(t, "LetsEncrypt", packs[0].Issuer())
+}
+
+func TestGetSSLSettings(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/settings", r.URL.Path)
+		writeResult(w, []map[string]interface{}{
+			{"id": "ssl", "value": "strict"},
+			{"id": "min_tls_version", "value": "1.2"},
+			{"id": "tls_1_3", "value": "zrt"},
+			{"id": "always_use_https", "value": "on"},
+			{"id": "automatic_https_rewrites", "value": "off"},
+			{"id": "challenge_ttl", "value": 1800},
+		})
+	}))
+
+	ssl, err := client.GetSSLSettings(context.Background(), "zone-1")
+	require.NoError(t, err)
+	assert.Equal(t, "strict", ssl.Mode)
+	assert.Equal(t, "1.2", ssl.MinTLSVersion)
+	assert.Equal(t, "zrt", ssl.TLS13)
+	assert.True(t, ssl.AlwaysUseHTTPS)
+	assert.False(t, ssl.AutomaticHTTPSRewrites)
+}
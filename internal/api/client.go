@@ -2,7 +2,12 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"time"
 
 	cfv6 "github.com/cloudflare/cloudflare-go/v6"
@@ -24,21 +29,29 @@ type ClientConfig struct {
 	Email    string
 	Timeout  int
 	Retries  int
+	BaseURL  string
 }
 
 // NewClient creates a new Cloudflare API client
 func NewClient(cfg ClientConfig) (*Client, error) {
+	var opts []option.RequestOption
+
+	// Allow the endpoint to be overridden (used by tests)
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+
 	var api *cfv6.Client
 
 	if cfg.APIToken != "" {
-		api = cfv6.NewClient(
+		api = cfv6.NewClient(append(opts,
 			option.WithAPIToken(cfg.APIToken),
-		)
+		)...)
 	} else if cfg.APIKey != "" && cfg.Email != "" {
-		api = cfv6.NewClient(
+		api = cfv6.NewClient(append(opts,
 			option.WithAPIKey(cfg.APIKey),
 			option.WithAPIEmail(cfg.Email),
-		)
+		)...)
 	} else {
 		return nil, fmt.Errorf("either API token or API key with email must be provided")
 	}
@@ -118,3 +131,72 @@ func (c *Client) GetTimeout() time.Duration {
 func (c *Client) GetRetries() int {
 	return c.retries
 }
+
+// doRaw performs a request against an endpoint that has no convenient typed
+// wrapper in the SDK and decodes the "result" field of the response envelope
+// into out (which may be nil). opts can replace the JSON body, e.g. with a
+// multipart upload.
+func (c *Client) doRaw(ctx context.Context, method, path string, body, out interface{}, opts ...option.RequestOption) error {
+	var raw []byte
+	if err := c.api.Execute(ctx, method, path, body, &raw, opts...); err != nil {
+		return err
+	}
+
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+
+	envelope := struct {
+		Result json.RawMessage `json:"result"`
+	}{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if len(envelope.Result) == 0 || string(envelope.Result) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(envelope.Result, out); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+
+	return nil
+}
+
+// getEntrypoint fetches a phase entrypoint ruleset into out. It returns
+// false with no error when the ruleset doesn't exist yet.
+func (c *Client) getEntrypoint(ctx context.Context, path string, out interface{}) (bool, error) {
+	if err := c.doRaw(ctx, http.MethodGet, path, nil, out); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// isNotFound reports whether err is an API error with a 404 status
+func isNotFound(err error) bool {
+	var apiErr *cfv6.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsRetryable reports whether a failed request may succeed when retried:
+// network errors, timeouts, rate limiting and server errors. Anything else,
+// such as other API errors or configuration, keyring, validation and decode
+// errors, is not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *cfv6.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -1,10 +1,20 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
+	cfv6 "github.com/cloudflare/cloudflare-go/v6"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewClient(t *testing.T) {
@@ -94,3 +104,33 @@ func TestClientDefaultRetries(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 3, client.retries)
 }
+
+func TestIsRetryable(t *testing.T) {
+	var v interface{}
+	decodeErr := json.Unmarshal([]byte(`{"result":`), &v)
+	require.Error(t, decodeErr)
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"network", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}, true},
+		{"url", &url.Error{Op: "Get", URL: "https://api.cloudflare.com", Err: io.ErrUnexpectedEOF}, true},
+		{"truncated body", fmt.Errorf("read response: %w", io.ErrUnexpectedEOF), true},
+		{"timeout", fmt.Errorf("list zones: %w", context.DeadlineExceeded), true},
+		{"canceled", fmt.Errorf("list zones: %w", context.Canceled), false},
+		{"rate limited", &cfv6.Error{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", fmt.Errorf("purge cache: %w", &cfv6.Error{StatusCode: http.StatusBadGateway}), true},
+		{"bad request", &cfv6.Error{StatusCode: http.StatusBadRequest}, false},
+		{"zone not found", fmt.Errorf("%w: example.com", ErrZoneNotFound), false},
+		{"plain error", errors.New("no default account configured"), false},
+		{"decode error", fmt.Errorf("decode response: %w", decodeErr), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// FirewallCustomPhase is the ruleset phase that holds WAF custom rules
+const FirewallCustomPhase = "http_request_firewall_custom"
+
+// accessRulesPerPage is the page size used when listing IP Access rules,
+// the API maximum
+const accessRulesPerPage = 1000
+
+// Scope selects the zone or account a firewall call applies to. Account
+// level custom rules require an Enterprise plan.
+type Scope struct {
+	Account bool
+	ID      string
+}
+
+// ZoneScope targets the firewall of one zone
+func ZoneScope(zoneID string) Scope {
+	return Scope{ID: zoneID}
+}
+
+// AccountScope targets the firewall of a Cloudflare account, applying to
+// all of its zones
+func AccountScope(accountID string) Scope {
+	return Scope{Account: true, ID: accountID}
+}
+
+// String returns "zone" or "account"
+func (s Scope) String() string {
+	if s.Account {
+		return "account"
+	}
+	return "zone"
+}
+
+// path builds an API path below the zone or account
+func (s Scope) path(format string, args ...interface{}) string {
+	prefix := "zones/"
+	if s.Account {
+		prefix = "accounts/"
+	}
+	return prefix + s.ID + "/" + fmt.Sprintf(format, args...)
+}
+
+// firewallRuleset is the custom rules entrypoint of a zone or account
+type firewallRuleset struct {
+	ID    string                    `json:"id"`
+	Rules []cloudflare.FirewallRule `json:"rules"`
+}
+
+func firewallEntrypointPath(scope Scope) string {
+	return scope.path("rulesets/phases/%s/entrypoint", FirewallCustomPhase)
+}
+
+// rulesetReadOnlyFields are rule fields the API sets itself, which are
+// left out when a rule is written back
+var rulesetReadOnlyFields = []string{"version", "last_updated", "categories"}
+
+// prepareFirewallRule clears the rule ID and fills in the parameters a skip
+// rule needs when none are given. The parameters of other actions, such as
+// the custom response of a block rule, and fields the rule type doesn't
+// model are passed through unchanged.
+func prepareFirewallRule(rule cloudflare.FirewallRule) cloudflare.FirewallRule {
+	rule.ID = ""
+	if rule.Action == "skip" && len(rule.ActionParameters) == 0 {
+		rule.ActionParameters = map[string]interface{}{"ruleset": "current"}
+	}
+	rule.Raw = withoutFields(rule.Raw, rulesetReadOnlyFields...)
+	return rule
+}
+
+// withoutFields removes keys from a JSON object. Anything that isn't an
+// object is dropped.
+func withoutFields(raw json.RawMessage, keys ...string) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+	for _, key := range keys {
+		delete(fields, key)
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// ListFirewallRules retrieves the WAF custom rules of a zone or account in
+// evaluation order
+func (c *Client) ListFirewallRules(ctx context.Context, scope Scope) ([]cloudflare.FirewallRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs firewallRuleset
+	if _, err := c.getEntrypoint(ctx, firewallEntrypointPath(scope), &rs); err != nil {
+		return nil, fmt.Errorf("list firewall rules: %w", err)
+	}
+	if rs.Rules == nil {
+		return []cloudflare.FirewallRule{}, nil
+	}
+	return rs.Rules, nil
+}
+
+// CreateFirewallRule appends a WAF custom rule, creating the entrypoint
+// ruleset if needed, and returns the created rule
+func (c *Client) CreateFirewallRule(ctx context.Context, scope Scope, rule cloudflare.FirewallRule) (*cloudflare.FirewallRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rule = prepareFirewallRule(rule)
+
+	var rs firewallRuleset
+	found, err := c.getEntrypoint(ctx, firewallEntrypointPath(scope), &rs)
+	if err != nil {
+		return nil, fmt.Errorf("create firewall rule: %w", err)
+	}
+
+	// Both calls respond with the whole ruleset; new rules are appended
+	var updated firewallRuleset
+	if !found {
+		body := map[string]interface{}{
+			"rules": []cloudflare.FirewallRule{rule},
+		}
+		err = c.doRaw(ctx, http.MethodPut, firewallEntrypointPath(scope), body, &updated)
+	} else {
+		err = c.doRaw(ctx, http.MethodPost, scope.path("rulesets/%s/rules", rs.ID), rule, &updated)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create firewall rule: %w", err)
+	}
+
+	if len(updated.Rules) == 0 {
+		return &rule, nil
+	}
+	return &updated.Rules[len(updated.Rules)-1], nil
+}
+
+// UpdateFirewallRule replaces an existing WAF custom rule identified by
+// rule.ID
+func (c *Client) UpdateFirewallRule(ctx context.Context, scope Scope, rule cloudflare.FirewallRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("update firewall rule: rule ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs firewallRuleset
+	found, err := c.getEntrypoint(ctx, firewallEntrypointPath(scope), &rs)
+	if err != nil {
+		return fmt.Errorf("update firewall rule: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("update firewall rule: %s has no custom rules", scope)
+	}
+
+	id := rule.ID
+	rule = prepareFirewallRule(rule)
+	if err := c.doRaw(ctx, http.MethodPatch, scope.path("rulesets/%s/rules/%s", rs.ID, id), rule, nil); err != nil {
+		return fmt.Errorf("update firewall rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteFirewallRule removes a WAF custom rule
+func (c *Client) DeleteFirewallRule(ctx context.Context, scope Scope, ruleID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs firewallRuleset
+	found, err := c.getEntrypoint(ctx, firewallEntrypointPath(scope), &rs)
+	if err != nil {
+		return fmt.Errorf("delete firewall rule: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("delete firewall rule: %s has no custom rules", scope)
+	}
+
+	if err := c.doRaw(ctx, http.MethodDelete, scope.path("rulesets/%s/rules/%s", rs.ID, ruleID), nil, nil); err != nil {
+		return fmt.Errorf("delete firewall rule: %w", err)
+	}
+	return nil
+}
+
+// accessRule is the API representation of an IP Access rule
+type accessRule struct {
+	ID            string `json:"id,omitempty"`
+	Mode          string `json:"mode"`
+	Notes         string `json:"notes"`
+	Configuration struct {
+		Target string `json:"target"`
+		Value  string `json:"value"`
+	} `json:"configuration"`
+	Scope *struct {
+		Type string `json:"type"`
+	} `json:"scope,omitempty"`
+	CreatedOn  time.Time `json:"created_on"`
+	ModifiedOn time.Time `json:"modified_on"`
+}
+
+func toAccessRule(r accessRule) cloudflare.AccessRule {
+	rule := cloudflare.AccessRule{
+		ID:         r.ID,
+		Mode:       r.Mode,
+		Target:     r.Configuration.Target,
+		Value:      r.Configuration.Value,
+		Notes:      r.Notes,
+		CreatedOn:  r.CreatedOn,
+		ModifiedOn: r.ModifiedOn,
+	}
+	if r.Scope != nil {
+		rule.Scope = r.Scope.Type
+	}
+	return rule
+}
+
+// ListAccessRules retrieves every IP Access rule that applies to a zone or
+// account. Zone listings include rules inherited from the account.
+func (c *Client) ListAccessRules(ctx context.Context, scope Scope) ([]cloudflare.AccessRule, error) {
+	rules := []cloudflare.AccessRule{}
+	for page := 1; ; page++ {
+		items, totalPages, err := c.listAccessRulesPage(ctx, scope, page)
+		if err != nil {
+			return nil, fmt.Errorf("list access rules: %w", err)
+		}
+		for _, r := range items {
+			rules = append(rules, toAccessRule(r))
+		}
+		if page >= totalPages {
+			return rules, nil
+		}
+	}
+}
+
+// listAccessRulesPage fetches one page of IP Access rules with its own
+// timeout and returns the total page count
+func (c *Client) listAccessRulesPage(ctx context.Context, scope Scope, page int) ([]accessRule, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(page))
+	query.Set("per_page", fmt.Sprint(accessRulesPerPage))
+	path := scope.path("firewall/access_rules/rules?%s", query.Encode())
+
+	// doRaw drops result_info, which holds the page count
+	var raw []byte
+	if err := c.api.Execute(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, 0, err
+	}
+	var envelope struct {
+		Result     []accessRule `json:"result"`
+		ResultInfo struct {
+			TotalPages int `json:"total_pages"`
+		} `json:"result_info"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, 0, fmt.Errorf("decode response: %w", err)
+	}
+	return envelope.Result, envelope.ResultInfo.TotalPages, nil
+}
+
+// CreateAccessRule adds an IP Access rule and returns it
+func (c *Client) CreateAccessRule(ctx context.Context, scope Scope, rule cloudflare.AccessRule) (*cloudflare.AccessRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var body accessRule
+	body.Mode = rule.Mode
+	body.Notes = rule.Notes
+	body.Configuration.Target = rule.Target
+	body.Configuration.Value = rule.Value
+
+	var created accessRule
+	if err := c.doRaw(ctx, http.MethodPost, scope.path("firewall/access_rules/rules"), body, &created); err != nil {
+		return nil, fmt.Errorf("create access rule: %w", err)
+	}
+
+	result := toAccessRule(created)
+	return &result, nil
+}
+
+// UpdateAccessRule changes the mode and notes of an IP Access rule. The
+// target and value of a rule can't be changed.
+func (c *Client) UpdateAccessRule(ctx context.Context, scope Scope, rule cloudflare.AccessRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("update access rule: rule ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	body := map[string]string{
+		"mode":  rule.Mode,
+		"notes": rule.Notes,
+	}
+	if err := c.doRaw(ctx, http.MethodPatch, scope.path("firewall/access_rules/rules/%s", rule.ID), body, nil); err != nil {
+		return fmt.Errorf("update access rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccessRule removes an IP Access rule
+func (c *Client) DeleteAccessRule(ctx context.Context, scope Scope, ruleID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.doRaw(ctx, http.MethodDelete, scope.path("firewall/access_rules/rules/%s", ruleID), nil, nil); err != nil {
+		return fmt.Errorf("delete access rule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFirewallRulesAccountScope(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct-1/rulesets/phases/http_request_firewall_custom/entrypoint", r.URL.Path)
+		writeResult(w, map[string]interface{}{
+			"id": "rs-1",
+			"rules": []map[string]interface{}{
+				{"id": "r1", "description": "Block bots", "expression": "cf.client.bot", "action": "block", "enabled": true},
+			},
+		})
+	}))
+
+	rules, err := client.ListFirewallRules(context.Background(), AccountScope("acct-1"))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "block", rules[0].Action)
+	assert.Equal(t, "cf.client.bot", rules[0].Expression)
+}
+
+func TestCreateFirewallRule(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeResult(w, map[string]interface{}{"id": "rs-1", "rules": []interface{}{}})
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/zones/zone-1/rulesets/rs-1/rules", r.URL.Path)
+		var body cloudflare.FirewallRule
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "skip", body.Action)
+		assert.Equal(t, "current", body.ActionParameters["ruleset"])
+
+		writeResult(w, map[string]interface{}{
+			"id": "rs-1",
+			"rules": []map[string]interface{}{
+				{"id": "old", "expression": "true", "action": "log"},
+				{"id": "new", "expression": body.Expression, "action": body.Action, "enabled": true},
+			},
+		})
+	}))
+
+	rule, err := client.CreateFirewallRule(context.Background(), ZoneScope("zone-1"), cloudflare.FirewallRule{
+		Expression: `ip.src eq 198.51.100.4`,
+		Action:     "skip",
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new", rule.ID)
+}
+
+func TestListAccessRulesPages(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/firewall/access_rules/rules", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":  true,
+			"errors":   []interface{}{},
+			"messages": []interface{}{},
+			"result": []map[string]interface{}{{
+				"id":            "ar-" + page,
+				"mode":          "block",
+				"notes":         "abuse",
+				"configuration": map[string]string{"target": "ip", "value": "198.51.100." + page},
+				"scope":         map[string]string{"type": "account"},
+			}},
+			"result_info": map[string]interface{}{"page": page, "total_pages": 2},
+		})
+	}))
+
+	rules, err := client.ListAccessRules(context.Background(), ZoneScope("zone-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "ar-2", rules[1].ID)
+	assert.Equal(t, "ip", rules[1].Target)
+	assert.Equal(t, "198.51.100.2", rules[1].Value)
+	assert.Equal(t, "account", rules[1].Scope)
+}
+
+func TestCreateAccessRule(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/accounts/acct-1/firewall/access_rules/rules", r.URL.Path)
+
+		var body accessRule
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "challenge", body.Mode)
+		assert.Equal(t, "country", body.Configuration.Target)
+		assert.Equal(t, "XX", body.Configuration.Value)
+
+		body.ID = "ar-1"
+		writeResult(w, body)
+	}))
+
+	rule, err := client.CreateAccessRule(context.Background(), AccountScope("acct-1"), cloudflare.AccessRule{
+		Mode:   "challenge",
+		Target: "country",
+		Value:  "XX",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ar-1", rule.ID)
+	assert.Equal(t, "XX", rule.Value)
+}
+
+func TestUpdateAccessRule(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/zone-1/firewall/access_rules/rules/ar-1", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"mode": "whitelist", "notes": "office"}, body)
+		writeResult(w, map[string]string{"id": "ar-1"})
+	}))
+
+	err := client.UpdateAccessRule(context.Background(), ZoneScope("zone-1"), cloudflare.AccessRule{ID: "ar-1", Mode: "whitelist", Notes: "office"})
+	require.NoError(t, err)
+}
+
+func TestUpdateFirewallRuleKeepsParameters(t *testing.T) {
+	var body map[string]json.RawMessage
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeResult(w, map[string]interface{}{
+				"id": "rs-1",
+				"rules": []map[string]interface{}{{
+					"id":           "r1",
+					"version":      "3",
+					"expression":   "cf.client.bot",
+					"action":       "block",
+					"enabled":      true,
+					"last_updated": "2025-01-02T15:04:05Z",
+					"logging":      map[string]bool{"enabled": false},
+					"action_parameters": map[string]interface{}{
+						"response": map[string]interface{}{"status_code": 403, "content": "go away", "content_type": "text/plain"},
+					},
+				}},
+			})
+			return
+		}
+
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/zone-1/rulesets/rs-1/rules/r1", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		writeResult(w, map[string]interface{}{"id": "rs-1"})
+	}))
+
+	rules, err := client.ListFirewallRules(context.Background(), ZoneScope("zone-1"))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	rule := rules[0]
+	rule.Enabled = false
+	require.NoError(t, client.UpdateFirewallRule(context.Background(), ZoneScope("zone-1"), rule))
+
+	assert.JSONEq(t, `{"response":{"status_code":403,"content":"go away","content_type":"text/plain"}}`, string(body["action_parameters"]))
+	assert.JSONEq(t, `{"enabled":false}`, string(body["logging"]))
+	assert.JSONEq(t, `false`, string(body["enabled"]))
+	assert.NotContains(t, body, "id")
+	assert.NotContains(t, body, "version")
+	assert.NotContains(t, body, "last_updated")
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+const (
+	kvNamespacesPerPage = 100
+	// kvBulkLimit is the most pairs one bulk write may carry
+	kvBulkLimit = 10000
+)
+
+// KVWriteOptions are the optional parts of a KV write. Expiration is a Unix
+// time and ExpirationTTL a number of seconds; at most one should be set.
+type KVWriteOptions struct {
+	Expiration    int64
+	ExpirationTTL int64
+	Metadata      json.RawMessage
+}
+
+// kvPath builds the path of a namespace endpoint. Key names are escaped
+// since they may hold slashes and other reserved characters.
+func kvPath(accountID, namespaceID, endpoint, key string) string {
+	path := fmt.Sprintf("accounts/%s/storage/kv/namespaces/%s/%s", accountID, namespaceID, endpoint)
+	if key != "" {
+		path += "/" + url.PathEscape(key)
+	}
+	return path
+}
+
+// ListKVNamespaces retrieves every Workers KV namespace of an account
+func (c *Client) ListKVNamespaces(ctx context.Context, accountID string) ([]cloudflare.KVNamespace, error) {
+	namespaces := []cloudflare.KVNamespace{}
+	for page := 1; ; page++ {
+		items, totalPages, err := c.listKVNamespacesPage(ctx, accountID, page)
+		if err != nil {
+			return nil, fmt.Errorf("list kv namespaces: %w", err)
+		}
+		namespaces = append(namespaces, items...)
+		if page >= totalPages {
+			return namespaces, nil
+		}
+	}
+}
+
+// listKVNamespacesPage fetches one page of namespaces with its own timeout
+// and returns the total page count
+func (c *Client) listKVNamespacesPage(ctx context.Context, accountID string, page int) ([]cloudflare.KVNamespace, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(page))
+	query.Set("per_page", fmt.Sprint(kvNamespacesPerPage))
+	path := fmt.Sprintf("accounts/%s/storage/kv/namespaces?%s", accountID, query.Encode())
+
+	// doRaw drops result_info, which holds the page count
+	var raw []byte
+	if err := c.api.Execute(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, 0, err
+	}
+	var envelope struct {
+		Result     []cloudflare.KVNamespace `json:"result"`
+		ResultInfo struct {
+			TotalPages int `json:"total_pages"`
+		} `json:"result_info"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, 0, fmt.Errorf("decode response: %w", err)
+	}
+	return envelope.Result, envelope.ResultInfo.TotalPages, nil
+}
+
+// ListKVKeys retrieves one page of the keys of a namespace that start with
+// prefix. Pass the cursor of the previous page to continue a listing; limit
+// is between 10 and 1000, or zero for the API default of 1000.
+func (c *Client) ListKVKeys(ctx context.Context, accountID, namespaceID, prefix, cursor string, limit int) (*cloudflare.KVKeyPage, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	query := url.Values{}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", fmt.Sprint(limit))
+	}
+	path := kvPath(accountID, namespaceID, "keys", "")
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	// doRaw drops result_info, which holds the cursor
+	var raw []byte
+	if err := c.api.Execute(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, fmt.Errorf("list kv keys: %w", err)
+	}
+	var envelope struct {
+		Result     []cloudflare.KVKey `json:"result"`
+		ResultInfo struct {
+			Cursor string `json:"cursor"`
+		} `json:"result_info"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("list kv keys: decode response: %w", err)
+	}
+
+	page := &cloudflare.KVKeyPage{Keys: envelope.Result, Cursor: envelope.ResultInfo.Cursor}
+	if page.Keys == nil {
+		page.Keys = []cloudflare.KVKey{}
+	}
+	return page, nil
+}
+
+// GetKVKey retrieves the expiration and metadata of a key. Neither has an
+// endpoint of its own, so the key is looked up in a listing; a key that is
+// missing from it is reported without expiration or metadata.
+func (c *Client) GetKVKey(ctx context.Context, accountID, namespaceID, key string) (*cloudflare.KVKey, error) {
+	// Keys are listed in byte order, so the key itself comes before any
+	// other key it is a prefix of
+	page, err := c.ListKVKeys(ctx, accountID, namespaceID, key, "", 10)
+	if err != nil {
+		return nil, fmt.Errorf("get kv key: %w", err)
+	}
+	for _, k := range page.Keys {
+		if k.Name == key {
+			return &k, nil
+		}
+	}
+	return &cloudflare.KVKey{Name: key}, nil
+}
+
+// GetKVValue retrieves the value stored under a key
+func (c *Client) GetKVValue(ctx context.Context, accountID, namespaceID, key string) ([]byte, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Values are returned as the raw body, without a result envelope
+	var value []byte
+	if err := c.api.Execute(ctx, http.MethodGet, kvPath(accountID, namespaceID, "values", key), nil, &value); err != nil {
+		return nil, fmt.Errorf("get kv value: %w", err)
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return value, nil
+}
+
+// kvValueBody encodes a value and its metadata as the multipart form the
+// write endpoint expects and returns it with its content type
+func kvValueBody(value []byte, metadata json.RawMessage) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	if err := w.WriteField("value", string(value)); err != nil {
+		return nil, "", err
+	}
+	if len(metadata) > 0 {
+		if err := w.WriteField("metadata", string(metadata)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// PutKVValue writes a value under a key, replacing the value, metadata and
+// expiration the key had
+func (c *Client) PutKVValue(ctx context.Context, accountID, namespaceID, key string, value []byte, opts KVWriteOptions) error {
+	body, contentType, err := kvValueBody(value, opts.Metadata)
+	if err != nil {
+		return fmt.Errorf("put kv value: %w", err)
+	}
+
+	query := url.Values{}
+	if opts.Expiration > 0 {
+		query.Set("expiration", fmt.Sprint(opts.Expiration))
+	}
+	if opts.ExpirationTTL > 0 {
+		query.Set("expiration_ttl", fmt.Sprint(opts.ExpirationTTL))
+	}
+	path := kvPath(accountID, namespaceID, "values", key)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.doRaw(ctx, http.MethodPut, path, nil, nil, option.WithRequestBody(contentType, body)); err != nil {
+		return fmt.Errorf("put kv value: %w", err)
+	}
+	return nil
+}
+
+// DeleteKVValue removes a key and its value from a namespace
+func (c *Client) DeleteKVValue(ctx context.Context, accountID, namespaceID, key string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.doRaw(ctx, http.MethodDelete, kvPath(accountID, namespaceID, "values", key), nil, nil); err != nil {
+		return fmt.Errorf("delete kv value: %w", err)
+	}
+	return nil
+}
+
+// BulkPutKV writes many pairs to a namespace, in batches of the most the
+// API accepts at once. Keys the API rejected are returned; writing stops at
+// the first batch that fails outright.
+func (c *Client) BulkPutKV(ctx context.Context, accountID, namespaceID string, pairs []cloudflare.KVPair) ([]string, error) {
+	var unsuccessful []string
+	for start := 0; start < len(pairs); start += kvBulkLimit {
+		end := min(start+kvBulkLimit, len(pairs))
+		failed, err := c.bulkPutKVBatch(ctx, accountID, namespaceID, pairs[start:end])
+		if err != nil {
+			return unsuccessful, fmt.Errorf("bulk put kv (keys %d-%d of %d): %w", start+1, end, len(pairs), err)
+		}
+		unsuccessful = append(unsuccessful, failed...)
+	}
+	return unsuccessful, nil
+}
+
+// bulkPutKVBatch sends one batch with its own timeout
+func (c *Client) bulkPutKVBatch(ctx context.Context, accountID, namespaceID string, pairs []cloudflare.KVPair) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var result struct {
+		UnsuccessfulKeys []string `json:"unsuccessful_keys"`
+	}
+	if err := c.doRaw(ctx, http.MethodPut, kvPath(accountID, namespaceID, "bulk", ""), pairs, &result); err != nil {
+		return nil, err
+	}
+	return result.UnsuccessfulKeys, nil
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListKVNamespaces(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"result":      []map[string]string{{"id": "ns-" + page, "title": "CONFIG_" + page}},
+			"result_info": map[string]int{"total_pages": 2},
+		})
+	}))
+
+	namespaces, err := client.ListKVNamespaces(context.Background(), "acct-1")
+	require.NoError(t, err)
+	assert.Equal(t, []cloudflare.KVNamespace{{ID: "ns-1", Title: "CONFIG_1"}, {ID: "ns-2", Title: "CONFIG_2"}}, namespaces)
+}
+
+func TestListKVKeys(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces/ns-1/keys", r.URL.Path)
+		assert.Equal(t, "feature/", r.URL.Query().Get("prefix"))
+		assert.Equal(t, "c1", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"result": []map[string]interface{}{
+				{"name": "feature/beta", "expiration": 1700000000, "metadata": map[string]string{"owner": "web"}},
+				{"name": "feature/dark-mode"},
+			},
+			"result_info": map[string]interface{}{"count": 2, "cursor": "c2"},
+		})
+	}))
+
+	page, err := client.ListKVKeys(context.Background(), "acct-1", "ns-1", "feature/", "c1", 50)
+	require.NoError(t, err)
+	assert.Equal(t, "c2", page.Cursor)
+	require.Len(t, page.Keys, 2)
+	assert.Equal(t, int64(1700000000), page.Keys[0].Expiration)
+	assert.JSONEq(t, `{"owner":"web"}`, string(page.Keys[0].Metadata))
+	assert.Equal(t, "feature/dark-mode", page.Keys[1].Name)
+}
+
+func TestGetKVValue(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Slashes in key names must not become path separators
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces/ns-1/values/feature%2Fbeta", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(`{"enabled":true}`))
+	}))
+
+	value, err := client.GetKVValue(context.Background(), "acct-1", "ns-1", "feature/beta")
+	require.NoError(t, err)
+	assert.Equal(t, `{"enabled":true}`, string(value))
+}
+
+func TestGetKVKey(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("prefix") != "beta" {
+			writeResult(w, []interface{}{})
+			return
+		}
+		writeResult(w, []map[string]interface{}{
+			{"name": "beta", "expiration": 1700000000},
+			{"name": "beta-2"},
+		})
+	}))
+
+	key, err := client.GetKVKey(context.Background(), "acct-1", "ns-1", "beta")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), key.Expiration)
+
+	key, err = client.GetKVKey(context.Background(), "acct-1", "ns-1", "gamma")
+	require.NoError(t, err)
+	assert.Equal(t, cloudflare.KVKey{Name: "gamma"}, *key)
+}
+
+func TestPutKVValue(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces/ns-1/values/greeting", r.URL.Path)
+		assert.Equal(t, "3600", r.URL.Query().Get("expiration_ttl"))
+		assert.Empty(t, r.URL.Query().Get("expiration"))
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediaType)
+
+		parts := map[string]string{}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(part)
+			require.NoError(t, err)
+			parts[part.FormName()] = string(data)
+		}
+		assert.Equal(t, map[string]string{"value": "hello", "metadata": `{"lang":"en"}`}, parts)
+
+		writeResult(w, map[string]interface{}{})
+	}))
+
+	err := client.PutKVValue(context.Background(), "acct-1", "ns-1", "greeting", []byte("hello"), KVWriteOptions{
+		ExpirationTTL: 3600,
+		Metadata:      json.RawMessage(`{"lang":"en"}`),
+	})
+	require.NoError(t, err)
+}
+
+func TestDeleteKVValue(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces/ns-1/values/greeting", r.URL.Path)
+		writeResult(w, nil)
+	}))
+
+	require.NoError(t, client.DeleteKVValue(context.Background(), "acct-1", "ns-1", "greeting"))
+}
+
+func TestBulkPutKV(t *testing.T) {
+	var batches []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/accounts/acct-1/storage/kv/namespaces/ns-1/bulk", r.URL.Path)
+
+		var pairs []cloudflare.KVPair
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pairs))
+		batches = append(batches, len(pairs))
+
+		var unsuccessful []string
+		if len(batches) == 2 {
+			unsuccessful = []string{pairs[0].Key}
+		}
+		writeResult(w, map[string]interface{}{"successful_key_count": len(pairs) - len(unsuccessful), "unsuccessful_keys": unsuccessful})
+	}))
+
+	pairs := make([]cloudflare.KVPair, kvBulkLimit+1)
+	for i := range pairs {
+		pairs[i] = cloudflare.KVPair{Key: fmt.Sprintf("key-%d", i), Value: "v"}
+	}
+
+	unsuccessful, err := client.BulkPutKV(context.Background(), "acct-1", "ns-1", pairs)
+	require.NoError(t, err)
+	assert.Equal(t, []int{kvBulkLimit, 1}, batches)
+	assert.Equal(t, []string{fmt.Sprintf("key-%d", kvBulkLimit)}, unsuccessful)
+}
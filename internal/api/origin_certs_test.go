@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOriginCertificate(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/certificates", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "origin-ecc", body["request_type"])
+		assert.Equal(t, float64(5475), body["requested_validity"])
+		assert.Equal(t, []interface{}{"example.com", "*.example.com"}, body["hostnames"])
+
+		writeResult(w, map[string]interface{}{
+			"id":                 "oc-1",
+			"hostnames":          body["hostnames"],
+			"request_type":       body["request_type"],
+			"requested_validity": 5475,
+			"expires_on":         "2041-01-01 05:20:00 +0000 UTC",
+			"certificate":        "-----BEGIN CERTIFICATE-----\n...",
+		})
+	}))
+
+	cert, err := client.CreateOriginCertificate(context.Background(), "csr", []string{"example.com", "*.example.com"}, "origin-ecc", 5475)
+	require.NoError(t, err)
+	assert.Equal(t, "oc-1", cert.ID)
+	assert.Equal(t, time.Date(2041, 1, 1, 5, 20, 0, 0, time.UTC), cert.ExpiresOn.UTC())
+	assert.Contains(t, cert.Certificate, "BEGIN CERTIFICATE")
+}
+
+func TestListOriginCertificates(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/certificates", r.URL.Path)
+		assert.Equal(t, "zone-1", r.URL.Query().Get("zone_id"))
+		writeResult(w, []map[string]interface{}{{
+			"id":          "oc-1",
+			"hostnames":   []string{"example.com"},
+			"expires_on":  "2030-06-01T00:00:00Z",
+			"certificate": "pem",
+		}})
+	}))
+
+	certs, err := client.ListOriginCertificates(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, 2030, certs[0].ExpiresOn.Year())
+	assert.Empty(t, certs[0].Certificate)
+}
+
+func TestRevokeOriginCertificate(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/certificates/oc-1", r.URL.Path)
+		writeResult(w, map[string]string{"id": "oc-1"})
+	}))
+
+	require.NoError(t, client.RevokeOriginCertificate(context.Background(), "oc-1"))
+}
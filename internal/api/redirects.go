@@ -0,0 +1,646 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+const (
+	// RedirectRulesPhase is the ruleset phase that holds a zone's Single Redirects
+	RedirectRulesPhase = "http_request_dynamic_redirect"
+
+	// BulkRedirectsPhase is the account ruleset phase that turns Bulk
+	// Redirect lists on
+	BulkRedirectsPhase = "http_request_redirect"
+
+	redirectAction = "redirect"
+)
+
+// bulkOperationPollInterval is how often an asynchronous list operation is
+// checked for completion
+var bulkOperationPollInterval = time.Second
+
+// pageRule is the API representation of a Page Rule
+type pageRule struct {
+	ID       string                      `json:"id,omitempty"`
+	Targets  []pageRuleTarget            `json:"targets"`
+	Actions  []cloudflare.PageRuleAction `json:"actions"`
+	Priority int                         `json:"priority,omitempty"`
+	Status   string                      `json:"status"`
+}
+
+type pageRuleTarget struct {
+	Target     string `json:"target"`
+	Constraint struct {
+		Operator string `json:"operator"`
+		Value    string `json:"value"`
+	} `json:"constraint"`
+}
+
+func toPageRule(r pageRule) cloudflare.PageRule {
+	rule := cloudflare.PageRule{
+		ID:       r.ID,
+		Actions:  r.Actions,
+		Priority: r.Priority,
+		Status:   r.Status,
+	}
+	if len(r.Targets) > 0 {
+		rule.URL = r.Targets[0].Constraint.Value
+	}
+	return rule
+}
+
+func fromPageRule(r cloudflare.PageRule) pageRule {
+	target := pageRuleTarget{Target: "url"}
+	target.Constraint.Operator = "matches"
+	target.Constraint.Value = r.URL
+
+	status := r.Status
+	if status == "" {
+		status = "active"
+	}
+	return pageRule{
+		Targets:  []pageRuleTarget{target},
+		Actions:  r.Actions,
+		Priority: r.Priority,
+		Status:   status,
+	}
+}
+
+// ListPageRules retrieves the Page Rules of a zone in priority order
+func (c *Client) ListPageRules(ctx context.Context, zoneID string) ([]cloudflare.PageRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rules []pageRule
+	path := fmt.Sprintf("zones/%s/pagerules?order=priority&direction=desc", zoneID)
+	if err := c.doRaw(ctx, http.MethodGet, path, nil, &rules); err != nil {
+		return nil, fmt.Errorf("list page rules: %w", err)
+	}
+
+	list := make([]cloudflare.PageRule, len(rules))
+	for i, r := range rules {
+		list[i] = toPageRule(r)
+	}
+	return list, nil
+}
+
+// CreatePageRule adds a Page Rule to a zone
+func (c *Client) CreatePageRule(ctx context.Context, zoneID string, rule cloudflare.PageRule) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("zones/%s/pagerules", zoneID)
+	if err := c.doRaw(ctx, http.MethodPost, path, fromPageRule(rule), nil); err != nil {
+		return fmt.Errorf("create page rule: %w", err)
+	}
+	return nil
+}
+
+// UpdatePageRule replaces an existing Page Rule identified by rule.ID
+func (c *Client) UpdatePageRule(ctx context.Context, zoneID string, rule cloudflare.PageRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("update page rule: rule ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("zones/%s/pagerules/%s", zoneID, rule.ID)
+	if err := c.doRaw(ctx, http.MethodPut, path, fromPageRule(rule), nil); err != nil {
+		return fmt.Errorf("update page rule: %w", err)
+	}
+	return nil
+}
+
+// DeletePageRule removes a Page Rule from a zone
+func (c *Client) DeletePageRule(ctx context.Context, zoneID, ruleID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("zones/%s/pagerules/%s", zoneID, ruleID)
+	if err := c.doRaw(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("delete page rule: %w", err)
+	}
+	return nil
+}
+
+// redirectRule is the ruleset representation of a Single Redirect
+type redirectRule struct {
+	ID               string `json:"id,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Expression       string `json:"expression"`
+	Action           string `json:"action"`
+	Enabled          bool   `json:"enabled"`
+	ActionParameters struct {
+		FromValue struct {
+			TargetURL struct {
+				Value      string `json:"value,omitempty"`
+				Expression string `json:"expression,omitempty"`
+			} `json:"target_url"`
+			StatusCode          int  `json:"status_code,omitempty"`
+			PreserveQueryString bool `json:"preserve_query_string"`
+		} `json:"from_value"`
+	} `json:"action_parameters"`
+
+	raw json.RawMessage // the rule as the API returned it
+}
+
+// redirectRuleFields are the JSON keys redirectRule models. The keys of
+// action_parameters and from_value it doesn't model are kept as well.
+var redirectRuleFields = jsonFields{
+	modeled: []string{"id", "description", "expression", "action", "enabled"},
+	nested: map[string]jsonFields{
+		"action_parameters": {nested: map[string]jsonFields{
+			"from_value": {modeled: []string{"target_url", "status_code", "preserve_query_string"}},
+		}},
+	},
+}
+
+// UnmarshalJSON decodes a rule and keeps its JSON in raw
+func (r *redirectRule) UnmarshalJSON(data []byte) error {
+	type plain redirectRule
+	var rule plain
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	*r = redirectRule(rule)
+	r.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes a rule, carrying over the fields of raw the struct
+// doesn't model
+func (r redirectRule) MarshalJSON() ([]byte, error) {
+	type plain redirectRule
+	known, err := json.Marshal(plain(r))
+	if err != nil || len(r.raw) == 0 {
+		return known, err
+	}
+	return mergeFields(r.raw, known, redirectRuleFields)
+}
+
+// jsonFields describes which keys of a JSON object a struct models
+type jsonFields struct {
+	modeled []string              // keys always taken from the struct
+	nested  map[string]jsonFields // objects merged key by key
+}
+
+// mergeFields overlays the JSON object known onto raw. The modeled keys of
+// raw are dropped first, so a field the struct leaves out is cleared
+// rather than kept from raw. If raw isn't an object, known is returned.
+func mergeFields(raw, known json.RawMessage, fields jsonFields) (json.RawMessage, error) {
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &merged); err != nil || merged == nil {
+		return known, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(known, &values); err != nil {
+		return nil, err
+	}
+
+	for _, key := range fields.modeled {
+		delete(merged, key)
+	}
+	for key, value := range values {
+		if nested, ok := fields.nested[key]; ok && merged[key] != nil {
+			var err error
+			if value, err = mergeFields(merged[key], value, nested); err != nil {
+				return nil, err
+			}
+		}
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+type redirectRuleset struct {
+	ID    string         `json:"id"`
+	Rules []redirectRule `json:"rules"`
+}
+
+func toRedirectRule(r redirectRule) cloudflare.RedirectRule {
+	from := r.ActionParameters.FromValue
+	return cloudflare.RedirectRule{
+		ID:                  r.ID,
+		Description:         r.Description,
+		Expression:          r.Expression,
+		TargetURL:           from.TargetURL.Value,
+		TargetExpression:    from.TargetURL.Expression,
+		StatusCode:          from.StatusCode,
+		PreserveQueryString: from.PreserveQueryString,
+		Enabled:             r.Enabled,
+		Raw:                 r.raw,
+	}
+}
+
+func fromRedirectRule(r cloudflare.RedirectRule) redirectRule {
+	rule := redirectRule{
+		Description: r.Description,
+		Expression:  r.Expression,
+		Action:      redirectAction,
+		Enabled:     r.Enabled,
+		raw:         withoutFields(r.Raw, rulesetReadOnlyFields...),
+	}
+	from := &rule.ActionParameters.FromValue
+	if r.TargetURL != "" {
+		from.TargetURL.Value = r.TargetURL
+	} else {
+		from.TargetURL.Expression = r.TargetExpression
+	}
+	from.StatusCode = r.StatusCode
+	from.PreserveQueryString = r.PreserveQueryString
+	return rule
+}
+
+func redirectRulesEntrypointPath(zoneID string) string {
+	return fmt.Sprintf("zones/%s/rulesets/phases/%s/entrypoint", zoneID, RedirectRulesPhase)
+}
+
+// ListRedirectRules retrieves the Single Redirects of a zone in evaluation order
+func (c *Client) ListRedirectRules(ctx context.Context, zoneID string) ([]cloudflare.RedirectRule, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs redirectRuleset
+	if _, err := c.getEntrypoint(ctx, redirectRulesEntrypointPath(zoneID), &rs); err != nil {
+		return nil, fmt.Errorf("list redirect rules: %w", err)
+	}
+
+	rules := make([]cloudflare.RedirectRule, len(rs.Rules))
+	for i, r := range rs.Rules {
+		rules[i] = toRedirectRule(r)
+	}
+	return rules, nil
+}
+
+// CreateRedirectRule appends a Single Redirect to the zone, creating the
+// entrypoint ruleset if the zone does not have one yet
+func (c *Client) CreateRedirectRule(ctx context.Context, zoneID string, rule cloudflare.RedirectRule) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs redirectRuleset
+	found, err := c.getEntrypoint(ctx, redirectRulesEntrypointPath(zoneID), &rs)
+	if err != nil {
+		return fmt.Errorf("create redirect rule: %w", err)
+	}
+
+	if !found {
+		body := map[string]interface{}{
+			"rules": []redirectRule{fromRedirectRule(rule)},
+		}
+		if err := c.doRaw(ctx, http.MethodPut, redirectRulesEntrypointPath(zoneID), body, nil); err != nil {
+			return fmt.Errorf("create redirect rule: %w", err)
+		}
+		return nil
+	}
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules", zoneID, rs.ID)
+	if err := c.doRaw(ctx, http.MethodPost, path, fromRedirectRule(rule), nil); err != nil {
+		return fmt.Errorf("create redirect rule: %w", err)
+	}
+	return nil
+}
+
+// UpdateRedirectRule replaces an existing Single Redirect identified by rule.ID
+func (c *Client) UpdateRedirectRule(ctx context.Context, zoneID string, rule cloudflare.RedirectRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("update redirect rule: rule ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs redirectRuleset
+	found, err := c.getEntrypoint(ctx, redirectRulesEntrypointPath(zoneID), &rs)
+	if err != nil {
+		return fmt.Errorf("update redirect rule: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("update redirect rule: zone has no redirect rules")
+	}
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules/%s", zoneID, rs.ID, rule.ID)
+	if err := c.doRaw(ctx, http.MethodPatch, path, fromRedirectRule(rule), nil); err != nil {
+		return fmt.Errorf("update redirect rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRedirectRule removes a Single Redirect from the zone
+func (c *Client) DeleteRedirectRule(ctx context.Context, zoneID, ruleID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var rs redirectRuleset
+	found, err := c.getEntrypoint(ctx, redirectRulesEntrypointPath(zoneID), &rs)
+	if err != nil {
+		return fmt.Errorf("delete redirect rule: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("delete redirect rule: zone has no redirect rules")
+	}
+
+	path := fmt.Sprintf("zones/%s/rulesets/%s/rules/%s", zoneID, rs.ID, ruleID)
+	if err := c.doRaw(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("delete redirect rule: %w", err)
+	}
+	return nil
+}
+
+// bulkList is the API representation of an account list
+type bulkList struct {
+	cloudflare.BulkRedirectList
+	Kind string `json:"kind"`
+}
+
+// ListBulkRedirectLists retrieves the Bulk Redirect lists of an account
+func (c *Client) ListBulkRedirectLists(ctx context.Context, accountID string) ([]cloudflare.BulkRedirectList, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var lists []bulkList
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("accounts/%s/rules/lists", accountID), nil, &lists); err != nil {
+		return nil, fmt.Errorf("list bulk redirect lists: %w", err)
+	}
+
+	// Accounts also hold IP, hostname and ASN lists
+	redirects := []cloudflare.BulkRedirectList{}
+	for _, l := range lists {
+		if l.Kind == redirectAction {
+			redirects = append(redirects, l.BulkRedirectList)
+		}
+	}
+	return redirects, nil
+}
+
+// FindBulkRedirectList looks up a Bulk Redirect list by name. A nil list
+// with no error means the account has no list with that name.
+func (c *Client) FindBulkRedirectList(ctx context.Context, accountID, name string) (*cloudflare.BulkRedirectList, error) {
+	lists, err := c.ListBulkRedirectLists(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		if l.Name == name {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateBulkRedirectList creates an empty Bulk Redirect list. Names may only
+// contain lowercase letters, numbers and underscores.
+func (c *Client) CreateBulkRedirectList(ctx context.Context, accountID, name, description string) (*cloudflare.BulkRedirectList, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	body := map[string]string{
+		"name":        name,
+		"kind":        redirectAction,
+		"description": description,
+	}
+
+	var l bulkList
+	if err := c.doRaw(ctx, http.MethodPost, fmt.Sprintf("accounts/%s/rules/lists", accountID), body, &l); err != nil {
+		return nil, fmt.Errorf("create bulk redirect list: %w", err)
+	}
+	return &l.BulkRedirectList, nil
+}
+
+// DeleteBulkRedirectList deletes a Bulk Redirect list. The API refuses while
+// a rule still references the list.
+func (c *Client) DeleteBulkRedirectList(ctx context.Context, accountID, listID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("accounts/%s/rules/lists/%s", accountID, listID)
+	if err := c.doRaw(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("delete bulk redirect list: %w", err)
+	}
+	return nil
+}
+
+// bulkListItem is the API representation of a redirect list item
+type bulkListItem struct {
+	ID       string                  `json:"id,omitempty"`
+	Redirect cloudflare.BulkRedirect `json:"redirect"`
+}
+
+// ListBulkRedirects retrieves every item of a Bulk Redirect list, following
+// the list's cursor pagination
+func (c *Client) ListBulkRedirects(ctx context.Context, accountID, listID string) ([]cloudflare.BulkRedirect, error) {
+	var redirects []cloudflare.BulkRedirect
+	cursor := ""
+	for {
+		items, next, err := c.listBulkRedirectsPage(ctx, accountID, listID, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("list bulk redirects: %w", err)
+		}
+		for _, item := range items {
+			r := item.Redirect
+			r.ID = item.ID
+			redirects = append(redirects, r)
+		}
+		if next == "" {
+			return redirects, nil
+		}
+		cursor = next
+	}
+}
+
+// listBulkRedirectsPage fetches one page of list items with its own timeout
+// and returns the cursor of the next page ("" on the last page)
+func (c *Client) listBulkRedirectsPage(ctx context.Context, accountID, listID, cursor string) ([]bulkListItem, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("accounts/%s/rules/lists/%s/items", accountID, listID)
+	if cursor != "" {
+		path += "?cursor=" + url.QueryEscape(cursor)
+	}
+
+	// doRaw drops result_info, which holds the cursors
+	var raw []byte
+	if err := c.api.Execute(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		return nil, "", err
+	}
+	var envelope struct {
+		Result     []bulkListItem `json:"result"`
+		ResultInfo struct {
+			Cursors struct {
+				After string `json:"after"`
+			} `json:"cursors"`
+		} `json:"result_info"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, "", fmt.Errorf("decode response: %w", err)
+	}
+	return envelope.Result, envelope.ResultInfo.Cursors.After, nil
+}
+
+// ReplaceBulkRedirects replaces every item of a Bulk Redirect list and
+// waits for Cloudflare to finish applying the change
+func (c *Client) ReplaceBulkRedirects(ctx context.Context, accountID, listID string, redirects []cloudflare.BulkRedirect) error {
+	if err := c.bulkListOperation(ctx, accountID, http.MethodPut, listID, bulkItems(redirects)); err != nil {
+		return fmt.Errorf("replace bulk redirects: %w", err)
+	}
+	return nil
+}
+
+// AddBulkRedirects appends items to a Bulk Redirect list and waits for
+// Cloudflare to finish applying the change
+func (c *Client) AddBulkRedirects(ctx context.Context, accountID, listID string, redirects []cloudflare.BulkRedirect) error {
+	if err := c.bulkListOperation(ctx, accountID, http.MethodPost, listID, bulkItems(redirects)); err != nil {
+		return fmt.Errorf("add bulk redirects: %w", err)
+	}
+	return nil
+}
+
+// DeleteBulkRedirects removes items, by item ID, from a Bulk Redirect list
+// and waits for Cloudflare to finish applying the change
+func (c *Client) DeleteBulkRedirects(ctx context.Context, accountID, listID string, itemIDs []string) error {
+	items := make([]map[string]string, len(itemIDs))
+	for i, id := range itemIDs {
+		items[i] = map[string]string{"id": id}
+	}
+	body := map[string]interface{}{"items": items}
+	if err := c.bulkListOperation(ctx, accountID, http.MethodDelete, listID, body); err != nil {
+		return fmt.Errorf("delete bulk redirects: %w", err)
+	}
+	return nil
+}
+
+func bulkItems(redirects []cloudflare.BulkRedirect) []bulkListItem {
+	items := make([]bulkListItem, len(redirects))
+	for i, r := range redirects {
+		r.ID = ""
+		items[i] = bulkListItem{Redirect: r}
+	}
+	return items
+}
+
+// bulkListOperation starts an asynchronous change to a list's items and
+// polls until it completes. Each request has its own timeout; ctx bounds
+// the whole wait.
+func (c *Client) bulkListOperation(ctx context.Context, accountID, method, listID string, body interface{}) error {
+	var op struct {
+		OperationID string `json:"operation_id"`
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	err := c.doRaw(reqCtx, method, fmt.Sprintf("accounts/%s/rules/lists/%s/items", accountID, listID), body, &op)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if op.OperationID == "" {
+		return nil
+	}
+
+	path := fmt.Sprintf("accounts/%s/rules/lists/bulk_operations/%s", accountID, op.OperationID)
+	for {
+		var status struct {
+			Status string `json:"status"` // pending, running, completed or failed
+			Error  string `json:"error"`
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := c.doRaw(reqCtx, http.MethodGet, path, nil, &status)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		switch status.Status {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("operation %s failed: %s", op.OperationID, status.Error)
+		}
+
+		select {
+		case <-time.After(bulkOperationPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// bulkRedirectRuleset is the account entrypoint of the Bulk Redirects phase
+type bulkRedirectRuleset struct {
+	ID    string `json:"id"`
+	Rules []struct {
+		ActionParameters struct {
+			FromList struct {
+				Name string `json:"name"`
+			} `json:"from_list"`
+		} `json:"action_parameters"`
+	} `json:"rules"`
+}
+
+// EnableBulkRedirectList adds the account rule that applies a Bulk Redirect
+// list, unless a rule already references it. It reports whether a rule was
+// added.
+func (c *Client) EnableBulkRedirectList(ctx context.Context, accountID, listName string) (bool, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	entrypoint := fmt.Sprintf("accounts/%s/rulesets/phases/%s/entrypoint", accountID, BulkRedirectsPhase)
+
+	var rs bulkRedirectRuleset
+	found, err := c.getEntrypoint(ctx, entrypoint, &rs)
+	if err != nil {
+		return false, fmt.Errorf("enable bulk redirect list: %w", err)
+	}
+	for _, r := range rs.Rules {
+		if r.ActionParameters.FromList.Name == listName {
+			return false, nil
+		}
+	}
+
+	rule := map[string]interface{}{
+		"description": "Bulk redirects from " + listName,
+		"expression":  fmt.Sprintf("http.request.full_uri in $%s", listName),
+		"action":      redirectAction,
+		"enabled":     true,
+		"action_parameters": map[string]interface{}{
+			"from_list": map[string]string{
+				"name": listName,
+				"key":  "http.request.full_uri",
+			},
+		},
+	}
+
+	if !found {
+		body := map[string]interface{}{"rules": []interface{}{rule}}
+		if err := c.doRaw(ctx, http.MethodPut, entrypoint, body, nil); err != nil {
+			return false, fmt.Errorf("enable bulk redirect list: %w", err)
+		}
+		return true, nil
+	}
+
+	path := fmt.Sprintf("accounts/%s/rulesets/%s/rules", accountID, rs.ID)
+	if err := c.doRaw(ctx, http.MethodPost, path, rule, nil); err != nil {
+		return false, fmt.Errorf("enable bulk redirect list: %w", err)
+	}
+	return true, nil
+}
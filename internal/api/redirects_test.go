@@ -0,0 +1,313 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPageRules(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/pagerules", r.URL.Path)
+		assert.Equal(t, "priority", r.URL.Query().Get("order"))
+		writeResult(w, []map[string]interface{}{
+			{
+				"id":       "pr-1",
+				"targets":  []map[string]interface{}{{"target": "url", "constraint": map[string]string{"operator": "matches", "value": "example.com/old/*"}}},
+				"actions":  []map[string]interface{}{{"id": "forwarding_url", "value": map[string]interface{}{"url": "https://example.com/new/$1", "status_code": 301}}},
+				"priority": 2,
+				"status":   "active",
+			},
+		})
+	}))
+
+	rules, err := client.ListPageRules(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "example.com/old/*", rules[0].URL)
+	assert.Equal(t, "active", rules[0].Status)
+
+	target, status, ok := rules[0].Forwarding()
+	require.True(t, ok)
+	assert.Equal(t, "https://example.com/new/$1", target)
+	assert.Equal(t, 301, status)
+}
+
+func TestCreatePageRule(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/zones/zone-1/pagerules", r.URL.Path)
+
+		var body pageRule
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Targets, 1)
+		assert.Equal(t, "matches", body.Targets[0].Constraint.Operator)
+		assert.Equal(t, "example.com/old", body.Targets[0].Constraint.Value)
+		assert.Equal(t, "active", body.Status)
+		assert.Equal(t, "forwarding_url", body.Actions[0].ID)
+		writeResult(w, map[string]string{"id": "pr-1"})
+	}))
+
+	err := client.CreatePageRule(context.Background(), "zone-1", cloudflare.PageRule{
+		URL: "example.com/old",
+		Actions: []cloudflare.PageRuleAction{
+			{ID: "forwarding_url", Value: map[string]interface{}{"url": "https://example.com/new", "status_code": 301}},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestCreateRedirectRuleWithoutEntrypoint(t *testing.T) {
+	var put bool
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/zone-1/rulesets/phases/http_request_dynamic_redirect/entrypoint", r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10003,"message":"not found"}],"messages":[],"result":null}`))
+			return
+		}
+
+		put = true
+		assert.Equal(t, http.MethodPut, r.Method)
+		var body struct {
+			Rules []redirectRule `json:"rules"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Rules, 1)
+		assert.Equal(t, "redirect", body.Rules[0].Action)
+		assert.Equal(t, "https://example.com/new", body.Rules[0].ActionParameters.FromValue.TargetURL.Value)
+		assert.Equal(t, 308, body.Rules[0].ActionParameters.FromValue.StatusCode)
+		writeResult(w, map[string]string{"id": "rs-1"})
+	}))
+
+	err := client.CreateRedirectRule(context.Background(), "zone-1", cloudflare.RedirectRule{
+		Expression: `http.request.full_uri eq "https://example.com/old"`,
+		TargetURL:  "https://example.com/new",
+		StatusCode: 308,
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	assert.True(t, put)
+}
+
+func TestListRedirectRules(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, map[string]interface{}{
+			"id": "rs-1",
+			"rules": []map[string]interface{}{
+				{
+					"id":          "rule-1",
+					"description": "Old blog",
+					"expression":  `http.request.uri.path eq "/blog"`,
+					"action":      "redirect",
+					"enabled":     true,
+					"action_parameters": map[string]interface{}{
+						"from_value": map[string]interface{}{
+							"target_url":            map[string]string{"expression": `concat("https://blog.example.com", http.request.uri.path)`},
+							"status_code":           301,
+							"preserve_query_string": true,
+						},
+					},
+				},
+			},
+		})
+	}))
+
+	rules, err := client.ListRedirectRules(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "Old blog", rules[0].Description)
+	assert.Empty(t, rules[0].TargetURL)
+	assert.Equal(t, `concat("https://blog.example.com", http.request.uri.path)`, rules[0].TargetExpression)
+	assert.Equal(t, 301, rules[0].StatusCode)
+	assert.True(t, rules[0].PreserveQueryString)
+}
+
+func TestUpdateRedirectRuleKeepsUnmodeledFields(t *testing.T) {
+	var body map[string]json.RawMessage
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeResult(w, map[string]interface{}{
+				"id": "rs-1",
+				"rules": []map[string]interface{}{{
+					"id":           "rule-1",
+					"version":      "2",
+					"ref":          "legacy-blog",
+					"description":  "Old blog",
+					"expression":   `http.request.uri.path eq "/blog"`,
+					"action":       "redirect",
+					"enabled":      true,
+					"last_updated": "2025-01-02T15:04:05Z",
+					"logging":      map[string]bool{"enabled": false},
+					"action_parameters": map[string]interface{}{
+						"from_value": map[string]interface{}{
+							"target_url":            map[string]string{"value": "https://blog.example.com/"},
+							"status_code":           301,
+							"preserve_query_string": true,
+						},
+					},
+				}},
+			})
+			return
+		}
+
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/zone-1/rulesets/rs-1/rules/rule-1", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		writeResult(w, map[string]interface{}{"id": "rs-1"})
+	}))
+
+	rules, err := client.ListRedirectRules(context.Background(), "zone-1")
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	rule := rules[0]
+	rule.Description = ""
+	rule.TargetURL = ""
+	rule.TargetExpression = `concat("https://blog.example.com", http.request.uri.path)`
+	rule.StatusCode = 308
+	require.NoError(t, client.UpdateRedirectRule(context.Background(), "zone-1", rule))
+
+	assert.JSONEq(t, `"legacy-blog"`, string(body["ref"]))
+	assert.JSONEq(t, `{"enabled":false}`, string(body["logging"]))
+	assert.JSONEq(t, `{"from_value":{"target_url":{"expression":"concat(\"https://blog.example.com\", http.request.uri.path)"},"status_code":308,"preserve_query_string":true}}`, string(body["action_parameters"]))
+	assert.NotContains(t, body, "description")
+	assert.NotContains(t, body, "version")
+	assert.NotContains(t, body, "last_updated")
+}
+
+func TestListBulkRedirectLists(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct-1/rules/lists", r.URL.Path)
+		writeResult(w, []map[string]interface{}{
+			{"id": "l-1", "name": "blocked_ips", "kind": "ip", "num_items": 3},
+			{"id": "l-2", "name": "old_blog", "kind": "redirect", "num_items": 120},
+		})
+	}))
+
+	lists, err := client.ListBulkRedirectLists(context.Background(), "acct-1")
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "old_blog", lists[0].Name)
+	assert.Equal(t, 120, lists[0].NumItems)
+}
+
+func TestListBulkRedirectsFollowsCursor(t *testing.T) {
+	var cursors []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/acct-1/rules/lists/l-1/items", r.URL.Path)
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		after := "page-2"
+		source := "example.com/a"
+		if cursor == "page-2" {
+			after = ""
+			source = "example.com/b"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"errors":      []interface{}{},
+			"messages":    []interface{}{},
+			"result":      []map[string]interface{}{{"id": "item-" + source, "redirect": map[string]interface{}{"source_url": source, "target_url": "https://example.com/", "status_code": 301}}},
+			"result_info": map[string]interface{}{"cursors": map[string]string{"after": after}},
+		})
+	}))
+
+	redirects, err := client.ListBulkRedirects(context.Background(), "acct-1", "l-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "page-2"}, cursors)
+	require.Len(t, redirects, 2)
+	assert.Equal(t, "item-example.com/b", redirects[1].ID)
+	assert.Equal(t, "example.com/b", redirects[1].SourceURL)
+	assert.Equal(t, 301, redirects[1].StatusCode)
+}
+
+func TestReplaceBulkRedirectsWaitsForOperation(t *testing.T) {
+	bulkOperationPollInterval = time.Millisecond
+	t.Cleanup(func() { bulkOperationPollInterval = time.Second })
+
+	polls := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/acct-1/rules/lists/l-1/items":
+			assert.Equal(t, http.MethodPut, r.Method)
+			var items []bulkListItem
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&items))
+			require.Len(t, items, 1)
+			assert.Empty(t, items[0].ID)
+			assert.Equal(t, "example.com/old", items[0].Redirect.SourceURL)
+			writeResult(w, map[string]string{"operation_id": "op-1"})
+		case "/accounts/acct-1/rules/lists/bulk_operations/op-1":
+			polls++
+			status := "running"
+			if polls == 3 {
+				status = "completed"
+			}
+			writeResult(w, map[string]string{"id": "op-1", "status": status})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	err := client.ReplaceBulkRedirects(context.Background(), "acct-1", "l-1", []cloudflare.BulkRedirect{
+		{ID: "stale", SourceURL: "example.com/old", TargetURL: "https://example.com/new"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+}
+
+func TestBulkOperationFailed(t *testing.T) {
+	bulkOperationPollInterval = time.Millisecond
+	t.Cleanup(func() { bulkOperationPollInterval = time.Second })
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			writeResult(w, map[string]string{"operation_id": "op-1"})
+			return
+		}
+		writeResult(w, map[string]string{"id": "op-1", "status": "failed", "error": "item not found"})
+	}))
+
+	err := client.DeleteBulkRedirects(context.Background(), "acct-1", "l-1", []string{"item-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item not found")
+}
+
+func TestEnableBulkRedirectList(t *testing.T) {
+	var added map[string]interface{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			assert.Equal(t, "/accounts/acct-1/rulesets/phases/http_request_redirect/entrypoint", r.URL.Path)
+			writeResult(w, map[string]interface{}{
+				"id": "rs-1",
+				"rules": []map[string]interface{}{
+					{"action_parameters": map[string]interface{}{"from_list": map[string]string{"name": "existing"}}},
+				},
+			})
+		case r.Method == http.MethodPost:
+			assert.Equal(t, "/accounts/acct-1/rulesets/rs-1/rules", r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&added))
+			writeResult(w, map[string]string{"id": "rs-1"})
+		}
+	}))
+
+	enabled, err := client.EnableBulkRedirectList(context.Background(), "acct-1", "existing")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Nil(t, added)
+
+	enabled, err = client.EnableBulkRedirectList(context.Background(), "acct-1", "old_blog")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, "http.request.full_uri in $old_blog", added["expression"])
+}
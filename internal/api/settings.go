@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// zoneSetting is the API representation of one zone setting
+type zoneSetting struct {
+	ID    string          `json:"id"`
+	Value json.RawMessage `json:"value"`
+}
+
+// GetZoneSetting returns the current value of a zone setting such as
+// "security_level" as raw JSON, so it can be written back unchanged
+func (c *Client) GetZoneSetting(ctx context.Context, zoneID, setting string) (json.RawMessage, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var s zoneSetting
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("zones/%s/settings/%s", zoneID, setting), nil, &s); err != nil {
+		return nil, fmt.Errorf("get zone setting %s: %w", setting, err)
+	}
+	if len(s.Value) == 0 {
+		return nil, fmt.Errorf("get zone setting %s: no value returned", setting)
+	}
+	return s.Value, nil
+}
+
+// UpdateZoneSetting changes the value of a zone setting. Value may be any
+// JSON encodable value, including a json.RawMessage from GetZoneSetting.
+func (c *Client) UpdateZoneSetting(ctx context.Context, zoneID, setting string, value interface{}) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	body := map[string]interface{}{"value": value}
+	if err := c.doRaw(ctx, http.MethodPatch, fmt.Sprintf("zones/%s/settings/%s", zoneID, setting), body, nil); err != nil {
+		return fmt.Errorf("update zone setting %s: %w", setting, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetZoneSetting(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/zones/zone-1/settings/challenge_ttl", r.URL.Path)
+		writeResult(w, map[string]interface{}{"id": "challenge_ttl", "value": 1800, "editable": true})
+	}))
+
+	value, err := client.GetZoneSetting(context.Background(), "zone-1", "challenge_ttl")
+	require.NoError(t, err)
+	assert.JSONEq(t, `1800`, string(value))
+}
+
+func TestUpdateZoneSettingRawValue(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/zones/zone-1/settings/security_level", r.URL.Path)
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.JSONEq(t, `"medium"`, string(body["value"]))
+		writeResult(w, map[string]interface{}{"id": "security_level", "value": "medium"})
+	}))
+
+	err := client.UpdateZoneSetting(context.Background(), "zone-1", "security_level", json.RawMessage(`"medium"`))
+	require.NoError(t, err)
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/cloudflare/cloudflare-go/v6/option"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// keepBindings are the binding types an upload inherits from the deployed
+// script, so deploying new code never drops secrets, KV namespaces or other
+// configuration made in the dashboard
+var keepBindings = []string{
+	"plain_text", "secret_text", "json", "kv_namespace", "r2_bucket", "d1",
+	"durable_object_namespace", "queue", "service", "analytics_engine",
+	"wasm_module", "text_blob", "data_blob", "browser", "ai", "vectorize",
+	"hyperdrive", "mtls_certificate", "version_metadata", "secrets_store_secret",
+}
+
+// ListWorkerScripts retrieves the Workers scripts of an account
+func (c *Client) ListWorkerScripts(ctx context.Context, accountID string) ([]cloudflare.WorkerScript, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var scripts []cloudflare.WorkerScript
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("accounts/%s/workers/scripts", accountID), nil, &scripts); err != nil {
+		return nil, fmt.Errorf("list worker scripts: %w", err)
+	}
+	if scripts == nil {
+		return []cloudflare.WorkerScript{}, nil
+	}
+	return scripts, nil
+}
+
+// workerUploadBody encodes a bundle as the multipart form the upload
+// endpoint expects and returns it with its content type
+func workerUploadBody(bundle cloudflare.WorkerBundle) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"main_module":        bundle.MainModule,
+		"compatibility_date": bundle.CompatibilityDate,
+		"keep_bindings":      keepBindings,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="metadata"`)
+	header.Set("Content-Type", "application/json")
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(metadata); err != nil {
+		return nil, "", err
+	}
+
+	for _, module := range bundle.Modules {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, module.Name, module.Name))
+		header.Set("Content-Type", module.ContentType)
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(module.Content); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// UploadWorkerScript creates or replaces a Workers script with the modules
+// of a bundle. Bindings and secrets of an existing script are kept.
+func (c *Client) UploadWorkerScript(ctx context.Context, accountID, name string, bundle cloudflare.WorkerBundle) (*cloudflare.WorkerScript, error) {
+	body, contentType, err := workerUploadBody(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("upload worker script: %w", err)
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var script cloudflare.WorkerScript
+	path := fmt.Sprintf("accounts/%s/workers/scripts/%s", accountID, name)
+	if err := c.doRaw(ctx, http.MethodPut, path, nil, &script, option.WithRequestBody(contentType, body)); err != nil {
+		return nil, fmt.Errorf("upload worker script: %w", err)
+	}
+	if script.ID == "" {
+		script.ID = name
+	}
+	return &script, nil
+}
+
+// ListWorkerRoutes retrieves the Worker routes of a zone
+func (c *Client) ListWorkerRoutes(ctx context.Context, zoneID string) ([]cloudflare.WorkerRoute, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var routes []cloudflare.WorkerRoute
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("zones/%s/workers/routes", zoneID), nil, &routes); err != nil {
+		return nil, fmt.Errorf("list worker routes: %w", err)
+	}
+	if routes == nil {
+		return []cloudflare.WorkerRoute{}, nil
+	}
+	return routes, nil
+}
+
+// CreateWorkerRoute adds a Worker route to a zone and returns it
+func (c *Client) CreateWorkerRoute(ctx context.Context, zoneID string, route cloudflare.WorkerRoute) (*cloudflare.WorkerRoute, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	route.ID = ""
+	var created cloudflare.WorkerRoute
+	if err := c.doRaw(ctx, http.MethodPost, fmt.Sprintf("zones/%s/workers/routes", zoneID), route, &created); err != nil {
+		return nil, fmt.Errorf("create worker route: %w", err)
+	}
+
+	// The API only returns the new route's ID
+	route.ID = created.ID
+	return &route, nil
+}
+
+// UpdateWorkerRoute changes the pattern and script of the route identified
+// by route.ID
+func (c *Client) UpdateWorkerRoute(ctx context.Context, zoneID string, route cloudflare.WorkerRoute) error {
+	if route.ID == "" {
+		return fmt.Errorf("update worker route: route ID is required")
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	id := route.ID
+	route.ID = ""
+	if err := c.doRaw(ctx, http.MethodPut, fmt.Sprintf("zones/%s/workers/routes/%s", zoneID, id), route, nil); err != nil {
+		return fmt.Errorf("update worker route: %w", err)
+	}
+	return nil
+}
+
+// DeleteWorkerRoute removes a Worker route from a zone
+func (c *Client) DeleteWorkerRoute(ctx context.Context, zoneID, routeID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.doRaw(ctx, http.MethodDelete, fmt.Sprintf("zones/%s/workers/routes/%s", zoneID, routeID), nil, nil); err != nil {
+		return fmt.Errorf("delete worker route: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadWorkerScript(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/accounts/acct-1/workers/scripts/edge", r.URL.Path)
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediaType)
+
+		parts := map[string]string{}
+		types := map[string]string{}
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(part)
+			require.NoError(t, err)
+			parts[part.FormName()] = string(data)
+			types[part.FormName()] = part.Header.Get("Content-Type")
+		}
+
+		var metadata map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(parts["metadata"]), &metadata))
+		assert.Equal(t, "index.js", metadata["main_module"])
+		assert.Equal(t, "2024-09-01", metadata["compatibility_date"])
+		assert.Contains(t, metadata["keep_bindings"], "secret_text")
+		assert.Equal(t, "export default {}", parts["index.js"])
+		assert.Equal(t, "application/javascript+module", types["index.js"])
+		assert.Equal(t, "application/wasm", types["lib/add.wasm"])
+
+		writeResult(w, map[string]interface{}{"id": "edge", "etag": "abc", "handlers": []string{"fetch"}})
+	}))
+
+	script, err := client.UploadWorkerScript(context.Background(), "acct-1", "edge", cloudflare.WorkerBundle{
+		MainModule:        "index.js",
+		CompatibilityDate: "2024-09-01",
+		Modules: []cloudflare.WorkerModule{
+			{Name: "index.js", ContentType: "application/javascript+module", Content: []byte("export default {}")},
+			{Name: "lib/add.wasm", ContentType: "application/wasm", Content: []byte{0, 'a', 's', 'm'}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", script.ETag)
+	assert.Equal(t, []string{"fetch"}, script.Handlers)
+}
+
+func TestWorkerRoutes(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/zones/zone-1/workers/routes", r.URL.Path)
+			writeResult(w, []map[string]string{{"id": "rt-1", "pattern": "example.com/api/*", "script": "edge"}})
+		case http.MethodPost:
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"pattern": "example.com/*", "script": "edge"}, body)
+			writeResult(w, map[string]string{"id": "rt-2"})
+		case http.MethodPut:
+			assert.Equal(t, "/zones/zone-1/workers/routes/rt-1", r.URL.Path)
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"pattern": "example.com/v2/*"}, body)
+			writeResult(w, body)
+		}
+	}))
+
+	ctx := context.Background()
+	routes, err := client.ListWorkerRoutes(ctx, "zone-1")
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, "edge", routes[0].Script)
+
+	route, err := client.CreateWorkerRoute(ctx, "zone-1", cloudflare.WorkerRoute{Pattern: "example.com/*", Script: "edge"})
+	require.NoError(t, err)
+	assert.Equal(t, "rt-2", route.ID)
+	assert.Equal(t, "example.com/*", route.Pattern)
+
+	// No script disables Workers on the pattern
+	require.NoError(t, client.UpdateWorkerRoute(ctx, "zone-1", cloudflare.WorkerRoute{ID: "rt-1", Pattern: "example.com/v2/*"}))
+}
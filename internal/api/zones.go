@@ -2,73 +2,238 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 
 	cfv6 "github.com/cloudflare/cloudflare-go/v6"
 	cfv6zones "github.com/cloudflare/cloudflare-go/v6/zones"
 	"github.com/siyamsarker/cfctl/pkg/cloudflare"
 )
 
-// ListZones retrieves all zones for the account
-func (c *Client) ListZones(ctx context.Context) ([]cloudflare.Zone, error) {
-	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+// ErrZoneNotFound is returned when no zone matches a name or ID
+var ErrZoneNotFound = errors.New("zone not found")
+
+// zonesPerPage is the page size used when listing zones
+const zonesPerPage = 50
+
+// zonePageConcurrency bounds the zone pages fetched at the same time
+const zonePageConcurrency = 4
+
+// ZonePage is one page of a streamed zone listing
+type ZonePage struct {
+	Page       int // 1-based page number
+	TotalPages int // 0 when the API didn't report a page count
+	Zones      []cloudflare.Zone
+	Err        error
+}
+
+// ListZones retrieves the zones the credential can access, fetching pages
+// concurrently. A non-empty accountID limits the listing to the zones of
+// that Cloudflare account. Zones are returned in the order the API lists
+// them.
+func (c *Client) ListZones(ctx context.Context, accountID string) ([]cloudflare.Zone, error) {
+	// Stop the stream if a page fails
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// List zones using v6 API - use a single page first to verify connectivity
-	var allZones []cloudflare.Zone
+	var pages [][]cloudflare.Zone
+	for p := range c.StreamZones(ctx, accountID) {
+		if p.Err != nil {
+			return nil, p.Err
+		}
+		for len(pages) < p.Page {
+			pages = append(pages, nil)
+		}
+		pages[p.Page-1] = p.Zones
+	}
+	if err := ctx.Err(); err != nil {
+		// The stream ends without an error page when ctx is canceled
+		return nil, fmt.Errorf("list zones: %w", err)
+	}
 
-	// Use a channel to handle the API call with timeout
-	type result struct {
-		zones []cloudflare.Zone
-		err   error
+	var zones []cloudflare.Zone
+	for _, page := range pages {
+		zones = append(zones, page...)
 	}
-	resultChan := make(chan result, 1)
+	return zones, nil
+}
+
+// StreamZones lists zones page by page, limited to a Cloudflare account
+// when accountID is not empty, sending each page on
+// the returned channel as it arrives. The first page reports the total
+// page count; the rest are fetched concurrently, each with its own
+// timeout, so pages may arrive out of order. The first failed page is sent
+// with Err set and ends the stream. The channel is closed when the listing
+// ends; callers that stop reading early must cancel ctx.
+func (c *Client) StreamZones(ctx context.Context, accountID string) <-chan ZonePage {
+	out := make(chan ZonePage)
 
 	go func() {
-		var zones []cloudflare.Zone
-		pager := c.api.Zones.ListAutoPaging(ctx, cfv6zones.ZoneListParams{
-			PerPage: cfv6.F(float64(50)),
-		})
-
-		for pager.Next() {
-			z := pager.Current()
-			zones = append(zones, cloudflare.Zone{
-				ID:     z.ID,
-				Name:   z.Name,
-				Status: string(z.Status),
-				Plan: cloudflare.Plan{
-					Name: z.Plan.Name,
-				},
-			})
+		defer close(out)
+		send := func(p ZonePage) bool {
+			select {
+			case out <- p:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		zones, total, err := c.listZonesPage(ctx, accountID, 1)
+		if err != nil {
+			send(ZonePage{Page: 1, Err: err})
+			return
+		}
+		if !send(ZonePage{Page: 1, TotalPages: total, Zones: zones}) {
+			return
 		}
 
-		if err := pager.Err(); err != nil {
-			resultChan <- result{err: err}
+		if total == 0 {
+			// Without a page count, keep going until a short page
+			for page := 2; len(zones) == zonesPerPage; page++ {
+				zones, _, err = c.listZonesPage(ctx, accountID, page)
+				if !send(ZonePage{Page: page, Zones: zones, Err: err}) || err != nil {
+					return
+				}
+			}
 			return
 		}
-		resultChan <- result{zones: zones}
+
+		c.streamZonePages(ctx, accountID, total, send)
 	}()
 
-	// Wait for result or timeout
-	select {
-	case <-ctx.Done():
-		return nil, fmt.Errorf("timeout fetching zones: %w", ctx.Err())
-	case res := <-resultChan:
-		if res.err != nil {
-			errMsg := res.err.Error()
-			if contains(errMsg, "code\":9109") || contains(errMsg, "Cannot use the access token from location") {
-				return nil, fmt.Errorf("IP restriction error: Your API token has IP address restrictions configured in Cloudflare. Please remove the IP restrictions or add your current IP address to the allowed list")
+	return out
+}
+
+// streamZonePages fetches pages 2 to total with a bounded number of
+// workers, passing each to send until one fails or send gives up
+func (c *Client) streamZonePages(ctx context.Context, accountID string, total int, send func(ZonePage) bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan int)
+	go func() {
+		defer close(pages)
+		for page := 2; page <= total; page++ {
+			select {
+			case pages <- page:
+			case <-ctx.Done():
+				return
 			}
-			if contains(errMsg, "403") || contains(errMsg, "Forbidden") || contains(errMsg, "permission") {
-				return nil, fmt.Errorf("insufficient permissions: this token must include Zone.Zone.Read to list domains")
+		}
+	}()
+
+	results := make(chan ZonePage)
+	var wg sync.WaitGroup
+	for range min(zonePageConcurrency, total-1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				zones, _, err := c.listZonesPage(ctx, accountID, page)
+				select {
+				case results <- ZonePage{Page: page, TotalPages: total, Zones: zones, Err: err}:
+				case <-ctx.Done():
+					return
+				}
 			}
-			return nil, fmt.Errorf("list zones: %w", res.err)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Drain results after a failure so every worker exits
+	stopped := false
+	for p := range results {
+		if stopped {
+			continue
+		}
+		if !send(p) || p.Err != nil {
+			stopped = true
+			cancel()
 		}
-		allZones = res.zones
 	}
+}
 
-	return allZones, nil
+// listZonesPage fetches one page of zones with its own timeout and returns
+// the total page count reported by the API (0 if missing)
+func (c *Client) listZonesPage(ctx context.Context, accountID string, page int) ([]cloudflare.Zone, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	params := cfv6zones.ZoneListParams{
+		Page:    cfv6.F(float64(page)),
+		PerPage: cfv6.F(float64(zonesPerPage)),
+	}
+	if accountID != "" {
+		params.Account = cfv6.F(cfv6zones.ZoneListParamsAccount{ID: cfv6.F(accountID)})
+	}
+
+	res, err := c.api.Zones.List(ctx, params)
+	if err != nil {
+		return nil, 0, listZonesError(err)
+	}
+
+	zones := make([]cloudflare.Zone, 0, len(res.Result))
+	for _, z := range res.Result {
+		zones = append(zones, toZone(z))
+	}
+
+	// The SDK doesn't expose total_pages, so read it from the raw result_info
+	var info struct {
+		TotalPages int `json:"total_pages"`
+	}
+	_ = json.Unmarshal([]byte(res.ResultInfo.JSON.RawJSON()), &info)
+
+	return zones, info.TotalPages, nil
+}
+
+// listZonesError turns a zone listing failure into an actionable error
+func listZonesError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout fetching a page of zones, check network connectivity: %w", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("list zones: %w", err)
+	}
+
+	errMsg := err.Error()
+	if contains(errMsg, "code\":9109") || contains(errMsg, "Cannot use the access token from location") {
+		return fmt.Errorf("IP restriction error: Your API token has IP address restrictions configured in Cloudflare. Please remove the IP restrictions or add your current IP address to the allowed list")
+	}
+	if contains(errMsg, "403") || contains(errMsg, "Forbidden") || contains(errMsg, "permission") {
+		return fmt.Errorf("insufficient permissions: this token must include Zone.Zone.Read to list domains")
+	}
+	return fmt.Errorf("list zones: %w", err)
+}
+
+// toZone converts an SDK zone to the cfctl zone type
+func toZone(z cfv6zones.Zone) cloudflare.Zone {
+	return cloudflare.Zone{
+		ID:     z.ID,
+		Name:   z.Name,
+		Status: string(z.Status),
+		Plan: cloudflare.Plan{
+			Name: z.Plan.Name,
+		},
+		Account: cloudflare.ZoneAccount{
+			ID:   z.Account.ID,
+			Name: z.Account.Name,
+		},
+		Type:              string(z.Type),
+		Paused:            z.Paused,
+		NameServers:       z.NameServers,
+		OriginalRegistrar: z.OriginalRegistrar,
+		DevelopmentMode:   int(z.DevelopmentMode),
+		CreatedOn:         z.CreatedOn,
+		ModifiedOn:        z.ModifiedOn,
+		ActivatedOn:       z.ActivatedOn,
+	}
 }
 
 // GetZone retrieves a specific zone by ID
@@ -84,12 +249,124 @@ func (c *Client) GetZone(ctx context.Context, zoneID string) (*cloudflare.Zone,
 		return nil, fmt.Errorf("get zone: %w", err)
 	}
 
-	return &cloudflare.Zone{
-		ID:     z.ID,
-		Name:   z.Name,
-		Status: string(z.Status),
-		Plan: cloudflare.Plan{
-			Name: z.Plan.Name,
-		},
-	}, nil
+	zone := toZone(*z)
+	return &zone, nil
+}
+
+// FindZone resolves a zone from either its ID or its domain name
+func (c *Client) FindZone(ctx context.Context, nameOrID string) (*cloudflare.Zone, error) {
+	if isZoneID(nameOrID) {
+		return c.GetZone(ctx, nameOrID)
+	}
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	page, err := c.api.Zones.List(ctx, cfv6zones.ZoneListParams{
+		Name: cfv6.F(nameOrID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find zone: %w", err)
+	}
+
+	for _, z := range page.Result {
+		if z.Name == nameOrID {
+			zone := toZone(z)
+			return &zone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrZoneNotFound, nameOrID)
+}
+
+// CreateZone adds a full-setup zone to a Cloudflare account. With jumpStart
+// set, Cloudflare scans the domain's existing DNS records and imports them.
+func (c *Client) CreateZone(ctx context.Context, accountID, name string, jumpStart bool) (*cloudflare.Zone, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// jump_start isn't part of the SDK's create params, so post the body directly
+	body := map[string]interface{}{
+		"account":    map[string]string{"id": accountID},
+		"name":       name,
+		"type":       "full",
+		"jump_start": jumpStart,
+	}
+
+	var z cfv6zones.Zone
+	if err := c.doRaw(ctx, http.MethodPost, "zones", body, &z); err != nil {
+		return nil, fmt.Errorf("create zone: %w", err)
+	}
+
+	zone := toZone(z)
+	return &zone, nil
+}
+
+// SetZonePaused pauses Cloudflare on a zone, serving DNS only and sending
+// traffic straight to the origin, or resumes it
+func (c *Client) SetZonePaused(ctx context.Context, zoneID string, paused bool) (*cloudflare.Zone, error) {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	z, err := c.api.Zones.Edit(ctx, cfv6zones.ZoneEditParams{
+		ZoneID: cfv6.F(zoneID),
+		Paused: cfv6.F(paused),
+	})
+	if err != nil {
+		if paused {
+			return nil, fmt.Errorf("pause zone: %w", err)
+		}
+		return nil, fmt.Errorf("resume zone: %w", err)
+	}
+
+	zone := toZone(*z)
+	return &zone, nil
+}
+
+// CheckActivation asks Cloudflare to check a pending zone's name servers
+// now instead of waiting for the next scheduled check. Cloudflare limits how
+// often a zone can be checked.
+func (c *Client) CheckActivation(ctx context.Context, zoneID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.api.Zones.ActivationCheck.Trigger(ctx, cfv6zones.ActivationCheckTriggerParams{
+		ZoneID: cfv6.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("check activation: %w", err)
+	}
+	return nil
+}
+
+// DeleteZone removes a zone and all of its configuration from Cloudflare
+func (c *Client) DeleteZone(ctx context.Context, zoneID string) error {
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.api.Zones.Delete(ctx, cfv6zones.ZoneDeleteParams{
+		ZoneID: cfv6.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete zone: %w", err)
+	}
+	return nil
+}
+
+// isZoneID reports whether s looks like a Cloudflare zone identifier
+func isZoneID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	for _, c := range s {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')) {
+			return false
+		}
+	}
+	return true
 }
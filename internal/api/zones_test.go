@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListZones(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		// 51 zones: a full first page and one zone on the second
+		count := zonesPerPage
+		if page == "2" {
+			count = 1
+		}
+		result := make([]map[string]interface{}, count)
+		for i := range result {
+			result[i] = map[string]interface{}{
+				"id":     fmt.Sprintf("zone-%s-%d", page, i),
+				"name":   fmt.Sprintf("example-%s-%d.com", page, i),
+				"status": "active",
+				"plan":   map[string]string{"name": "Free Website"},
+			}
+		}
+		writeResult(w, result)
+	}))
+
+	zones, err := client.ListZones(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, zones, 51)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, "example-2-0.com", zones[50].Name)
+	assert.Equal(t, "active", zones[50].Status)
+	assert.Equal(t, "Free Website", zones[50].Plan.Name)
+}
+
+func TestListZonesByAccount(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "acct-1", r.URL.Query().Get("account.id"))
+		writeResult(w, []map[string]interface{}{
+			{"id": "zone-1", "name": "example.com", "account": map[string]string{"id": "acct-1", "name": "Acme"}},
+		})
+	}))
+
+	zones, err := client.ListZones(context.Background(), "acct-1")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "acct-1", zones[0].Account.ID)
+	assert.Equal(t, "Acme", zones[0].Account.Name)
+}
+
+// writeZonesPage writes a page of count zones with a total_pages hint
+func writeZonesPage(w http.ResponseWriter, page string, count, totalPages int) {
+	result := make([]map[string]interface{}, count)
+	for i := range result {
+		result[i] = map[string]interface{}{
+			"id":   fmt.Sprintf("zone-%s-%d", page, i),
+			"name": fmt.Sprintf("example-%s-%d.com", page, i),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"errors":      []interface{}{},
+		"messages":    []interface{}{},
+		"result":      result,
+		"result_info": map[string]interface{}{"page": page, "per_page": zonesPerPage, "total_pages": totalPages},
+	})
+}
+
+func TestListZonesConcurrentPages(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		page := r.URL.Query().Get("page")
+		count := zonesPerPage
+		if page == "10" {
+			count = 3
+		} else if page != "1" {
+			time.Sleep(10 * time.Millisecond)
+		}
+		writeZonesPage(w, page, count, 10)
+	}))
+
+	zones, err := client.ListZones(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, zones, 9*zonesPerPage+3)
+	assert.Equal(t, "example-1-0.com", zones[0].Name)
+	assert.Equal(t, "example-2-0.com", zones[zonesPerPage].Name, "pages are returned in order")
+	assert.Equal(t, "example-10-2.com", zones[len(zones)-1].Name)
+	assert.LessOrEqual(t, maxInFlight, zonePageConcurrency)
+}
+
+func TestStreamZonesStopsOnError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "3" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":1000,"message":"bad page"}],"messages":[],"result":null}`))
+			return
+		}
+		writeZonesPage(w, page, zonesPerPage, 20)
+	}))
+
+	var pages []ZonePage
+	for p := range client.StreamZones(context.Background(), "") {
+		pages = append(pages, p)
+	}
+
+	require.NotEmpty(t, pages)
+	assert.Equal(t, 1, pages[0].Page)
+	assert.Equal(t, 20, pages[0].TotalPages)
+	assert.Error(t, pages[len(pages)-1].Err)
+	assert.Less(t, len(pages), 20)
+
+	_, err := client.ListZones(context.Background(), "")
+	assert.Error(t, err)
+}
+
+func TestListZonesCanceled(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.ListZones(ctx, "")
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestGetZoneMetadata(t *testing.T) {
+	zoneID := "023e105f4ecef8ad9ca31a8372d0c353"
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/zones/"+zoneID, r.URL.Path)
+		writeResult(w, map[string]interface{}{
+			"id":                 zoneID,
+			"name":               "example.com",
+			"status":             "active",
+			"paused":             true,
+			"type":               "partial",
+			"development_mode":   7200,
+			"name_servers":       []string{"bob.ns.cloudflare.com", "lola.ns.cloudflare.com"},
+			"original_registrar": "GoDaddy",
+			"created_on":         "2024-01-02T03:04:05Z",
+			"modified_on":        "2024-02-03T04:05:06Z",
+			"activated_on":       nil,
+			"account":            map[string]string{"id": "acc-1", "name": "Example Inc"},
+			"plan":               map[string]string{"name": "Business Website"},
+		})
+	}))
+
+	zone, err := client.FindZone(context.Background(), zoneID)
+	require.NoError(t, err)
+	assert.Equal(t, "acc-1", zone.Account.ID)
+	assert.Equal(t, "Example Inc", zone.Account.Name)
+	assert.Equal(t, "partial", zone.Type)
+	assert.True(t, zone.Paused)
+	assert.True(t, zone.DevelopmentModeOn())
+	assert.Equal(t, []string{"bob.ns.cloudflare.com", "lola.ns.cloudflare.com"}, zone.NameServers)
+	assert.Equal(t, "GoDaddy", zone.OriginalRegistrar)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), zone.CreatedOn.UTC())
+	assert.True(t, zone.ActivatedOn.IsZero())
+}
+
+func TestCreateZone(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/zones", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "example.com", body["name"])
+		assert.Equal(t, "full", body["type"])
+		assert.Equal(t, true, body["jump_start"])
+		assert.Equal(t, map[string]interface{}{"id": "acct-1"}, body["account"])
+
+		writeResult(w, map[string]interface{}{
+			"id":           "zone-1",
+			"name":         "example.com",
+			"status":       "pending",
+			"name_servers": []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"},
+		})
+	}))
+
+	zone, err := client.CreateZone(context.Background(), "acct-1", "example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, "zone-1", zone.ID)
+	assert.Equal(t, "pending", zone.Status)
+	assert.Equal(t, []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}, zone.NameServers)
+}
+
+func TestSetZonePaused(t *testing.T) {
+	for _, paused := range []bool{true, false} {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/zones/zone-1", r.URL.Path)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, paused, body["paused"])
+
+			writeResult(w, map[string]interface{}{"id": "zone-1", "name": "example.com", "paused": paused})
+		}))
+
+		zone, err := client.SetZonePaused(context.Background(), "zone-1", paused)
+		require.NoError(t, err)
+		assert.Equal(t, paused, zone.Paused)
+	}
+}
+
+func TestCheckActivation(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/zones/zone-1/activation_check", r.URL.Path)
+		writeResult(w, map[string]string{"id": "zone-1"})
+	}))
+
+	require.NoError(t, client.CheckActivation(context.Background(), "zone-1"))
+}
+
+func TestDeleteZone(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/zones/zone-1", r.URL.Path)
+		writeResult(w, map[string]string{"id": "zone-1"})
+	}))
+
+	require.NoError(t, client.DeleteZone(context.Background(), "zone-1"))
+}
+
+func TestDeleteZoneError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+	}))
+
+	err := client.DeleteZone(context.Background(), "zone-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "delete zone")
+}
@@ -0,0 +1,65 @@
+// Package cacherules reads and edits the TTL settings in the action
+// parameters of Cache Rules.
+package cacherules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TTL modes of edge_ttl and browser_ttl
+const (
+	ModeOverrideOrigin = "override_origin"
+	ModeRespectOrigin  = "respect_origin"
+)
+
+// TTLSeconds returns the TTL a rule sets under key ("edge_ttl" or
+// "browser_ttl") when it overrides the origin's
+func TTLSeconds(params map[string]interface{}, key string) (int, bool) {
+	ttl, ok := params[key].(map[string]interface{})
+	if !ok || ttl["mode"] != ModeOverrideOrigin {
+		return 0, false
+	}
+
+	switch v := ttl["default"].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
+
+// SetTTL applies the value of a TTL field to params. A number of seconds
+// overrides the origin's TTL. A blank value clears an override, going back
+// to respecting the origin, and leaves any other mode, such as
+// bypass_by_default, as it is. Settings besides mode and default, such as
+// status_code_ttl, are always kept.
+func SetTTL(params map[string]interface{}, key, value string) error {
+	current, _ := params[key].(map[string]interface{})
+	ttl := make(map[string]interface{}, len(current)+2)
+	for k, v := range current {
+		ttl[k] = v
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if ttl["mode"] != ModeOverrideOrigin {
+			return nil
+		}
+		ttl["mode"] = ModeRespectOrigin
+		delete(ttl, "default")
+		params[key] = ttl
+		return nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return fmt.Errorf("%s must be a non-negative number of seconds", strings.ReplaceAll(key, "_", " "))
+	}
+	ttl["mode"] = ModeOverrideOrigin
+	ttl["default"] = seconds
+	params[key] = ttl
+	return nil
+}
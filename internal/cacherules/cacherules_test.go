@@ -0,0 +1,77 @@
+package cacherules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decode parses action parameters the way they arrive from the API
+func decode(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var params map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &params))
+	return params
+}
+
+func TestTTLSeconds(t *testing.T) {
+	params := decode(t, `{"edge_ttl":{"mode":"override_origin","default":3600},"browser_ttl":{"mode":"respect_origin"}}`)
+
+	ttl, ok := TTLSeconds(params, "edge_ttl")
+	assert.True(t, ok)
+	assert.Equal(t, 3600, ttl)
+
+	_, ok = TTLSeconds(params, "browser_ttl")
+	assert.False(t, ok)
+	_, ok = TTLSeconds(params, "missing")
+	assert.False(t, ok)
+}
+
+func TestSetTTLKeepsNonOverrideModes(t *testing.T) {
+	params := decode(t, `{
+		"cache": true,
+		"edge_ttl": {"mode": "respect_origin", "status_code_ttl": [{"status_code": 404, "value": 60}]},
+		"browser_ttl": {"mode": "bypass_by_default"}
+	}`)
+
+	// The editor shows no value for these modes, so saving sends blanks
+	require.NoError(t, SetTTL(params, "edge_ttl", ""))
+	require.NoError(t, SetTTL(params, "browser_ttl", " "))
+
+	edited, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"cache": true,
+		"edge_ttl": {"mode": "respect_origin", "status_code_ttl": [{"status_code": 404, "value": 60}]},
+		"browser_ttl": {"mode": "bypass_by_default"}
+	}`, string(edited))
+}
+
+func TestSetTTLOverride(t *testing.T) {
+	params := decode(t, `{"edge_ttl": {"mode": "respect_origin", "status_code_ttl": [{"status_code": 404, "value": 60}]}}`)
+
+	require.NoError(t, SetTTL(params, "edge_ttl", "600"))
+	require.NoError(t, SetTTL(params, "browser_ttl", "30"))
+
+	edited, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"edge_ttl": {"mode": "override_origin", "default": 600, "status_code_ttl": [{"status_code": 404, "value": 60}]},
+		"browser_ttl": {"mode": "override_origin", "default": 30}
+	}`, string(edited))
+
+	assert.Error(t, SetTTL(params, "edge_ttl", "-1"))
+	assert.Error(t, SetTTL(params, "edge_ttl", "1h"))
+}
+
+func TestSetTTLClearsOverride(t *testing.T) {
+	params := decode(t, `{"edge_ttl": {"mode": "override_origin", "default": 600, "status_code_ttl": [{"status_code": 404, "value": 60}]}}`)
+
+	require.NoError(t, SetTTL(params, "edge_ttl", ""))
+
+	edited, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"edge_ttl": {"mode": "respect_origin", "status_code_ttl": [{"status_code": 404, "value": 60}]}}`, string(edited))
+}
@@ -0,0 +1,88 @@
+// Package certs holds the logic behind the certificate commands: expiry
+// windows, SSL mode descriptions and the local key and CSR generation for
+// Origin CA certificates.
+package certs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// sslModes maps the ssl setting values to the names used in the dashboard
+var sslModes = map[string]string{
+	"off":      "Off",
+	"flexible": "Flexible",
+	"full":     "Full",
+	"strict":   "Full (strict)",
+}
+
+// SSLModeLabel returns the dashboard name of an SSL mode
+func SSLModeLabel(mode string) string {
+	if label, ok := sslModes[mode]; ok {
+		return label
+	}
+	return mode
+}
+
+// ParseWithin parses an expiry window such as "30d", "2w" or "36h"
+func ParseWithin(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("expiry window is required")
+	}
+
+	unit := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[value[len(value)-1]]
+	if unit == 0 {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("invalid expiry window %q: use days (30d), weeks (2w) or hours (36h)", value)
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid expiry window %q: use days (30d), weeks (2w) or hours (36h)", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// DaysLeft returns the whole days from now until expires, negative once it
+// has passed
+func DaysLeft(expires, now time.Time) int {
+	return int(expires.Sub(now).Hours() / 24)
+}
+
+// ExpiringWithin returns the packs with an issued certificate expiring
+// before now+within, soonest first. Packs without an issued certificate
+// are left out.
+func ExpiringWithin(packs []cloudflare.CertificatePack, now time.Time, within time.Duration) []cloudflare.CertificatePack {
+	deadline := now.Add(within)
+
+	var expiring []cloudflare.CertificatePack
+	for _, pack := range packs {
+		expires := pack.Expires()
+		if !expires.IsZero() && expires.Before(deadline) {
+			expiring = append(expiring, pack)
+		}
+	}
+	SortByExpiry(expiring)
+	return expiring
+}
+
+// SortByExpiry orders packs by their first expiry, packs without an issued
+// certificate last
+func SortByExpiry(packs []cloudflare.CertificatePack) {
+	sort.SliceStable(packs, func(i, j int) bool {
+		a, b := packs[i].Expires(), packs[j].Expires()
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+}
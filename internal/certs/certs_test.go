@@ -0,0 +1,65 @@
+package certs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWithin(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{" 7d ", 7 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseWithin(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "d", "-3d", "0d", "soon", "-1h"} {
+		_, err := ParseWithin(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func pack(id string, expires ...time.Time) cloudflare.CertificatePack {
+	p := cloudflare.CertificatePack{ID: id}
+	for _, e := range expires {
+		p.Certificates = append(p.Certificates, cloudflare.EdgeCertificate{ExpiresOn: e})
+	}
+	return p
+}
+
+func TestExpiringWithin(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	packs := []cloudflare.CertificatePack{
+		pack("later", now.Add(90*24*time.Hour)),
+		pack("pending"),
+		pack("soon", now.Add(40*24*time.Hour), now.Add(10*24*time.Hour)),
+		pack("expired", now.Add(-24*time.Hour)),
+	}
+
+	expiring := ExpiringWithin(packs, now, 30*24*time.Hour)
+	require.Len(t, expiring, 2)
+	assert.Equal(t, "expired", expiring[0].ID)
+	assert.Equal(t, "soon", expiring[1].ID)
+	assert.Equal(t, 10, DaysLeft(expiring[1].Expires(), now))
+	assert.Equal(t, -1, DaysLeft(expiring[0].Expires(), now))
+
+	SortByExpiry(packs)
+	assert.Equal(t, "pending", packs[3].ID)
+}
+
+func TestSSLModeLabel(t *testing.T) {
+	assert.Equal(t, "Full (strict)", SSLModeLabel("strict"))
+	assert.Equal(t, "unknown", SSLModeLabel("unknown"))
+}
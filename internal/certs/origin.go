@@ -0,0 +1,158 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Origin CA key types and the request type each maps to
+const (
+	KeyRSA   = "rsa"
+	KeyECDSA = "ecdsa"
+)
+
+// rsaKeyBits is the size of generated RSA keys
+const rsaKeyBits = 2048
+
+// OriginValidities are the certificate lifetimes in days the Origin CA
+// accepts
+var OriginValidities = []int{7, 30, 90, 365, 730, 1095, 5475}
+
+// RequestType returns the Origin CA request type for a key type
+func RequestType(keyType string) (string, error) {
+	switch keyType {
+	case KeyRSA:
+		return "origin-rsa", nil
+	case KeyECDSA:
+		return "origin-ecc", nil
+	}
+	return "", fmt.Errorf("invalid key type %q: use %s or %s", keyType, KeyRSA, KeyECDSA)
+}
+
+// ParseValidity parses an Origin CA certificate lifetime given in days
+// ("90d" or "90") or years ("1y", "15y") and checks the CA accepts it
+func ParseValidity(value string) (int, error) {
+	number := strings.ToLower(strings.TrimSpace(value))
+
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(number, "y"):
+		multiplier = 365
+		number = strings.TrimSuffix(number, "y")
+	case strings.HasSuffix(number, "d"):
+		number = strings.TrimSuffix(number, "d")
+	}
+
+	n, err := strconv.Atoi(number)
+	if err == nil {
+		days := n * multiplier
+		for _, valid := range OriginValidities {
+			if days == valid {
+				return days, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid validity %q: use 7d, 30d, 90d, 1y, 2y, 3y or 15y", value)
+}
+
+// GenerateKey creates a private key for an Origin CA certificate: RSA 2048
+// or ECDSA P-256
+func GenerateKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case KeyRSA:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case KeyECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	return nil, fmt.Errorf("invalid key type %q: use %s or %s", keyType, KeyRSA, KeyECDSA)
+}
+
+// CreateCSR builds a PEM encoded certificate signing request for the
+// hostnames, using the first as the common name
+func CreateCSR(key crypto.Signer, hostnames []string) ([]byte, error) {
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("at least one hostname is required")
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hostnames[0]},
+		DNSNames: hostnames,
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("create CSR: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// EncodeKey encodes a private key as a PEM PKCS #8 block
+func EncodeKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// DefaultPaths returns the certificate and key file names used when none
+// are given, based on the first hostname
+func DefaultPaths(hostnames []string) (string, string) {
+	base := "origin"
+	if len(hostnames) > 0 {
+		base = strings.Replace(hostnames[0], "*.", "wildcard.", 1)
+	}
+	return base + ".pem", base + ".key"
+}
+
+// CheckWritable fails when a file exists at path, unless overwrite is set,
+// so an existing key is never silently replaced
+func CheckWritable(path string, overwrite bool) error {
+	if overwrite {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists; pass --force to overwrite it", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// WriteFile writes data atomically with the given permissions. The
+// temporary file is created with those permissions, so a private key is
+// never readable by others, even briefly.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
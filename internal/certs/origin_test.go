@@ -0,0 +1,84 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidity(t *testing.T) {
+	tests := map[string]int{"15y": 5475, "1y": 365, "90d": 90, "30": 30, " 3Y ": 1095}
+	for value, want := range tests {
+		got, err := ParseValidity(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"", "5y", "60d", "forever", "-7d"} {
+		_, err := ParseValidity(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRequestType(t *testing.T) {
+	rt, err := RequestType(KeyECDSA)
+	require.NoError(t, err)
+	assert.Equal(t, "origin-ecc", rt)
+
+	_, err = RequestType("dsa")
+	assert.Error(t, err)
+}
+
+func TestCreateCSR(t *testing.T) {
+	key, err := GenerateKey(KeyECDSA)
+	require.NoError(t, err)
+
+	csrPEM, err := CreateCSR(key, []string{"example.com", "*.example.com"})
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(csrPEM)
+	require.NotNil(t, block)
+	assert.Equal(t, "CERTIFICATE REQUEST", block.Type)
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, csr.CheckSignature())
+	assert.Equal(t, "example.com", csr.Subject.CommonName)
+	assert.Equal(t, []string{"example.com", "*.example.com"}, csr.DNSNames)
+
+	keyPEM, err := EncodeKey(key)
+	require.NoError(t, err)
+	block, _ = pem.Decode(keyPEM)
+	require.NotNil(t, block)
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	assert.IsType(t, &ecdsa.PrivateKey{}, parsed)
+
+	_, err = CreateCSR(key, nil)
+	assert.Error(t, err)
+}
+
+func TestDefaultPaths(t *testing.T) {
+	cert, key := DefaultPaths([]string{"*.example.com", "example.com"})
+	assert.Equal(t, "wildcard.example.com.pem", cert)
+	assert.Equal(t, "wildcard.example.com.key", key)
+}
+
+func TestWriteFileAndCheckWritable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "origin.key")
+	require.NoError(t, CheckWritable(path, false))
+
+	require.NoError(t, WriteFile(path, []byte("secret"), 0600))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.Error(t, CheckWritable(path, false))
+	assert.NoError(t, CheckWritable(path, true))
+}
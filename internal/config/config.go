@@ -17,6 +17,10 @@ type Config struct {
 	API      APISettings          `yaml:"api" mapstructure:"api"`
 	UI       UISettings           `yaml:"ui" mapstructure:"ui"`
 	Cache    CacheSettings        `yaml:"cache" mapstructure:"cache"`
+	Warmup   WarmupSettings       `yaml:"warmup" mapstructure:"warmup"`
+	Paths    PathSettings         `yaml:"paths" mapstructure:"paths"`
+	Presets  []Preset             `yaml:"presets" mapstructure:"presets"`
+	Incident IncidentSettings     `yaml:"incident" mapstructure:"incident"`
 	Accounts []cloudflare.Account `yaml:"accounts" mapstructure:"accounts"`
 }
 
@@ -46,6 +50,37 @@ type CacheSettings struct {
 	Enabled    bool `yaml:"enabled" mapstructure:"enabled"`
 }
 
+// WarmupSettings holds post-purge cache warming configuration
+type WarmupSettings struct {
+	Concurrency int               `yaml:"concurrency" mapstructure:"concurrency"`
+	Timeout     int               `yaml:"timeout" mapstructure:"timeout"`
+	Headers     map[string]string `yaml:"headers" mapstructure:"headers"`
+}
+
+// PathSettings maps local file paths to public URLs for git and file
+// watcher purges
+type PathSettings struct {
+	BaseURL  string        `yaml:"base_url" mapstructure:"base_url"`
+	Include  []string      `yaml:"include" mapstructure:"include"`
+	Rewrites []RewriteRule `yaml:"rewrites" mapstructure:"rewrites"`
+}
+
+// RewriteRule rewrites a file path with a regular expression before it is
+// turned into a URL
+type RewriteRule struct {
+	Match   string `yaml:"match" mapstructure:"match"`
+	Replace string `yaml:"replace" mapstructure:"replace"`
+}
+
+// IncidentSettings is the hardened security profile applied by incident
+// mode. Settings maps zone setting IDs such as "security_level" to the value
+// used during an incident; EnableRules names disabled WAF custom rules, by
+// description or ID, that are switched on for its duration.
+type IncidentSettings struct {
+	Settings    map[string]interface{} `yaml:"settings" mapstructure:"settings"`
+	EnableRules []string               `yaml:"enable_rules" mapstructure:"enable_rules"`
+}
+
 // Load loads configuration from file
 func Load() (*Config, error) {
 	configPath, err := getConfigPath()
@@ -90,6 +125,10 @@ func (c *Config) Save() error {
 	viper.Set("api", c.API)
 	viper.Set("ui", c.UI)
 	viper.Set("cache", c.Cache)
+	viper.Set("warmup", c.Warmup)
+	viper.Set("paths", c.Paths)
+	viper.Set("presets", c.Presets)
+	viper.Set("incident", c.Incident)
 	viper.Set("accounts", c.Accounts)
 
 	if err := viper.WriteConfigAs(configPath); err != nil {
@@ -104,7 +143,10 @@ func (c *Config) AddAccount(account cloudflare.Account) error {
 	// Check if account with same name exists
 	for i, acc := range c.Accounts {
 		if acc.Name == account.Name {
-			// Update existing account
+			// Update existing account, keeping its pinned Cloudflare account
+			if account.AccountID == "" {
+				account.AccountID = acc.AccountID
+			}
 			account.UpdatedAt = time.Now()
 			c.Accounts[i] = account
 			return c.Save()
@@ -188,6 +230,29 @@ func (c *Config) SetDefaultAccount(name string) error {
 	return c.Save()
 }
 
+// SetAccountID pins the Cloudflare account a stored account lists zones
+// from by default. An empty accountID lists zones of every account.
+func (c *Config) SetAccountID(name, accountID string) error {
+	for i := range c.Accounts {
+		if c.Accounts[i].Name == name {
+			c.Accounts[i].AccountID = accountID
+			c.Accounts[i].UpdatedAt = time.Now()
+			return c.Save()
+		}
+	}
+	return fmt.Errorf("account not found: %s", name)
+}
+
+// Dir returns the directory holding the configuration file. Other local
+// state such as the schedule queue and purge history lives beside it.
+func Dir() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(configPath), nil
+}
+
 func getConfigPath() (string, error) {
 	// Check environment variable
 	if path := os.Getenv("CFCTL_CONFIG"); path != "" {
@@ -214,6 +279,12 @@ func setDefaults() {
 	viper.SetDefault("ui.colors", true)
 	viper.SetDefault("cache.domains_ttl", 300)
 	viper.SetDefault("cache.enabled", true)
+	viper.SetDefault("warmup.concurrency", 4)
+	viper.SetDefault("warmup.timeout", 15)
+	viper.SetDefault("paths.rewrites", []map[string]string{
+		{"match": "^public/", "replace": ""},
+		{"match": "(^|/)index\\.html$", "replace": "$1"},
+	})
 }
 
 func createDefaultConfig(path string) (*Config, error) {
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAccountID(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("CFCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NoError(t, cfg.AddAccount(cloudflare.Account{Name: "work", AuthType: "token"}))
+	require.NoError(t, cfg.SetAccountID("work", "acc-123"))
+	assert.Error(t, cfg.SetAccountID("missing", "acc-123"))
+
+	// Updating the credential keeps the pinned account
+	require.NoError(t, cfg.AddAccount(cloudflare.Account{Name: "work", AuthType: "token"}))
+
+	viper.Reset()
+	cfg, err = Load()
+	require.NoError(t, err)
+	account, err := cfg.GetDefaultAccount()
+	require.NoError(t, err)
+	assert.Equal(t, "acc-123", account.AccountID)
+
+	require.NoError(t, cfg.SetAccountID("work", ""))
+	account, err = cfg.GetAccount("work")
+	require.NoError(t, err)
+	assert.Empty(t, account.AccountID)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Preset purge types
+const (
+	PresetURL        = "url"
+	PresetHostname   = "hostname"
+	PresetTag        = "tag"
+	PresetPrefix     = "prefix"
+	PresetEverything = "everything"
+)
+
+// Preset is a named purge that can be run again from the purge menu or
+// with "cfctl purge preset <name>"
+type Preset struct {
+	Name    string   `yaml:"name" mapstructure:"name"`
+	Zone    string   `yaml:"zone" mapstructure:"zone"`
+	Type    string   `yaml:"type" mapstructure:"type"`
+	Targets []string `yaml:"targets,omitempty" mapstructure:"targets"`
+}
+
+// Describe returns a short description such as "3 tags"
+func (p Preset) Describe() string {
+	if p.Type == PresetEverything {
+		return "everything"
+	}
+
+	noun := map[string][2]string{
+		PresetURL:      {"URL", "URLs"},
+		PresetHostname: {"hostname", "hostnames"},
+		PresetTag:      {"tag", "tags"},
+		PresetPrefix:   {"prefix", "prefixes"},
+	}[p.Type]
+	if len(p.Targets) == 1 {
+		return "1 " + noun[0]
+	}
+	return fmt.Sprintf("%d %s", len(p.Targets), noun[1])
+}
+
+// ValidatePreset checks a preset's name, zone and type. Targets are checked
+// by the purge validators when the preset runs.
+func ValidatePreset(p Preset) error {
+	if err := ValidatePresetName(p.Name); err != nil {
+		return err
+	}
+	if p.Zone == "" {
+		return fmt.Errorf("preset zone is required")
+	}
+
+	switch p.Type {
+	case PresetEverything:
+		if len(p.Targets) > 0 {
+			return fmt.Errorf("everything presets cannot have targets")
+		}
+	case PresetURL, PresetHostname, PresetTag, PresetPrefix:
+		if len(p.Targets) == 0 {
+			return fmt.Errorf("preset has no targets")
+		}
+	default:
+		return fmt.Errorf("invalid preset type: %s", p.Type)
+	}
+	return nil
+}
+
+// ValidatePresetName validates a preset name. Names are used on the command
+// line, so spaces are not allowed.
+func ValidatePresetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("preset name is required")
+	}
+
+	if len(name) > 50 {
+		return fmt.Errorf("preset name must be less than 50 characters")
+	}
+
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.') {
+			return fmt.Errorf("preset name can only contain letters, numbers, dashes, underscores, and dots")
+		}
+	}
+
+	return nil
+}
+
+// SavePreset adds a preset, replacing any existing preset with the same name
+func (c *Config) SavePreset(preset Preset) error {
+	if err := ValidatePreset(preset); err != nil {
+		return err
+	}
+
+	for i, p := range c.Presets {
+		if strings.EqualFold(p.Name, preset.Name) {
+			c.Presets[i] = preset
+			return c.Save()
+		}
+	}
+
+	c.Presets = append(c.Presets, preset)
+	return c.Save()
+}
+
+// RemovePreset removes a preset from the configuration
+func (c *Config) RemovePreset(name string) error {
+	for i, p := range c.Presets {
+		if strings.EqualFold(p.Name, name) {
+			c.Presets = append(c.Presets[:i], c.Presets[i+1:]...)
+			return c.Save()
+		}
+	}
+	return fmt.Errorf("preset not found: %s", name)
+}
+
+// GetPreset retrieves a preset by name, ignoring case
+func (c *Config) GetPreset(name string) (*Preset, error) {
+	for _, p := range c.Presets {
+		if strings.EqualFold(p.Name, name) {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("preset not found: %s", name)
+}
+
+// PresetsForZone returns the presets of a zone, matched by name or ID
+func (c *Config) PresetsForZone(zoneName, zoneID string) []Preset {
+	var presets []Preset
+	for _, p := range c.Presets {
+		if strings.EqualFold(p.Zone, zoneName) || (zoneID != "" && p.Zone == zoneID) {
+			presets = append(presets, p)
+		}
+	}
+	return presets
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePreset(t *testing.T) {
+	valid := Preset{Name: "homepage", Zone: "example.com", Type: PresetTag, Targets: []string{"home"}}
+	assert.NoError(t, ValidatePreset(valid))
+	assert.NoError(t, ValidatePreset(Preset{Name: "all", Zone: "example.com", Type: PresetEverything}))
+
+	tests := []Preset{
+		{Name: "", Zone: "example.com", Type: PresetTag, Targets: []string{"a"}},
+		{Name: "has space", Zone: "example.com", Type: PresetTag, Targets: []string{"a"}},
+		{Name: "nozone", Type: PresetTag, Targets: []string{"a"}},
+		{Name: "notargets", Zone: "example.com", Type: PresetURL},
+		{Name: "badtype", Zone: "example.com", Type: "cookie", Targets: []string{"a"}},
+		{Name: "all", Zone: "example.com", Type: PresetEverything, Targets: []string{"a"}},
+	}
+	for _, p := range tests {
+		assert.Error(t, ValidatePreset(p), p.Name)
+	}
+}
+
+func TestPresetDescribe(t *testing.T) {
+	assert.Equal(t, "1 tag", Preset{Type: PresetTag, Targets: []string{"a"}}.Describe())
+	assert.Equal(t, "2 prefixes", Preset{Type: PresetPrefix, Targets: []string{"a", "b"}}.Describe())
+	assert.Equal(t, "everything", Preset{Type: PresetEverything}.Describe())
+}
+
+func TestPresetsRoundTrip(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("CFCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	require.NoError(t, cfg.SavePreset(Preset{Name: "home", Zone: "example.com", Type: PresetTag, Targets: []string{"a"}}))
+	require.NoError(t, cfg.SavePreset(Preset{Name: "assets", Zone: "other.com", Type: PresetPrefix, Targets: []string{"other.com/static"}}))
+	// Saving under an existing name replaces the preset
+	require.NoError(t, cfg.SavePreset(Preset{Name: "HOME", Zone: "example.com", Type: PresetTag, Targets: []string{"a", "b"}}))
+
+	viper.Reset()
+	cfg, err = Load()
+	require.NoError(t, err)
+	require.Len(t, cfg.Presets, 2)
+
+	preset, err := cfg.GetPreset("home")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, preset.Targets)
+
+	assert.Len(t, cfg.PresetsForZone("example.com", "zone-id"), 1)
+
+	require.NoError(t, cfg.RemovePreset("assets"))
+	assert.Error(t, cfg.RemovePreset("assets"))
+	_, err = cfg.GetPreset("assets")
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+// Package firewall checks WAF custom rule actions and parses IP Access rule
+// modes and targets.
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Actions are the WAF custom rule actions cfctl offers
+var Actions = []string{"block", "managed_challenge", "js_challenge", "challenge", "log", "skip"}
+
+// Modes are the IP Access rule modes. "allow" is accepted as an alias of
+// whitelist, the API's name for it.
+var Modes = []string{"block", "managed_challenge", "js_challenge", "challenge", "whitelist"}
+
+// Access rule targets
+const (
+	TargetIP      = "ip"
+	TargetIP6     = "ip6"
+	TargetIPRange = "ip_range"
+	TargetASN     = "asn"
+	TargetCountry = "country"
+)
+
+// ValidateAction checks a WAF custom rule action
+func ValidateAction(action string) error {
+	for _, a := range Actions {
+		if a == action {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid action %q, expected one of %s", action, strings.Join(Actions, ", "))
+}
+
+// ParseMode normalizes an IP Access rule mode, accepting "allow" for
+// whitelist
+func ParseMode(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "allow" {
+		return "whitelist", nil
+	}
+	for _, m := range Modes {
+		if m == mode {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("invalid mode %q, expected one of block, managed_challenge, js_challenge, challenge or allow", mode)
+}
+
+// ModeLabel returns the name shown for a mode, allow for whitelist
+func ModeLabel(mode string) string {
+	if mode == "whitelist" {
+		return "allow"
+	}
+	return mode
+}
+
+// ParseTarget works out what an IP Access rule value is: an IPv4 or IPv6
+// address, a CIDR range, an ASN (AS13335 or 13335) or a two-letter country
+// code. It returns the target and the value in the form the API expects.
+func ParseTarget(value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", fmt.Errorf("value is required")
+	}
+
+	if addr, err := netip.ParseAddr(value); err == nil {
+		if addr.Is4() {
+			return TargetIP, addr.String(), nil
+		}
+		return TargetIP6, addr.String(), nil
+	}
+
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		return parseRange(prefix)
+	}
+
+	asn := strings.TrimPrefix(strings.ToUpper(value), "AS")
+	if n, err := strconv.ParseUint(asn, 10, 32); err == nil {
+		return TargetASN, "AS" + strconv.FormatUint(n, 10), nil
+	}
+
+	if len(value) == 2 && isLetters(value) {
+		return TargetCountry, strings.ToUpper(value), nil
+	}
+
+	return "", "", fmt.Errorf("%q is not an IP address, CIDR range, ASN or country code", value)
+}
+
+// parseRange checks a CIDR range against the prefix lengths Cloudflare
+// accepts: /16 and /24 for IPv4, /32, /48 and /64 for IPv6
+func parseRange(prefix netip.Prefix) (string, string, error) {
+	allowed := []int{16, 24}
+	if !prefix.Addr().Is4() {
+		allowed = []int{32, 48, 64}
+	}
+	for _, bits := range allowed {
+		if prefix.Bits() == bits {
+			return TargetIPRange, prefix.Masked().String(), nil
+		}
+	}
+
+	lengths := make([]string, len(allowed))
+	for i, bits := range allowed {
+		lengths[i] = "/" + strconv.Itoa(bits)
+	}
+	return "", "", fmt.Errorf("range %s must be a %s", prefix, strings.Join(lengths, ", "))
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		value  string
+		target string
+		want   string
+	}{
+		{"198.51.100.4", TargetIP, "198.51.100.4"},
+		{"2001:db8::1", TargetIP6, "2001:db8::1"},
+		{"198.51.100.77/24", TargetIPRange, "198.51.100.0/24"},
+		{"2001:db8:1::/48", TargetIPRange, "2001:db8:1::/48"},
+		{"AS13335", TargetASN, "AS13335"},
+		{"13335", TargetASN, "AS13335"},
+		{"as64512", TargetASN, "AS64512"},
+		{"de", TargetCountry, "DE"},
+	}
+	for _, tt := range tests {
+		target, value, err := ParseTarget(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.target, target, tt.value)
+		assert.Equal(t, tt.want, value, tt.value)
+	}
+}
+
+func TestParseTargetErrors(t *testing.T) {
+	for _, value := range []string{"", "198.51.100.0/20", "2001:db8::/56", "example.com", "DEU"} {
+		_, _, err := ParseTarget(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("Allow")
+	require.NoError(t, err)
+	assert.Equal(t, "whitelist", mode)
+	assert.Equal(t, "allow", ModeLabel(mode))
+
+	mode, err = ParseMode("managed_challenge")
+	require.NoError(t, err)
+	assert.Equal(t, "managed_challenge", mode)
+
+	_, err = ParseMode("skip")
+	assert.Error(t, err)
+}
+
+func TestValidateAction(t *testing.T) {
+	assert.NoError(t, ValidateAction("block"))
+	assert.Error(t, ValidateAction("allow"))
+}
@@ -0,0 +1,46 @@
+package gitdiff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ChangedFiles returns the paths that differ between two revisions of the
+// repository in dir, relative to the repository root. Deleted files and both
+// sides of renames are included so their URLs are purged too.
+func ChangedFiles(ctx context.Context, dir, from, to string) ([]string, error) {
+	for _, rev := range []string{from, to} {
+		if rev == "" {
+			return nil, fmt.Errorf("revision is required")
+		}
+		// Keep revisions from being interpreted as git options
+		if strings.HasPrefix(rev, "-") {
+			return nil, fmt.Errorf("invalid revision %q", rev)
+		}
+	}
+
+	args := []string{"-C", dir, "diff", "--name-only", "--no-renames", "-z", from, to, "--"}
+	cmd := exec.CommandContext(ctx, "git", args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git diff: %s", msg)
+		}
+		return nil, fmt.Errorf("git diff: %w", err)
+	}
+
+	var files []string
+	for _, name := range strings.Split(stdout.String(), "\x00") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,64 @@
+package gitdiff
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	git(t, dir, "init", "-q")
+	write("public/index.html", "v1")
+	write("public/old.css", "v1")
+	write("README.md", "v1")
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "first")
+
+	write("public/index.html", "v2")
+	write("public/new page.html", "v1")
+	require.NoError(t, os.Rename(filepath.Join(dir, "public/old.css"), filepath.Join(dir, "public/site.css")))
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "second")
+
+	files, err := ChangedFiles(context.Background(), dir, "HEAD~1", "HEAD")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"public/index.html",
+		"public/new page.html",
+		"public/old.css",
+		"public/site.css",
+	}, files)
+
+	_, err = ChangedFiles(context.Background(), dir, "--output=/tmp/x", "HEAD")
+	assert.Error(t, err)
+
+	_, err = ChangedFiles(context.Background(), dir, "no-such-rev", "HEAD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+)
+
+// historyFile is the name of the history file in the config directory
+const historyFile = "history.jsonl"
+
+// Entry states
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Entry records the outcome of one purge
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	JobID    string    `json:"job_id,omitempty"`
+	Account  string    `json:"account,omitempty"`
+	Zone     string    `json:"zone"`
+	Summary  string    `json:"summary"`
+	Status   string    `json:"status"`
+	Attempts int       `json:"attempts,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Log is an append-only JSON Lines file of purge results
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New returns a log stored at path
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Default returns the log stored in the config directory
+func Default() (*Log, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(dir, historyFile)), nil
+}
+
+// Path returns the location of the history file
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds an entry to the end of the log
+func (l *Log) Append(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode history entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// Read returns the most recent entries, oldest first. A limit of zero or
+// less returns every entry. Lines that cannot be parsed are skipped.
+func (l *Log) Read(limit int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,45 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	log := New(path)
+
+	entries, err := log.Read(0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, zone := range []string{"a.com", "b.com", "c.com"} {
+		require.NoError(t, log.Append(Entry{Source: "scheduler", Zone: zone, Summary: "1 URL", Status: StatusSucceeded}))
+	}
+
+	// Corrupt lines are skipped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("not json\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	entries, err = log.Read(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.False(t, entries[0].Time.IsZero())
+
+	entries, err = log.Read(2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b.com", entries[0].Zone)
+	assert.Equal(t, "c.com", entries[1].Zone)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -0,0 +1,422 @@
+// Package incident implements "under attack" incident mode: a hardened
+// security profile applied to a zone during an attack, with a snapshot of
+// the previous configuration so it can be restored exactly afterwards.
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// stateFile is the name of the snapshot file in the config directory
+const stateFile = "incidents.json"
+
+var (
+	// ErrActive is returned when starting an incident on a zone that already
+	// has one
+	ErrActive = errors.New("incident mode is already active")
+
+	// ErrNotActive is returned when ending an incident on a zone without one
+	ErrNotActive = errors.New("incident mode is not active")
+)
+
+// DefaultSettings is the profile applied when the config doesn't define
+// one: "I'm Under Attack" mode, the browser integrity check and a 30
+// minute challenge passage
+var DefaultSettings = map[string]interface{}{
+	"security_level": "under_attack",
+	"browser_check":  "on",
+	"challenge_ttl":  1800,
+}
+
+// Client is the part of api.Client needed to start and end incidents
+type Client interface {
+	GetZoneSetting(ctx context.Context, zoneID, setting string) (json.RawMessage, error)
+	UpdateZoneSetting(ctx context.Context, zoneID, setting string, value interface{}) error
+	ListFirewallRules(ctx context.Context, scope api.Scope) ([]cloudflare.FirewallRule, error)
+	UpdateFirewallRule(ctx context.Context, scope api.Scope, rule cloudflare.FirewallRule) error
+}
+
+// Profile is the hardened configuration applied during an incident
+type Profile struct {
+	Settings    map[string]interface{}
+	EnableRules []string
+}
+
+// ProfileFrom builds the incident profile from the config, falling back to
+// DefaultSettings when no settings are configured. Boolean values are
+// written as "on" and "off" as the settings API expects.
+func ProfileFrom(cfg config.IncidentSettings) Profile {
+	source := cfg.Settings
+	if len(source) == 0 {
+		source = DefaultSettings
+	}
+
+	settings := make(map[string]interface{}, len(source))
+	for id, value := range source {
+		if b, ok := value.(bool); ok {
+			value = "off"
+			if b {
+				value = "on"
+			}
+		}
+		settings[strings.TrimSpace(id)] = value
+	}
+
+	return Profile{Settings: settings, EnableRules: cfg.EnableRules}
+}
+
+// SettingIDs returns the IDs of the profile's settings in a stable order
+func (p Profile) SettingIDs() []string {
+	return sortedKeys(p.Settings)
+}
+
+// RuleState records the enabled state of a WAF custom rule before the
+// incident changed it
+type RuleState struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Label names the rule by its description, falling back to its ID
+func (r RuleState) Label() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	return r.ID
+}
+
+// Snapshot is the configuration of a zone captured when an incident starts
+type Snapshot struct {
+	ZoneID    string                     `json:"zone_id"`
+	Zone      string                     `json:"zone"`
+	Account   string                     `json:"account,omitempty"`
+	StartedAt time.Time                  `json:"started_at"`
+	Settings  map[string]json.RawMessage `json:"settings"`
+	Applied   map[string]interface{}     `json:"applied"`
+	Rules     []RuleState                `json:"rules,omitempty"`
+}
+
+// Change describes one setting changed by an incident
+type Change struct {
+	Setting string
+	From    string
+	To      string
+}
+
+// Changes lists the settings the incident changed with their previous and
+// incident values, ordered by setting ID
+func (s Snapshot) Changes() []Change {
+	changes := make([]Change, 0, len(s.Settings))
+	for _, id := range sortedKeys(s.Settings) {
+		changes = append(changes, Change{
+			Setting: id,
+			From:    FormatValue(s.Settings[id]),
+			To:      fmt.Sprint(s.Applied[id]),
+		})
+	}
+	return changes
+}
+
+// FormatValue renders a raw setting value for display, without the quotes
+// around strings
+func FormatValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// Start snapshots the zone's current values of every setting in the
+// profile, stores the snapshot and then applies the profile. The snapshot
+// is stored before anything changes, so a partly applied profile can still
+// be rolled back with End.
+func Start(ctx context.Context, client Client, store *Store, zone cloudflare.Zone, account string, profile Profile) (*Snapshot, error) {
+	if len(profile.Settings) == 0 && len(profile.EnableRules) == 0 {
+		return nil, fmt.Errorf("incident profile is empty")
+	}
+
+	existing, err := store.Get(zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w on %s since %s", ErrActive, zone.Name, existing.StartedAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	snapshot := &Snapshot{
+		ZoneID:    zone.ID,
+		Zone:      zone.Name,
+		Account:   account,
+		StartedAt: time.Now(),
+		Settings:  make(map[string]json.RawMessage, len(profile.Settings)),
+		Applied:   profile.Settings,
+	}
+	for _, id := range profile.SettingIDs() {
+		value, err := client.GetZoneSetting(ctx, zone.ID, id)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Settings[id] = value
+	}
+
+	rules, err := rulesToEnable(ctx, client, zone.ID, profile.EnableRules)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		snapshot.Rules = append(snapshot.Rules, RuleState{ID: rule.ID, Description: rule.Description, Enabled: rule.Enabled})
+	}
+
+	if err := store.Put(*snapshot); err != nil {
+		return nil, err
+	}
+
+	for _, id := range profile.SettingIDs() {
+		if err := client.UpdateZoneSetting(ctx, zone.ID, id, profile.Settings[id]); err != nil {
+			return snapshot, fmt.Errorf("%w (the profile was partly applied; end the incident to restore)", err)
+		}
+	}
+	for _, rule := range rules {
+		rule.Enabled = true
+		if err := client.UpdateFirewallRule(ctx, api.ZoneScope(zone.ID), rule); err != nil {
+			return snapshot, fmt.Errorf("%w (the profile was partly applied; end the incident to restore)", err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// rulesToEnable finds the disabled WAF custom rules named by description or
+// ID. Rules that are already enabled are left alone and not recorded.
+func rulesToEnable(ctx context.Context, client Client, zoneID string, names []string) ([]cloudflare.FirewallRule, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	rules, err := client.ListFirewallRules(ctx, api.ZoneScope(zoneID))
+	if err != nil {
+		return nil, err
+	}
+
+	var found []cloudflare.FirewallRule
+	for _, name := range names {
+		idx := -1
+		for i, rule := range rules {
+			if rule.ID == name || rule.Description == name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("firewall rule not found: %s", name)
+		}
+		if !rules[idx].Enabled {
+			found = append(found, rules[idx])
+		}
+	}
+	return found, nil
+}
+
+// End restores every setting and rule recorded in the zone's snapshot and
+// removes the snapshot. When anything fails to restore the snapshot is
+// kept so End can be run again.
+func End(ctx context.Context, client Client, store *Store, zoneID string) (*Snapshot, error) {
+	snapshot, err := store.Get(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrNotActive
+	}
+
+	var errs []error
+	for _, id := range sortedKeys(snapshot.Settings) {
+		if err := client.UpdateZoneSetting(ctx, zoneID, id, snapshot.Settings[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(snapshot.Rules) > 0 {
+		rules, err := client.ListFirewallRules(ctx, api.ZoneScope(zoneID))
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, state := range snapshot.Rules {
+			for _, rule := range rules {
+				// A rule deleted during the incident has nothing to restore
+				if rule.ID != state.ID || rule.Enabled == state.Enabled {
+					continue
+				}
+				// Only the enabled state is reverted; the rule is written
+				// back as listed, action parameters and logging included
+				rule.Enabled = state.Enabled
+				if err := client.UpdateFirewallRule(ctx, api.ZoneScope(zoneID), rule); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return snapshot, errors.Join(errs...)
+	}
+	if err := store.Delete(zoneID); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// Store is the file-backed set of active incident snapshots, one per zone
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a store kept at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the store kept in the config directory
+func DefaultStore() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, stateFile)), nil
+}
+
+// Get returns the snapshot of a zone, or nil when no incident is active
+func (s *Store) Get(zoneID string) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, ok := snapshots[zoneID]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+// List returns every active incident, oldest first
+func (s *Store) List() ([]Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		list = append(list, snapshot)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartedAt.Before(list[j].StartedAt)
+	})
+	return list, nil
+}
+
+// Put stores a zone's snapshot
+func (s *Store) Put(snapshot Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil {
+		return err
+	}
+	snapshots[snapshot.ZoneID] = snapshot
+	return s.save(snapshots)
+}
+
+// Delete removes a zone's snapshot
+func (s *Store) Delete(zoneID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(snapshots, zoneID)
+	return s.save(snapshots)
+}
+
+func (s *Store) load() (map[string]Snapshot, error) {
+	snapshots := map[string]Snapshot{}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read incident state: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &snapshots); err != nil {
+			return nil, fmt.Errorf("parse incident state: %w", err)
+		}
+	}
+	return snapshots, nil
+}
+
+// save writes the snapshots atomically so a crash never loses the values
+// needed to end an incident
+func (s *Store) save(snapshots map[string]Snapshot) error {
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode incident state: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, stateFile+".*")
+	if err != nil {
+		return fmt.Errorf("write incident state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write incident state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write incident state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write incident state: %w", err)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
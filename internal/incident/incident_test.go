@@ -0,0 +1,183 @@
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	settings map[string]json.RawMessage
+	rules    []cloudflare.FirewallRule
+	failSet  string // setting whose update fails
+}
+
+func (f *fakeClient) GetZoneSetting(ctx context.Context, zoneID, setting string) (json.RawMessage, error) {
+	value, ok := f.settings[setting]
+	if !ok {
+		return nil, errors.New("unknown setting " + setting)
+	}
+	return value, nil
+}
+
+func (f *fakeClient) UpdateZoneSetting(ctx context.Context, zoneID, setting string, value interface{}) error {
+	if setting == f.failSet {
+		return errors.New("update failed")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.settings[setting] = data
+	return nil
+}
+
+func (f *fakeClient) ListFirewallRules(ctx context.Context, scope api.Scope) ([]cloudflare.FirewallRule, error) {
+	return append([]cloudflare.FirewallRule(nil), f.rules...), nil
+}
+
+// UpdateFirewallRule stores the rule as it would arrive over the wire, so
+// fields lost in encoding are lost here too
+func (f *fakeClient) UpdateFirewallRule(ctx context.Context, scope api.Scope, rule cloudflare.FirewallRule) error {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	var sent cloudflare.FirewallRule
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return err
+	}
+	for i := range f.rules {
+		if f.rules[i].ID == rule.ID {
+			f.rules[i] = sent
+		}
+	}
+	return nil
+}
+
+func newFake() *fakeClient {
+	return &fakeClient{
+		settings: map[string]json.RawMessage{
+			"security_level": json.RawMessage(`"medium"`),
+			"browser_check":  json.RawMessage(`"off"`),
+			"challenge_ttl":  json.RawMessage(`3600`),
+		},
+		rules: []cloudflare.FirewallRule{
+			{ID: "r1", Description: "Block bad countries", Action: "block", Enabled: false},
+			{ID: "r2", Description: "Challenge bots", Action: "managed_challenge", Enabled: true},
+		},
+	}
+}
+
+func TestProfileFrom(t *testing.T) {
+	profile := ProfileFrom(config.IncidentSettings{})
+	assert.Equal(t, DefaultSettings, profile.Settings)
+	assert.Equal(t, []string{"browser_check", "challenge_ttl", "security_level"}, profile.SettingIDs())
+
+	profile = ProfileFrom(config.IncidentSettings{
+		Settings:    map[string]interface{}{"browser_check": true, "always_use_https": false},
+		EnableRules: []string{"r1"},
+	})
+	assert.Equal(t, map[string]interface{}{"browser_check": "on", "always_use_https": "off"}, profile.Settings)
+	assert.Equal(t, []string{"r1"}, profile.EnableRules)
+}
+
+func TestStartAndEndRestoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	client := newFake()
+	store := NewStore(filepath.Join(t.TempDir(), "incidents.json"))
+	zone := cloudflare.Zone{ID: "zone-1", Name: "example.com"}
+	profile := ProfileFrom(config.IncidentSettings{EnableRules: []string{"Block bad countries", "r2"}})
+
+	snapshot, err := Start(ctx, client, store, zone, "work", profile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"under_attack"`, string(client.settings["security_level"]))
+	assert.JSONEq(t, `1800`, string(client.settings["challenge_ttl"]))
+	assert.True(t, client.rules[0].Enabled)
+	// Already enabled rules aren't recorded
+	assert.Equal(t, []RuleState{{ID: "r1", Description: "Block bad countries", Enabled: false}}, snapshot.Rules)
+	assert.Contains(t, snapshot.Changes(), Change{Setting: "security_level", From: "medium", To: "under_attack"})
+
+	_, err = Start(ctx, client, store, zone, "work", profile)
+	assert.ErrorIs(t, err, ErrActive)
+
+	_, err = End(ctx, client, store, "zone-1")
+	require.NoError(t, err)
+	assert.Equal(t, newFake().settings, client.settings)
+	assert.False(t, client.rules[0].Enabled)
+	assert.True(t, client.rules[1].Enabled)
+
+	active, err := store.Get("zone-1")
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	_, err = End(ctx, client, store, "zone-1")
+	assert.ErrorIs(t, err, ErrNotActive)
+}
+
+func TestEndRestoresRuleWithParameters(t *testing.T) {
+	ctx := context.Background()
+	client := newFake()
+	var rule cloudflare.FirewallRule
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "r3",
+		"description": "Block scrapers",
+		"expression": "cf.client.bot",
+		"action": "block",
+		"enabled": false,
+		"logging": {"enabled": true},
+		"action_parameters": {"response": {"status_code": 429, "content": "slow down", "content_type": "text/plain"}}
+	}`), &rule))
+	client.rules = append(client.rules, rule)
+	store := NewStore(filepath.Join(t.TempDir(), "incidents.json"))
+
+	_, err := Start(ctx, client, store, cloudflare.Zone{ID: "zone-1", Name: "example.com"}, "", ProfileFrom(config.IncidentSettings{EnableRules: []string{"r3"}}))
+	require.NoError(t, err)
+	assert.True(t, client.rules[2].Enabled)
+
+	_, err = End(ctx, client, store, "zone-1")
+	require.NoError(t, err)
+
+	restored, err := json.Marshal(client.rules[2])
+	require.NoError(t, err)
+	assert.JSONEq(t, string(rule.Raw), string(restored))
+}
+
+func TestStartKeepsSnapshotWhenApplyFails(t *testing.T) {
+	ctx := context.Background()
+	client := newFake()
+	client.failSet = "security_level"
+	store := NewStore(filepath.Join(t.TempDir(), "incidents.json"))
+
+	_, err := Start(ctx, client, store, cloudflare.Zone{ID: "zone-1", Name: "example.com"}, "", ProfileFrom(config.IncidentSettings{}))
+	require.Error(t, err)
+
+	// browser_check and challenge_ttl were applied before the failure
+	assert.JSONEq(t, `"on"`, string(client.settings["browser_check"]))
+
+	client.failSet = ""
+	_, err = End(ctx, client, store, "zone-1")
+	require.NoError(t, err)
+	assert.Equal(t, newFake().settings, client.settings)
+}
+
+func TestStartFailsBeforeChangesForUnknownRule(t *testing.T) {
+	client := newFake()
+	store := NewStore(filepath.Join(t.TempDir(), "incidents.json"))
+
+	_, err := Start(context.Background(), client, store, cloudflare.Zone{ID: "zone-1"}, "", ProfileFrom(config.IncidentSettings{EnableRules: []string{"missing"}}))
+	require.Error(t, err)
+	assert.Equal(t, newFake().settings, client.settings)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,206 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Report describes the caching behaviour observed for a single URL
+type Report struct {
+	URL             string   `json:"url"`
+	StatusCode      int      `json:"status_code"`
+	CacheStatus     string   `json:"cache_status,omitempty"`
+	Age             int      `json:"age,omitempty"`
+	Ray             string   `json:"ray,omitempty"`
+	Colo            string   `json:"colo,omitempty"`
+	Server          string   `json:"server,omitempty"`
+	CacheControl    string   `json:"cache_control,omitempty"`
+	CDNCacheControl string   `json:"cdn_cache_control,omitempty"`
+	Expires         string   `json:"expires,omitempty"`
+	Vary            string   `json:"vary,omitempty"`
+	ETag            string   `json:"etag,omitempty"`
+	LastModified    string   `json:"last_modified,omitempty"`
+	CacheTags       []string `json:"cache_tags,omitempty"`
+	SetCookie       bool     `json:"set_cookie"`
+	Proxied         bool     `json:"proxied"`
+	Cached          bool     `json:"cached"`
+	Reasons         []string `json:"reasons"`
+}
+
+// Options configures an inspection request
+type Options struct {
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// cacheTagHeaders are the response headers origins use to attach cache tags
+var cacheTagHeaders = []string{"Cache-Tag", "Surrogate-Key", "X-Cache-Tags"}
+
+// URL requests url and builds a Report from the response headers
+func URL(ctx context.Context, url string, opts Options) (*Report, error) {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("inspect %s: %w", url, err)
+	}
+	req.Header.Set("User-Agent", "cfctl-inspect")
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("inspect %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return FromResponse(url, resp), nil
+}
+
+// FromResponse builds a Report from an HTTP response
+func FromResponse(url string, resp *http.Response) *Report {
+	h := resp.Header
+	r := &Report{
+		URL:             url,
+		StatusCode:      resp.StatusCode,
+		CacheStatus:     strings.ToUpper(strings.TrimSpace(h.Get("CF-Cache-Status"))),
+		Ray:             h.Get("CF-Ray"),
+		Server:          h.Get("Server"),
+		CacheControl:    h.Get("Cache-Control"),
+		CDNCacheControl: firstHeader(h, "Cloudflare-CDN-Cache-Control", "CDN-Cache-Control"),
+		Expires:         h.Get("Expires"),
+		Vary:            h.Get("Vary"),
+		ETag:            h.Get("ETag"),
+		LastModified:    h.Get("Last-Modified"),
+		SetCookie:       len(h.Values("Set-Cookie")) > 0,
+	}
+
+	if age, err := strconv.Atoi(strings.TrimSpace(h.Get("Age"))); err == nil {
+		r.Age = age
+	}
+
+	// CF-Ray has the form "<id>-<colo>"
+	if i := strings.LastIndex(r.Ray, "-"); i >= 0 {
+		r.Colo = r.Ray[i+1:]
+	}
+
+	for _, name := range cacheTagHeaders {
+		for _, v := range h.Values(name) {
+			for _, tag := range strings.Split(v, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					r.CacheTags = append(r.CacheTags, tag)
+				}
+			}
+		}
+	}
+
+	r.Proxied = r.Ray != "" || strings.EqualFold(r.Server, "cloudflare")
+	r.Cached = r.CacheStatus == "HIT" || r.CacheStatus == "STALE" ||
+		r.CacheStatus == "UPDATING" || r.CacheStatus == "REVALIDATED"
+	r.Reasons = explain(r)
+	return r
+}
+
+// explain returns human readable reasons why the response was or wasn't
+// served from cache
+func explain(r *Report) []string {
+	var reasons []string
+
+	if !r.Proxied {
+		return []string{"No Cloudflare headers found: the hostname is not proxied through Cloudflare (grey-clouded) or the request bypassed it"}
+	}
+
+	switch r.CacheStatus {
+	case "HIT":
+		reason := "Served from Cloudflare's cache"
+		if r.Age > 0 {
+			reason += fmt.Sprintf(" (cached %s ago)", time.Duration(r.Age)*time.Second)
+		}
+		reasons = append(reasons, reason)
+	case "MISS":
+		reasons = append(reasons, "Eligible for caching but not in this data center's cache yet; the response was fetched from origin and the next request should be a HIT")
+	case "EXPIRED":
+		reasons = append(reasons, "Was cached but its TTL expired, so it was fetched from origin again")
+	case "STALE":
+		reasons = append(reasons, "Served a stale cached copy because the origin could not be reached or returned an error")
+	case "UPDATING":
+		reasons = append(reasons, "Served a stale cached copy while the cache is being refreshed from origin")
+	case "REVALIDATED":
+		reasons = append(reasons, "The cached copy was revalidated with origin (304 Not Modified) and served from cache")
+	case "BYPASS":
+		reasons = append(reasons, "Caching was bypassed: the origin asked Cloudflare not to cache, or a Cache Rule set it to bypass")
+	case "DYNAMIC":
+		reasons = append(reasons, "Not eligible for caching: Cloudflare does not cache this content type by default (e.g. HTML) and no Cache Rule makes it eligible")
+	case "NONE", "UNKNOWN":
+		reasons = append(reasons, "No cache lookup happened: the response was generated by a Worker, a redirect or another Cloudflare feature")
+	case "":
+		reasons = append(reasons, "Proxied by Cloudflare but no CF-Cache-Status header was returned, so the response was not considered for caching")
+	default:
+		reasons = append(reasons, fmt.Sprintf("Unrecognized cache status %q", r.CacheStatus))
+	}
+
+	if r.Cached {
+		return reasons
+	}
+
+	cc := strings.ToLower(r.CacheControl + "," + r.CDNCacheControl)
+	for _, directive := range []string{"private", "no-store", "no-cache"} {
+		if hasDirective(cc, directive) {
+			reasons = append(reasons, fmt.Sprintf("Cache-Control contains %q, which tells Cloudflare not to cache the response", directive))
+		}
+	}
+	if hasDirective(cc, "max-age=0") || hasDirective(cc, "s-maxage=0") {
+		reasons = append(reasons, "Cache-Control sets a zero max-age, so the response expires immediately")
+	}
+	if r.SetCookie {
+		reasons = append(reasons, "The response sets a cookie; Cloudflare does not cache responses with Set-Cookie unless a Cache Rule overrides it")
+	}
+	if r.Vary != "" && !strings.EqualFold(strings.TrimSpace(r.Vary), "accept-encoding") {
+		reasons = append(reasons, fmt.Sprintf("Vary: %s limits caching; Cloudflare only honours Vary for Accept-Encoding (and images)", r.Vary))
+	}
+	if !cacheableStatus(r.StatusCode) {
+		reasons = append(reasons, fmt.Sprintf("HTTP %d responses are not cached by default", r.StatusCode))
+	}
+
+	return reasons
+}
+
+// hasDirective reports whether a comma separated Cache-Control value
+// contains directive
+func hasDirective(value, directive string) bool {
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheableStatus reports whether Cloudflare caches responses with this status
+// code by default
+func cacheableStatus(code int) bool {
+	switch code {
+	case 200, 206, 301, 302, 303, 404, 410:
+		return true
+	}
+	return false
+}
+
+func firstHeader(h http.Header, names ...string) string {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package inspect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "br", r.Header.Get("Accept-Encoding"))
+
+		switch r.URL.Path {
+		case "/hit":
+			w.Header().Set("CF-Cache-Status", "HIT")
+			w.Header().Set("Age", "120")
+			w.Header().Set("CF-Ray", "8a1b2c3d4e5f6789-AMS")
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+			w.Header().Set("Cache-Tag", "product, header")
+		case "/bypass":
+			w.Header().Set("CF-Cache-Status", "BYPASS")
+			w.Header().Set("CF-Ray", "8a1b2c3d4e5f6789-LHR")
+			w.Header().Set("Cache-Control", "private, no-store")
+			w.Header().Add("Set-Cookie", "session=1")
+		case "/origin":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	opts := Options{Headers: map[string]string{"Accept-Encoding": "br"}}
+
+	report, err := URL(context.Background(), server.URL+"/hit", opts)
+	require.NoError(t, err)
+	assert.True(t, report.Proxied)
+	assert.True(t, report.Cached)
+	assert.Equal(t, "HIT", report.CacheStatus)
+	assert.Equal(t, 120, report.Age)
+	assert.Equal(t, "AMS", report.Colo)
+	assert.Equal(t, []string{"product", "header"}, report.CacheTags)
+	require.Len(t, report.Reasons, 1)
+	assert.Contains(t, report.Reasons[0], "2m0s ago")
+
+	report, err = URL(context.Background(), server.URL+"/bypass", opts)
+	require.NoError(t, err)
+	assert.False(t, report.Cached)
+	assert.True(t, report.SetCookie)
+	assert.Len(t, report.Reasons, 4)
+	assert.Contains(t, report.Reasons[1], `"private"`)
+	assert.Contains(t, report.Reasons[2], `"no-store"`)
+	assert.Contains(t, report.Reasons[3], "sets a cookie")
+
+	report, err = URL(context.Background(), server.URL+"/origin", opts)
+	require.NoError(t, err)
+	assert.False(t, report.Proxied)
+	assert.Equal(t, http.StatusInternalServerError, report.StatusCode)
+	require.Len(t, report.Reasons, 1)
+	assert.Contains(t, report.Reasons[0], "not proxied")
+}
+
+func TestFromResponseDynamic(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("CF-Cache-Status", "dynamic")
+	resp.Header.Set("Server", "cloudflare")
+	resp.Header.Set("Vary", "User-Agent")
+
+	report := FromResponse("https://example.com/", resp)
+	assert.Equal(t, "DYNAMIC", report.CacheStatus)
+	assert.True(t, report.Proxied)
+	assert.False(t, report.Cached)
+	require.Len(t, report.Reasons, 2)
+	assert.Contains(t, report.Reasons[0], "Not eligible")
+	assert.Contains(t, report.Reasons[1], "Vary: User-Agent")
+}
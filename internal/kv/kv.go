@@ -0,0 +1,230 @@
+// Package kv checks keys, values and options for Workers KV writes and
+// parses bulk write files.
+package kv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+const (
+	// MaxKeyLength is the longest key name KV accepts, in bytes
+	MaxKeyLength = 512
+	// MaxMetadataSize is the largest serialized metadata KV stores, in bytes
+	MaxMetadataSize = 1024
+	// MinTTL is the shortest expiration KV accepts
+	MinTTL = 60 * time.Second
+)
+
+// ValidateKey checks a key name against the limits of KV
+func ValidateKey(key string) error {
+	switch {
+	case key == "":
+		return fmt.Errorf("key name is empty")
+	case key == "." || key == "..":
+		return fmt.Errorf("key name %q is not allowed", key)
+	case len(key) > MaxKeyLength:
+		return fmt.Errorf("key name is %d bytes; KV allows at most %d", len(key), MaxKeyLength)
+	}
+	return nil
+}
+
+// ParseMetadata checks that metadata is JSON within the size KV stores and
+// returns it compacted
+func ParseMetadata(value string) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(value)); err != nil {
+		return nil, fmt.Errorf("metadata must be JSON: %w", err)
+	}
+	if buf.Len() > MaxMetadataSize {
+		return nil, fmt.Errorf("metadata is %d bytes; KV allows at most %d", buf.Len(), MaxMetadataSize)
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+// ParseTTL parses how long a key lives, such as "90s", "12h" or "7d", and
+// returns it in seconds
+func ParseTTL(value string) (int64, error) {
+	var ttl time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q: expected a duration like 90s, 12h or 7d", value)
+		}
+		ttl = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if ttl, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid TTL %q: expected a duration like 90s, 12h or 7d", value)
+		}
+	}
+
+	if ttl < MinTTL {
+		return 0, fmt.Errorf("TTL %s is too short; KV requires at least %s", value, MinTTL)
+	}
+	return int64(ttl / time.Second), nil
+}
+
+// ParseExpiration parses when a key expires, as Unix seconds or an RFC 3339
+// time, and returns it as Unix seconds
+func ParseExpiration(value string, now time.Time) (int64, error) {
+	var at time.Time
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		at = time.Unix(seconds, 0)
+	} else if at, err = time.Parse(time.RFC3339, value); err != nil {
+		return 0, fmt.Errorf("invalid expiration %q: expected Unix seconds or a time like 2025-01-02T15:04:05Z", value)
+	}
+
+	if at.Sub(now) < MinTTL {
+		return 0, fmt.Errorf("expiration %s must be at least %s in the future", at.Format(time.RFC3339), MinTTL)
+	}
+	return at.Unix(), nil
+}
+
+// FormatExpiration describes when a key expires
+func FormatExpiration(expiration int64, now time.Time) string {
+	if expiration == 0 {
+		return "never"
+	}
+	at := time.Unix(expiration, 0)
+	left := at.Sub(now)
+
+	var in string
+	switch {
+	case left <= 0:
+		return at.Local().Format("2006-01-02 15:04") + " (expired)"
+	case left >= 48*time.Hour:
+		in = fmt.Sprintf("%dd", int(left.Hours()/24))
+	case left >= time.Hour:
+		in = fmt.Sprintf("%dh%02dm", int(left.Hours()), int(left.Minutes())%60)
+	default:
+		in = fmt.Sprintf("%dm", int(left.Minutes()))
+	}
+	return fmt.Sprintf("%s (in %s)", at.Local().Format("2006-01-02 15:04"), in)
+}
+
+// IsText reports whether a value is text that can be shown and edited in a
+// terminal rather than binary data
+func IsText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// bulkEntry is one entry of a bulk file. Value is kept raw so values that
+// are JSON documents can be stored as their text.
+type bulkEntry struct {
+	Key           string          `json:"key"`
+	Value         json.RawMessage `json:"value"`
+	Expiration    int64           `json:"expiration"`
+	ExpirationTTL int64           `json:"expiration_ttl"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Base64        bool            `json:"base64"`
+}
+
+// ParseBulk reads the pairs of a bulk write. The file is either an array in
+// the format of the bulk API, [{"key": ..., "value": ..., "expiration_ttl":
+// ..., "metadata": ...}], or an object mapping keys to values. Values that
+// aren't strings are stored as their JSON text.
+func ParseBulk(data []byte) ([]cloudflare.KVPair, error) {
+	data = bytes.TrimSpace(data)
+	var entries []bulkEntry
+
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("parse bulk file: %w", err)
+		}
+	case bytes.HasPrefix(data, []byte("{")):
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("parse bulk file: %w", err)
+		}
+		for key, value := range values {
+			entries = append(entries, bulkEntry{Key: key, Value: value})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	default:
+		return nil, fmt.Errorf("parse bulk file: expected a JSON array of pairs or an object of keys and values")
+	}
+
+	pairs := make([]cloudflare.KVPair, 0, len(entries))
+	seen := map[string]bool{}
+	for i, entry := range entries {
+		pair, err := entry.pair()
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if seen[pair.Key] {
+			return nil, fmt.Errorf("entry %d: duplicate key %q", i+1, pair.Key)
+		}
+		seen[pair.Key] = true
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
+
+// pair checks an entry and converts it to the pair the API expects
+func (e bulkEntry) pair() (cloudflare.KVPair, error) {
+	if err := ValidateKey(e.Key); err != nil {
+		return cloudflare.KVPair{}, err
+	}
+	pair := cloudflare.KVPair{
+		Key:           e.Key,
+		Expiration:    e.Expiration,
+		ExpirationTTL: e.ExpirationTTL,
+		Base64:        e.Base64,
+	}
+
+	if len(e.Value) == 0 || string(e.Value) == "null" {
+		return pair, fmt.Errorf("key %q has no value", e.Key)
+	}
+	if err := json.Unmarshal(e.Value, &pair.Value); err != nil {
+		// Not a string: store the JSON document itself
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, e.Value); err != nil {
+			return pair, fmt.Errorf("key %q: %w", e.Key, err)
+		}
+		if e.Base64 {
+			return pair, fmt.Errorf("key %q: a base64 value must be a string", e.Key)
+		}
+		pair.Value = buf.String()
+	}
+	if e.Base64 {
+		if _, err := base64.StdEncoding.DecodeString(pair.Value); err != nil {
+			return pair, fmt.Errorf("key %q: value is not valid base64", e.Key)
+		}
+	}
+
+	if e.Expiration != 0 && e.ExpirationTTL != 0 {
+		return pair, fmt.Errorf("key %q sets both expiration and expiration_ttl", e.Key)
+	}
+	if e.ExpirationTTL != 0 && e.ExpirationTTL < int64(MinTTL/time.Second) {
+		return pair, fmt.Errorf("key %q: expiration_ttl must be at least %d seconds", e.Key, int64(MinTTL/time.Second))
+	}
+
+	if len(e.Metadata) > 0 && string(e.Metadata) != "null" {
+		metadata, err := ParseMetadata(string(e.Metadata))
+		if err != nil {
+			return pair, fmt.Errorf("key %q: %w", e.Key, err)
+		}
+		pair.Metadata = metadata
+	}
+	return pair, nil
+}
@@ -0,0 +1,108 @@
+package kv
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	assert.NoError(t, ValidateKey("feature/dark-mode"))
+	assert.Error(t, ValidateKey(""))
+	assert.Error(t, ValidateKey(".."))
+	assert.Error(t, ValidateKey(strings.Repeat("k", MaxKeyLength+1)))
+}
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata(`{ "owner": "web" }`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"owner":"web"}`, string(metadata))
+
+	_, err = ParseMetadata(`{owner}`)
+	assert.Error(t, err)
+	_, err = ParseMetadata(`"` + strings.Repeat("m", MaxMetadataSize) + `"`)
+	assert.Error(t, err)
+}
+
+func TestParseTTL(t *testing.T) {
+	for value, want := range map[string]int64{"90s": 90, "12h": 43200, "7d": 604800} {
+		ttl, err := ParseTTL(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, ttl, value)
+	}
+
+	for _, value := range []string{"30s", "soon", "xd", ""} {
+		_, err := ParseTTL(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expiration, err := ParseExpiration("2025-01-02T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour).Unix(), expiration)
+
+	expiration, err = ParseExpiration("1735693200", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1735693200), expiration)
+
+	_, err = ParseExpiration("2024-12-31T00:00:00Z", now)
+	assert.Error(t, err)
+	_, err = ParseExpiration("tomorrow", now)
+	assert.Error(t, err)
+}
+
+func TestFormatExpiration(t *testing.T) {
+	now := time.Unix(1735689600, 0)
+	assert.Equal(t, "never", FormatExpiration(0, now))
+	assert.Contains(t, FormatExpiration(now.Add(90*time.Minute).Unix(), now), "(in 1h30m)")
+	assert.Contains(t, FormatExpiration(now.Add(72*time.Hour).Unix(), now), "(in 3d)")
+	assert.Contains(t, FormatExpiration(now.Add(-time.Minute).Unix(), now), "(expired)")
+}
+
+func TestIsText(t *testing.T) {
+	assert.True(t, IsText([]byte("line one\n\tline two")))
+	assert.False(t, IsText([]byte{0, 'a', 's', 'm'}))
+	assert.False(t, IsText([]byte{0xff, 0xfe}))
+}
+
+func TestParseBulkArray(t *testing.T) {
+	pairs, err := ParseBulk([]byte(`[
+		{"key": "greeting", "value": "hello", "expiration_ttl": 3600, "metadata": {"lang": "en"}},
+		{"key": "limits", "value": {"rps": 10}},
+		{"key": "logo", "value": "AGFzbQ==", "base64": true}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []cloudflare.KVPair{
+		{Key: "greeting", Value: "hello", ExpirationTTL: 3600, Metadata: []byte(`{"lang":"en"}`)},
+		{Key: "limits", Value: `{"rps":10}`},
+		{Key: "logo", Value: "AGFzbQ==", Base64: true},
+	}, pairs)
+}
+
+func TestParseBulkObject(t *testing.T) {
+	pairs, err := ParseBulk([]byte(`{"b": "two", "a": 1}`))
+	require.NoError(t, err)
+	assert.Equal(t, []cloudflare.KVPair{{Key: "a", Value: "1"}, {Key: "b", Value: "two"}}, pairs)
+}
+
+func TestParseBulkErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":      `key=value`,
+		"no key":        `[{"value": "v"}]`,
+		"no value":      `[{"key": "k"}]`,
+		"duplicate":     `[{"key": "k", "value": "1"}, {"key": "k", "value": "2"}]`,
+		"both expiries": `[{"key": "k", "value": "v", "expiration": 1900000000, "expiration_ttl": 3600}]`,
+		"short ttl":     `[{"key": "k", "value": "v", "expiration_ttl": 10}]`,
+		"bad base64":    `[{"key": "k", "value": "not base64!", "base64": true}]`,
+	} {
+		_, err := ParseBulk([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
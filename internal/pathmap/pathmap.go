@@ -0,0 +1,108 @@
+package pathmap
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
+)
+
+type rule struct {
+	match   *regexp.Regexp
+	replace string
+}
+
+// Mapper turns repository or build directory file paths into public URLs
+type Mapper struct {
+	base    *url.URL
+	include []*regexp.Regexp
+	rules   []rule
+}
+
+// New creates a Mapper for baseURL using the include globs and rewrite rules
+// from settings
+func New(baseURL string, settings config.PathSettings) (*Mapper, error) {
+	if err := utils.ValidateURL(baseURL); err != nil {
+		return nil, fmt.Errorf("base URL: %w", err)
+	}
+	base, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("base URL: %w", err)
+	}
+
+	m := &Mapper{base: base}
+
+	for _, g := range settings.Include {
+		re, err := utils.CompileGlob(strings.TrimPrefix(g, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid include glob %q: %w", g, err)
+		}
+		m.include = append(m.include, re)
+	}
+
+	for _, r := range settings.Rewrites {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rewrite %q: %w", r.Match, err)
+		}
+		m.rules = append(m.rules, rule{match: re, replace: r.Replace})
+	}
+
+	return m, nil
+}
+
+// ParseRewrite parses a "match=>replace" rewrite given on the command line
+func ParseRewrite(value string) (config.RewriteRule, error) {
+	match, replace, ok := strings.Cut(value, "=>")
+	if !ok || match == "" {
+		return config.RewriteRule{}, fmt.Errorf("invalid rewrite %q (expected \"regex=>replacement\")", value)
+	}
+	return config.RewriteRule{Match: match, Replace: replace}, nil
+}
+
+// URL maps a slash or OS separated relative file path to its URL. It returns
+// false for paths excluded by the include globs.
+func (m *Mapper) URL(file string) (string, bool) {
+	p := strings.TrimPrefix(filepath.ToSlash(file), "./")
+
+	if len(m.include) > 0 {
+		included := false
+		for _, re := range m.include {
+			if re.MatchString(p) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return "", false
+		}
+	}
+
+	for _, r := range m.rules {
+		p = r.match.ReplaceAllString(p, r.replace)
+	}
+
+	u := *m.base
+	u.Path = path.Join("/", m.base.Path, p)
+	// path.Join drops the trailing slash that directory URLs rely on
+	if strings.HasSuffix(p, "/") || p == "" {
+		u.Path = strings.TrimRight(u.Path, "/") + "/"
+	}
+	return u.String(), true
+}
+
+// URLs maps file paths to unique URLs in order, skipping excluded paths
+func (m *Mapper) URLs(files []string) []string {
+	urls := make([]string, 0, len(files))
+	for _, f := range files {
+		if u, ok := m.URL(f); ok {
+			urls = append(urls, u)
+		}
+	}
+	return utils.Dedupe(urls)
+}
@@ -0,0 +1,59 @@
+package pathmap
+
+import (
+	"testing"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapperURLs(t *testing.T) {
+	m, err := New("https://example.com/", config.PathSettings{
+		Include: []string{"public/**"},
+		Rewrites: []config.RewriteRule{
+			{Match: "^public/", Replace: ""},
+			{Match: `(^|/)index\.html$`, Replace: "$1"},
+		},
+	})
+	require.NoError(t, err)
+
+	urls := m.URLs([]string{
+		"public/index.html",
+		"public/blog/index.html",
+		"public/css/site main.css",
+		"src/app.ts",
+		"public/index.html",
+	})
+	assert.Equal(t, []string{
+		"https://example.com/",
+		"https://example.com/blog/",
+		"https://example.com/css/site%20main.css",
+	}, urls)
+}
+
+func TestMapperBasePath(t *testing.T) {
+	m, err := New("https://example.com/docs", config.PathSettings{})
+	require.NoError(t, err)
+
+	u, ok := m.URL("./guide/intro.html")
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/docs/guide/intro.html", u)
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New("example.com", config.PathSettings{})
+	assert.Error(t, err)
+
+	_, err = New("https://example.com", config.PathSettings{Rewrites: []config.RewriteRule{{Match: "("}}})
+	assert.Error(t, err)
+}
+
+func TestParseRewrite(t *testing.T) {
+	rule, err := ParseRewrite(`^dist/=>`)
+	require.NoError(t, err)
+	assert.Equal(t, config.RewriteRule{Match: "^dist/", Replace: ""}, rule)
+
+	_, err = ParseRewrite("no-arrow")
+	assert.Error(t, err)
+}
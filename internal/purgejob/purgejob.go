@@ -0,0 +1,184 @@
+package purgejob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// Purger is the part of api.Client needed to run purge jobs
+type Purger interface {
+	FindZone(ctx context.Context, nameOrID string) (*cloudflare.Zone, error)
+	PurgeCacheBatched(ctx context.Context, zoneID string, req cloudflare.PurgeRequest, progress func(done, total int)) error
+}
+
+// Spec describes what to purge in a zone. Several purge types may be
+// combined; each is sent as its own set of requests.
+type Spec struct {
+	Zone       string   `json:"zone"`
+	URLs       []string `json:"urls,omitempty"`
+	Hosts      []string `json:"hosts,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Prefixes   []string `json:"prefixes,omitempty"`
+	Everything bool     `json:"everything,omitempty"`
+}
+
+// FromRequest builds a spec for zone from a single purge request
+func FromRequest(zone string, req cloudflare.PurgeRequest) Spec {
+	return Spec{
+		Zone:       zone,
+		URLs:       req.Files,
+		Hosts:      req.Hosts,
+		Tags:       req.Tags,
+		Prefixes:   req.Prefixes,
+		Everything: req.PurgeEverything,
+	}
+}
+
+// FromPreset builds the spec for a saved preset
+func FromPreset(p config.Preset) Spec {
+	spec := Spec{Zone: p.Zone}
+	switch p.Type {
+	case config.PresetURL:
+		spec.URLs = p.Targets
+	case config.PresetHostname:
+		spec.Hosts = p.Targets
+	case config.PresetTag:
+		spec.Tags = p.Targets
+	case config.PresetPrefix:
+		spec.Prefixes = p.Targets
+	case config.PresetEverything:
+		spec.Everything = true
+	}
+	return spec
+}
+
+// Validate checks the zone and every target with the utils validators
+func (s Spec) Validate() error {
+	if s.Zone == "" {
+		return fmt.Errorf("zone is required")
+	}
+	if s.Everything {
+		if len(s.URLs)+len(s.Hosts)+len(s.Tags)+len(s.Prefixes) > 0 {
+			return fmt.Errorf("everything cannot be combined with other targets")
+		}
+		return nil
+	}
+
+	reqs := s.Requests()
+	if len(reqs) == 0 {
+		return fmt.Errorf("no purge targets provided")
+	}
+
+	// The validators enforce the per-request limit, so check batch by batch
+	for _, req := range reqs {
+		for _, batch := range api.SplitPurgeRequest(req, api.MaxPurgeBatchSize) {
+			var err error
+			switch {
+			case len(batch.Files) > 0:
+				err = utils.ValidateURLs(batch.Files)
+			case len(batch.Hosts) > 0:
+				err = utils.ValidateHostnames(batch.Hosts)
+			case len(batch.Tags) > 0:
+				err = utils.ValidateTags(batch.Tags)
+			case len(batch.Prefixes) > 0:
+				err = utils.ValidatePrefixes(batch.Prefixes)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Requests returns one purge request per purge type in the spec
+func (s Spec) Requests() []cloudflare.PurgeRequest {
+	if s.Everything {
+		return []cloudflare.PurgeRequest{{PurgeEverything: true}}
+	}
+
+	var reqs []cloudflare.PurgeRequest
+	if len(s.URLs) > 0 {
+		reqs = append(reqs, cloudflare.PurgeRequest{Files: s.URLs})
+	}
+	if len(s.Hosts) > 0 {
+		reqs = append(reqs, cloudflare.PurgeRequest{Hosts: s.Hosts})
+	}
+	if len(s.Tags) > 0 {
+		reqs = append(reqs, cloudflare.PurgeRequest{Tags: s.Tags})
+	}
+	if len(s.Prefixes) > 0 {
+		reqs = append(reqs, cloudflare.PurgeRequest{Prefixes: s.Prefixes})
+	}
+	return reqs
+}
+
+// Summary describes the spec in a few words, e.g. "3 URLs, 1 tag"
+func (s Spec) Summary() string {
+	if s.Everything {
+		return "everything"
+	}
+
+	var parts []string
+	add := func(n int, singular, plural string) {
+		if n > 0 {
+			parts = append(parts, utils.FormatCount(n, singular, plural))
+		}
+	}
+	add(len(s.URLs), "URL", "URLs")
+	add(len(s.Hosts), "host", "hosts")
+	add(len(s.Tags), "tag", "tags")
+	add(len(s.Prefixes), "prefix", "prefixes")
+	return strings.Join(parts, ", ")
+}
+
+// Retry controls how failed purges are retried
+type Retry struct {
+	Attempts int
+	Delay    time.Duration // doubled after every failed attempt
+}
+
+// Execute resolves the zone and runs every request of the spec, retrying
+// retryable failures. It returns the number of attempts made.
+func Execute(ctx context.Context, purger Purger, spec Spec, retry Retry) (int, error) {
+	if retry.Attempts < 1 {
+		retry.Attempts = 1
+	}
+
+	delay := retry.Delay
+	var err error
+	for attempt := 1; attempt <= retry.Attempts; attempt++ {
+		if err = run(ctx, purger, spec); err == nil || !api.IsRetryable(err) || attempt == retry.Attempts {
+			return attempt, err
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		}
+		delay *= 2
+	}
+	return retry.Attempts, err
+}
+
+func run(ctx context.Context, purger Purger, spec Spec) error {
+	zone, err := purger.FindZone(ctx, spec.Zone)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range spec.Requests() {
+		if err := purger.PurgeCacheBatched(ctx, zone.ID, req, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
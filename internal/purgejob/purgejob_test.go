@@ -0,0 +1,94 @@
+package purgejob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePurger struct {
+	failures int // number of calls that fail before succeeding
+	err      error
+	requests []cloudflare.PurgeRequest
+}
+
+func (f *fakePurger) FindZone(ctx context.Context, nameOrID string) (*cloudflare.Zone, error) {
+	if nameOrID == "missing.com" {
+		return nil, fmt.Errorf("%w: %s", api.ErrZoneNotFound, nameOrID)
+	}
+	return &cloudflare.Zone{ID: "zone-1", Name: nameOrID}, nil
+}
+
+func (f *fakePurger) PurgeCacheBatched(ctx context.Context, zoneID string, req cloudflare.PurgeRequest, progress func(done, total int)) error {
+	if f.failures > 0 {
+		f.failures--
+		return f.err
+	}
+	f.requests = append(f.requests, req)
+	return nil
+}
+
+func TestSpecValidate(t *testing.T) {
+	urls := make([]string, 45)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	assert.NoError(t, Spec{Zone: "example.com", URLs: urls, Tags: []string{"a"}}.Validate())
+	assert.NoError(t, Spec{Zone: "example.com", Everything: true}.Validate())
+
+	assert.Error(t, Spec{URLs: urls}.Validate())
+	assert.Error(t, Spec{Zone: "example.com"}.Validate())
+	assert.Error(t, Spec{Zone: "example.com", URLs: []string{"example.com/no-scheme"}}.Validate())
+	assert.Error(t, Spec{Zone: "example.com", Everything: true, Tags: []string{"a"}}.Validate())
+}
+
+func TestSpecSummary(t *testing.T) {
+	assert.Equal(t, "2 URLs, 1 tag", Spec{URLs: []string{"a", "b"}, Tags: []string{"t"}}.Summary())
+	assert.Equal(t, "everything", Spec{Everything: true}.Summary())
+}
+
+func TestFromPreset(t *testing.T) {
+	spec := FromPreset(config.Preset{Name: "news", Zone: "example.com", Type: config.PresetTag, Targets: []string{"a", "b"}})
+	assert.Equal(t, Spec{Zone: "example.com", Tags: []string{"a", "b"}}, spec)
+
+	spec = FromPreset(config.Preset{Name: "all", Zone: "example.com", Type: config.PresetEverything})
+	assert.True(t, spec.Everything)
+	assert.NoError(t, spec.Validate())
+}
+
+// errReset is a transient network error
+var errReset = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+
+func TestExecuteRetries(t *testing.T) {
+	spec := Spec{Zone: "example.com", URLs: []string{"https://example.com/"}, Tags: []string{"t"}}
+
+	purger := &fakePurger{failures: 2, err: errReset}
+	attempts, err := Execute(context.Background(), purger, spec, Retry{Attempts: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, purger.requests, 2)
+
+	purger = &fakePurger{failures: 5, err: errReset}
+	attempts, err = Execute(context.Background(), purger, spec, Retry{Attempts: 2})
+	assert.Error(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// Errors that aren't transient are not retried
+	attempts, err = Execute(context.Background(), &fakePurger{failures: 5, err: errors.New("no credential stored")}, spec, Retry{Attempts: 3})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	// Unknown zones are not retried
+	attempts, err = Execute(context.Background(), &fakePurger{}, Spec{Zone: "missing.com"}, Retry{Attempts: 3})
+	assert.ErrorIs(t, err, api.ErrZoneNotFound)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,291 @@
+// Package redirects parses and checks source to target redirect mappings
+// and converts them to and from Single Redirect rules.
+package redirects
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// DefaultStatus is the status code used when a mapping doesn't set one
+const DefaultStatus = 301
+
+// StatusCodes are the redirect status codes Cloudflare accepts
+var StatusCodes = []int{301, 302, 307, 308}
+
+// ValidStatus reports whether code is an accepted redirect status code
+func ValidStatus(code int) bool {
+	for _, c := range StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCSV reads source to target mappings, one per row:
+//
+//	source,target[,status[,preserve_query_string]]
+//
+// A header row is skipped when its first column is "source", "source_url"
+// or "from". Lines starting with # are comments. Rows without a status use
+// defaultStatus.
+func ParseCSV(r io.Reader, defaultStatus int) ([]cloudflare.BulkRedirect, error) {
+	if !ValidStatus(defaultStatus) {
+		return nil, fmt.Errorf("invalid status code %d, expected one of 301, 302, 307 or 308", defaultStatus)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	var redirects []cloudflare.BulkRedirect
+	seen := make(map[string]int)
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if first && isHeader(record[0]) {
+			continue
+		}
+		if len(record) == 1 && record[0] == "" {
+			continue
+		}
+
+		redirect, err := parseRecord(record, defaultStatus)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[redirect.SourceURL]; ok {
+			return nil, fmt.Errorf("line %d: %s is already redirected on line %d", line, redirect.SourceURL, prev)
+		}
+		seen[redirect.SourceURL] = line
+		redirects = append(redirects, redirect)
+	}
+
+	if len(redirects) == 0 {
+		return nil, fmt.Errorf("no redirects found")
+	}
+	return redirects, nil
+}
+
+func isHeader(column string) bool {
+	switch strings.ToLower(column) {
+	case "source", "source_url", "from":
+		return true
+	}
+	return false
+}
+
+func parseRecord(record []string, defaultStatus int) (cloudflare.BulkRedirect, error) {
+	if len(record) < 2 {
+		return cloudflare.BulkRedirect{}, fmt.Errorf("expected source and target columns")
+	}
+
+	redirect := cloudflare.BulkRedirect{
+		SourceURL:  record[0],
+		TargetURL:  record[1],
+		StatusCode: defaultStatus,
+	}
+	if err := ValidateSource(redirect.SourceURL); err != nil {
+		return redirect, err
+	}
+	if err := utils.ValidateURL(redirect.TargetURL); err != nil {
+		return redirect, fmt.Errorf("target: %w", err)
+	}
+
+	if len(record) > 2 && record[2] != "" {
+		code, err := strconv.Atoi(record[2])
+		if err != nil || !ValidStatus(code) {
+			return redirect, fmt.Errorf("invalid status code %q, expected one of 301, 302, 307 or 308", record[2])
+		}
+		redirect.StatusCode = code
+	}
+
+	if len(record) > 3 && record[3] != "" {
+		preserve, err := strconv.ParseBool(record[3])
+		if err != nil {
+			return redirect, fmt.Errorf("invalid preserve_query_string %q, expected true or false", record[3])
+		}
+		redirect.PreserveQueryString = preserve
+	}
+
+	return redirect, nil
+}
+
+// Validate checks a single mapping, such as one entered by hand
+func Validate(r cloudflare.BulkRedirect) error {
+	if err := ValidateSource(r.SourceURL); err != nil {
+		return err
+	}
+	if err := utils.ValidateURL(r.TargetURL); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	if !ValidStatus(r.StatusCode) {
+		return fmt.Errorf("invalid status code %d, expected one of 301, 302, 307 or 308", r.StatusCode)
+	}
+	return nil
+}
+
+// ValidateSource checks a source URL: a host and optional path, with or
+// without a scheme, and no query string
+func ValidateSource(source string) error {
+	switch {
+	case source == "":
+		return fmt.Errorf("source is required")
+	case strings.ContainsAny(source, " \t"):
+		return fmt.Errorf("source %q contains whitespace", source)
+	case strings.Contains(source, "?"):
+		return fmt.Errorf("source %q must not include a query string", source)
+	}
+
+	host, _ := splitSource(source)
+	if host == "" {
+		return fmt.Errorf("source %q must include a host", source)
+	}
+	return nil
+}
+
+// splitSource returns the host and path of a source URL
+func splitSource(source string) (string, string) {
+	rest := source
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+3:]
+	}
+	host, path, found := strings.Cut(rest, "/")
+	if !found {
+		return host, ""
+	}
+	return host, "/" + path
+}
+
+// Expression turns a source URL into a Single Redirect match expression. A
+// source with a scheme matches the full URI; one without matches the host
+// and path on either scheme.
+func Expression(source string) string {
+	if strings.Contains(source, "://") {
+		return fmt.Sprintf("http.request.full_uri eq %s", quote(source))
+	}
+
+	host, path := splitSource(source)
+	if path == "" {
+		return fmt.Sprintf("http.host eq %s", quote(host))
+	}
+	return fmt.Sprintf("(http.host eq %s and http.request.uri.path eq %s)", quote(host), quote(path))
+}
+
+var (
+	fullURIPattern  = regexp.MustCompile(`^http\.request\.full_uri eq "((?:[^"\\]|\\.)*)"$`)
+	hostPattern     = regexp.MustCompile(`^http\.host eq "((?:[^"\\]|\\.)*)"$`)
+	hostPathPattern = regexp.MustCompile(`^\(http\.host eq "((?:[^"\\]|\\.)*)" and http\.request\.uri\.path eq "((?:[^"\\]|\\.)*)"\)$`)
+)
+
+// Source reverses Expression, returning the source URL of an expression
+// built by it. Other expressions return false.
+func Source(expression string) (string, bool) {
+	expression = strings.TrimSpace(expression)
+	if m := fullURIPattern.FindStringSubmatch(expression); m != nil {
+		return unquote(m[1]), true
+	}
+	if m := hostPattern.FindStringSubmatch(expression); m != nil {
+		return unquote(m[1]), true
+	}
+	if m := hostPathPattern.FindStringSubmatch(expression); m != nil {
+		return unquote(m[1]) + unquote(m[2]), true
+	}
+	return "", false
+}
+
+// RuleSource returns the source URL of a rule built from one, or its
+// expression otherwise
+func RuleSource(rule cloudflare.RedirectRule) string {
+	if source, ok := Source(rule.Expression); ok {
+		return source
+	}
+	return rule.Expression
+}
+
+// RuleTarget returns the static target of a rule, or its target expression
+func RuleTarget(rule cloudflare.RedirectRule) string {
+	if rule.TargetURL != "" {
+		return rule.TargetURL
+	}
+	return rule.TargetExpression
+}
+
+// Rule turns a mapping into a Single Redirect rule
+func Rule(r cloudflare.BulkRedirect) cloudflare.RedirectRule {
+	status := r.StatusCode
+	if status == 0 {
+		status = DefaultStatus
+	}
+	return cloudflare.RedirectRule{
+		Description:         "Redirect " + r.SourceURL,
+		Expression:          Expression(r.SourceURL),
+		TargetURL:           r.TargetURL,
+		StatusCode:          status,
+		PreserveQueryString: r.PreserveQueryString,
+		Enabled:             true,
+	}
+}
+
+// quote returns s as a rules language string literal
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func unquote(s string) string {
+	s = strings.ReplaceAll(s, `\"`, `"`)
+	return strings.ReplaceAll(s, `\\`, `\`)
+}
+
+// Merge matches mappings against a zone's Single Redirects by source. It
+// returns rules to create for new sources and existing rules updated to
+// their mapping where the target, status or query string handling differ.
+// Rules that already match are left out, so importing twice is a no-op.
+func Merge(existing []cloudflare.RedirectRule, mappings []cloudflare.BulkRedirect) (create, update []cloudflare.RedirectRule) {
+	bySource := make(map[string]cloudflare.RedirectRule)
+	for _, rule := range existing {
+		if source, ok := Source(rule.Expression); ok {
+			bySource[source] = rule
+		}
+	}
+
+	for _, m := range mappings {
+		want := Rule(m)
+		rule, ok := bySource[m.SourceURL]
+		if !ok {
+			create = append(create, want)
+			continue
+		}
+		if rule.TargetURL == want.TargetURL && rule.TargetExpression == "" &&
+			rule.StatusCode == want.StatusCode && rule.PreserveQueryString == want.PreserveQueryString {
+			continue
+		}
+		rule.TargetURL = want.TargetURL
+		rule.TargetExpression = ""
+		rule.StatusCode = want.StatusCode
+		rule.PreserveQueryString = want.PreserveQueryString
+		update = append(update, rule)
+	}
+	return create, update
+}
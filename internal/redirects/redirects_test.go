@@ -0,0 +1,113 @@
+package redirects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := `source,target,status,preserve_query_string
+# moved in the 2024 redesign
+example.com/old,https://example.com/new
+https://example.com/blog,https://blog.example.com/,308,true
+
+shop.example.com,https://example.com/shop,302
+`
+	redirects, err := ParseCSV(strings.NewReader(input), DefaultStatus)
+	require.NoError(t, err)
+	require.Len(t, redirects, 3)
+
+	assert.Equal(t, "example.com/old", redirects[0].SourceURL)
+	assert.Equal(t, "https://example.com/new", redirects[0].TargetURL)
+	assert.Equal(t, 301, redirects[0].StatusCode)
+	assert.False(t, redirects[0].PreserveQueryString)
+
+	assert.Equal(t, 308, redirects[1].StatusCode)
+	assert.True(t, redirects[1].PreserveQueryString)
+	assert.Equal(t, 302, redirects[2].StatusCode)
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing target", "example.com/old\n", "line 1: expected source and target columns"},
+		{"relative target", "example.com/old,/new\n", "line 1: target: URL must include scheme"},
+		{"bad status", "a.com,https://b.com\nc.com,https://d.com,200\n", `line 2: invalid status code "200"`},
+		{"query string", "example.com/old?x=1,https://example.com/\n", "must not include a query string"},
+		{"duplicate", "a.com/x,https://b.com\na.com/x,https://c.com\n", "line 2: a.com/x is already redirected on line 1"},
+		{"empty", "source,target\n", "no redirects found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.input), DefaultStatus)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	_, err := ParseCSV(strings.NewReader("a.com,https://b.com\n"), 200)
+	assert.Error(t, err)
+}
+
+func TestExpressionRoundTrip(t *testing.T) {
+	tests := []struct {
+		source     string
+		expression string
+	}{
+		{"https://example.com/old", `http.request.full_uri eq "https://example.com/old"`},
+		{"example.com", `http.host eq "example.com"`},
+		{"example.com/old", `(http.host eq "example.com" and http.request.uri.path eq "/old")`},
+		{`example.com/a"b`, `(http.host eq "example.com" and http.request.uri.path eq "/a\"b")`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expression, Expression(tt.source))
+
+		source, ok := Source(tt.expression)
+		assert.True(t, ok)
+		assert.Equal(t, tt.source, source)
+	}
+
+	_, ok := Source(`http.request.uri.path wildcard "/old/*"`)
+	assert.False(t, ok)
+}
+
+func TestRule(t *testing.T) {
+	rule := Rule(cloudflare.BulkRedirect{SourceURL: "example.com/old", TargetURL: "https://example.com/new"})
+	assert.Equal(t, "Redirect example.com/old", rule.Description)
+	assert.Equal(t, `(http.host eq "example.com" and http.request.uri.path eq "/old")`, rule.Expression)
+	assert.Equal(t, "https://example.com/new", rule.TargetURL)
+	assert.Equal(t, DefaultStatus, rule.StatusCode)
+	assert.True(t, rule.Enabled)
+}
+
+func TestMerge(t *testing.T) {
+	existing := []cloudflare.RedirectRule{
+		{ID: "r1", Expression: Expression("example.com/same"), TargetURL: "https://example.com/a", StatusCode: 301, Enabled: true},
+		{ID: "r2", Expression: Expression("example.com/moved"), TargetURL: "https://example.com/b", StatusCode: 301, Enabled: false},
+		{ID: "r3", Expression: `http.request.uri.path wildcard "/x/*"`, TargetURL: "https://example.com/x"},
+	}
+	mappings := []cloudflare.BulkRedirect{
+		{SourceURL: "example.com/same", TargetURL: "https://example.com/a", StatusCode: 301},
+		{SourceURL: "example.com/moved", TargetURL: "https://example.com/c", StatusCode: 302},
+		{SourceURL: "example.com/new", TargetURL: "https://example.com/d"},
+	}
+
+	create, update := Merge(existing, mappings)
+
+	require.Len(t, create, 1)
+	assert.Equal(t, Expression("example.com/new"), create[0].Expression)
+	assert.Equal(t, DefaultStatus, create[0].StatusCode)
+
+	require.Len(t, update, 1)
+	assert.Equal(t, "r2", update[0].ID)
+	assert.Equal(t, "https://example.com/c", update[0].TargetURL)
+	assert.Equal(t, 302, update[0].StatusCode)
+	assert.False(t, update[0].Enabled, "updates keep the rule's enabled state")
+}
@@ -0,0 +1,29 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// lockQueue takes an exclusive OS lock on the lock file next to the queue
+// file, so a scheduler and an interactive session can't interleave their
+// load and save. It blocks until the lock is free and returns a function
+// that releases it.
+func lockQueue(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create config dir: %w", err)
+	}
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("lock schedule queue: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock schedule queue: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
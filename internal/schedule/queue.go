@@ -0,0 +1,304 @@
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+)
+
+// queueFile is the name of the queue file in the config directory
+const queueFile = "schedule.json"
+
+// Job is a purge waiting to run, either once at a fixed time or repeatedly
+// on a cron schedule
+type Job struct {
+	ID        string        `json:"id"`
+	Account   string        `json:"account,omitempty"`
+	Spec      purgejob.Spec `json:"spec"`
+	At        *time.Time    `json:"at,omitempty"`
+	Cron      string        `json:"cron,omitempty"`
+	NextRun   time.Time     `json:"next_run"`
+	LastRun   *time.Time    `json:"last_run,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	// Failures counts the failed runs of a one-off job that is waiting to
+	// be retried, and LastError holds the error of the latest one
+	Failures  int    `json:"failures,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	// ClaimedUntil is set while a scheduler runs the job, so no other
+	// scheduler runs it too. A claim left by a scheduler that died ends
+	// on its own.
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+}
+
+// NewOnce creates a job that runs once at the given time
+func NewOnce(account string, spec purgejob.Spec, at time.Time) (Job, error) {
+	if err := spec.Validate(); err != nil {
+		return Job{}, err
+	}
+	if !at.After(time.Now()) {
+		return Job{}, fmt.Errorf("scheduled time %s is in the past", at.Format(time.RFC3339))
+	}
+
+	return Job{
+		ID:        newID(),
+		Account:   account,
+		Spec:      spec,
+		At:        &at,
+		NextRun:   at,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewRecurring creates a job that runs on a cron schedule
+func NewRecurring(account string, spec purgejob.Spec, expr string) (Job, error) {
+	if err := spec.Validate(); err != nil {
+		return Job{}, err
+	}
+	cron, err := ParseCron(expr)
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := time.Now()
+	next := cron.Next(now)
+	if next.IsZero() {
+		return Job{}, fmt.Errorf("cron expression %q never matches", expr)
+	}
+
+	return Job{
+		ID:        newID(),
+		Account:   account,
+		Spec:      spec,
+		Cron:      strings.TrimSpace(expr),
+		NextRun:   next,
+		CreatedAt: now,
+	}, nil
+}
+
+// Due reports whether the job should run at now
+func (j Job) Due(now time.Time) bool {
+	return !j.NextRun.After(now) && !j.Claimed(now)
+}
+
+// Claimed reports whether a scheduler is running the job at now
+func (j Job) Claimed(now time.Time) bool {
+	return j.ClaimedUntil != nil && j.ClaimedUntil.After(now)
+}
+
+// Recurring reports whether the job runs on a cron schedule
+func (j Job) Recurring() bool {
+	return j.Cron != ""
+}
+
+// Describe returns a short human readable description of when the job runs
+func (j Job) Describe() string {
+	if j.Recurring() {
+		return fmt.Sprintf("cron %q", j.Cron)
+	}
+	if j.Failures > 0 {
+		return fmt.Sprintf("once, retry %d of %d", j.Failures, MaxFailures-1)
+	}
+	return "once"
+}
+
+// Advance records a run at now and moves NextRun forward. It returns false
+// when the job has no further runs and should be removed from the queue.
+func (j *Job) Advance(now time.Time) bool {
+	j.LastRun = &now
+	if !j.Recurring() {
+		return false
+	}
+
+	cron, err := ParseCron(j.Cron)
+	if err != nil {
+		return false
+	}
+	j.NextRun = cron.Next(now)
+	return !j.NextRun.IsZero()
+}
+
+// ParseTime parses a scheduled time given as RFC 3339, "2006-01-02 15:04"
+// or "2006-01-02T15:04" in the local time zone, or as a duration from now
+// such as "+30m"
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if rel, ok := strings.CutPrefix(value, "+"); ok {
+		d, err := time.ParseDuration(rel)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q: %w", rel, err)
+		}
+		return time.Now().Add(d).Truncate(time.Second), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339, \"YYYY-MM-DD HH:MM\" or \"+30m\"", value)
+}
+
+// Queue is the file-backed list of scheduled jobs
+type Queue struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewQueue returns a queue stored at path
+func NewQueue(path string) *Queue {
+	return &Queue{path: path}
+}
+
+// DefaultQueue returns the queue stored in the config directory
+func DefaultQueue() (*Queue, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewQueue(filepath.Join(dir, queueFile)), nil
+}
+
+// Path returns the location of the queue file
+func (q *Queue) Path() string {
+	return q.path
+}
+
+// List returns the queued jobs ordered by their next run
+func (q *Queue) List() ([]Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.load()
+}
+
+// Add appends a job to the queue
+func (q *Queue) Add(job Job) error {
+	return q.Update(func(jobs []Job) []Job {
+		return append(jobs, job)
+	})
+}
+
+// Remove deletes the job with the given ID, which may be abbreviated to a
+// unique prefix
+func (q *Queue) Remove(id string) (Job, error) {
+	var removed Job
+	var findErr error
+
+	err := q.Update(func(jobs []Job) []Job {
+		idx := -1
+		for i, job := range jobs {
+			if strings.HasPrefix(job.ID, id) {
+				if idx >= 0 {
+					findErr = fmt.Errorf("job ID %q is ambiguous", id)
+					return jobs
+				}
+				idx = i
+			}
+		}
+		if idx < 0 {
+			findErr = fmt.Errorf("job not found: %s", id)
+			return jobs
+		}
+		removed = jobs[idx]
+		return append(jobs[:idx], jobs[idx+1:]...)
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	return removed, findErr
+}
+
+// Update loads the queue, applies fn and writes the result back. The
+// queue file is locked for the whole cycle, so updates from other cfctl
+// processes, such as a running scheduler, aren't lost.
+func (q *Queue) Update(fn func([]Job) []Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	unlock, err := lockQueue(q.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	jobs, err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.save(fn(jobs))
+}
+
+func (q *Queue) load() ([]Job, error) {
+	data, err := os.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schedule queue: %w", err)
+	}
+
+	var jobs []Job
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return nil, fmt.Errorf("parse schedule queue: %w", err)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].NextRun.Before(jobs[j].NextRun)
+	})
+	return jobs, nil
+}
+
+// save writes the queue atomically so a crash never leaves a truncated file
+func (q *Queue) save(jobs []Job) error {
+	if jobs == nil {
+		jobs = []Job{}
+	}
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode schedule queue: %w", err)
+	}
+
+	dir := filepath.Dir(q.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, queueFile+".*")
+	if err != nil {
+		return fmt.Errorf("write schedule queue: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write schedule queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write schedule queue: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		return fmt.Errorf("write schedule queue: %w", err)
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+)
+
+const (
+	// MaxFailures is how many times a one-off job may fail with a
+	// retryable error before it is dropped from the queue
+	MaxFailures = 5
+	// failureBackoff is how long a failed one-off job waits before its
+	// first retry; the wait doubles with each further failure
+	failureBackoff = 5 * time.Minute
+	// claimLease is how long a claim on a running job lasts. It outlasts
+	// any run with its retries, and lets the job run again if the
+	// scheduler that claimed it died.
+	claimLease = time.Hour
+)
+
+// RunFunc executes one due job
+type RunFunc func(ctx context.Context, job Job) error
+
+// RunDue executes every job that is due at now, one at a time, and then
+// advances or removes it. run is expected to retry transient errors itself.
+// A recurring job that fails waits for its next scheduled time; a one-off
+// job that fails with a retryable error is run again after a backoff, up
+// to MaxFailures times, and is only removed once it succeeds or can't be
+// retried. Each job is claimed in the queue before it runs, so a job
+// removed in the meantime doesn't run and two schedulers never run the same
+// job. It returns the number of jobs run.
+func RunDue(ctx context.Context, q *Queue, now time.Time, run RunFunc) (int, error) {
+	jobs, err := q.List()
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, job := range jobs {
+		if !job.Due(now) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ran, ctx.Err()
+		}
+
+		claimed, err := claim(q, job.ID, now)
+		if err != nil {
+			return ran, err
+		}
+		if claimed == nil {
+			// Removed, or taken by another scheduler, since it was listed
+			continue
+		}
+
+		runErr := run(ctx, *claimed)
+		ran++
+
+		// Re-read the queue so jobs added while this one ran are kept
+		if err := q.Update(func(current []Job) []Job {
+			return complete(current, job.ID, time.Now(), runErr)
+		}); err != nil {
+			return ran, err
+		}
+	}
+	return ran, nil
+}
+
+// claim marks the job with the given ID as running when it is still queued
+// and due, and returns it as claimed. It returns nil when there is nothing
+// to run.
+func claim(q *Queue, id string, now time.Time) (*Job, error) {
+	var claimed *Job
+	err := q.Update(func(jobs []Job) []Job {
+		for i := range jobs {
+			if jobs[i].ID != id || !jobs[i].Due(now) {
+				continue
+			}
+			until := now.Add(claimLease)
+			jobs[i].ClaimedUntil = &until
+			job := jobs[i]
+			claimed = &job
+		}
+		return jobs
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// complete records the outcome of a run of the job with the given ID. A
+// one-off job that failed with a retryable error is rescheduled with
+// backoff; otherwise the job is advanced and dropped when it has no
+// further runs.
+func complete(jobs []Job, id string, now time.Time, runErr error) []Job {
+	for i := range jobs {
+		if jobs[i].ID != id {
+			continue
+		}
+		jobs[i].ClaimedUntil = nil
+		if runErr != nil && !jobs[i].Recurring() && api.IsRetryable(runErr) && jobs[i].Failures+1 < MaxFailures {
+			jobs[i].LastRun = &now
+			jobs[i].Failures++
+			jobs[i].LastError = runErr.Error()
+			jobs[i].NextRun = now.Add(failureBackoff << (jobs[i].Failures - 1))
+			return jobs
+		}
+		if jobs[i].Advance(now) {
+			return jobs
+		}
+		return append(jobs[:i], jobs[i+1:]...)
+	}
+	return jobs
+}
@@ -0,0 +1,293 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, 3, 14, 10, 7, 30, 0, time.UTC) // a Saturday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 2 * * mon-fri", time.Date(2026, 3, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * jun *", time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2026, 3, 14, 10, 25, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 14, 11, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{"0 0 20 * sun", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, cron.Next(base), tt.expr)
+	}
+
+	never, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(base).IsZero())
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	got, err := ParseTime("2026-05-01T10:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), got.UTC())
+
+	got, err = ParseTime("2026-05-01 10:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 5, 1, 10, 30, 0, 0, time.Local), got)
+
+	got, err = ParseTime("+1h")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got, 2*time.Second)
+
+	_, err = ParseTime("tomorrow")
+	assert.Error(t, err)
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "schedule.json"))
+	spec := purgejob.Spec{Zone: "example.com", Tags: []string{"news"}}
+
+	jobs, err := q.List()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	_, err = NewOnce("", spec, time.Now().Add(-time.Minute))
+	assert.Error(t, err)
+	_, err = NewOnce("", purgejob.Spec{Zone: "example.com"}, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+
+	once, err := NewOnce("work", spec, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	recurring, err := NewRecurring("work", spec, "*/5 * * * *")
+	require.NoError(t, err)
+
+	require.NoError(t, q.Add(once))
+	require.NoError(t, q.Add(recurring))
+
+	jobs, err = q.List()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, recurring.ID, jobs[0].ID, "jobs are ordered by next run")
+
+	removed, err := q.Remove(once.ID[:6])
+	require.NoError(t, err)
+	assert.Equal(t, once.ID, removed.ID)
+
+	_, err = q.Remove("missing")
+	assert.Error(t, err)
+
+	jobs, err = q.List()
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+func TestQueueUpdatesFromSeparateQueues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+
+	// Separate queues share no mutex, as separate processes wouldn't, so
+	// only the file lock keeps their updates from overwriting each other
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := NewOnce("", spec, time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			assert.NoError(t, NewQueue(path).Add(job))
+		}()
+	}
+	wg.Wait()
+
+	jobs, err := NewQueue(path).List()
+	require.NoError(t, err)
+	assert.Len(t, jobs, 20)
+}
+
+func TestRunDue(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "schedule.json"))
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+
+	once, err := NewOnce("", spec, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	recurring, err := NewRecurring("", spec, "* * * * *")
+	require.NoError(t, err)
+	later, err := NewOnce("", spec, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	for _, job := range []Job{once, recurring, later} {
+		require.NoError(t, q.Add(job))
+	}
+
+	var ran []string
+	n, err := RunDue(context.Background(), q, time.Now().Add(2*time.Minute), func(ctx context.Context, job Job) error {
+		ran = append(ran, job.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.ElementsMatch(t, []string{once.ID, recurring.ID}, ran)
+
+	jobs, err := q.List()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2, "one-off job is removed after running")
+
+	for _, job := range jobs {
+		if job.ID == recurring.ID {
+			require.NotNil(t, job.LastRun)
+			assert.True(t, job.NextRun.After(*job.LastRun))
+		}
+	}
+}
+
+func TestRunDueRetriesFailedOnce(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "schedule.json"))
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+
+	once, err := NewOnce("", spec, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, q.Add(once))
+
+	failing := func(ctx context.Context, job Job) error {
+		return &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+	}
+
+	now := time.Now().Add(2 * time.Minute)
+	for i := 1; i < MaxFailures; i++ {
+		n, err := RunDue(context.Background(), q, now, failing)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		jobs, err := q.List()
+		require.NoError(t, err)
+		require.Len(t, jobs, 1, "failed one-off job is kept for a retry")
+		assert.Equal(t, i, jobs[0].Failures)
+		assert.Equal(t, "read tcp: connection reset", jobs[0].LastError)
+		assert.True(t, jobs[0].NextRun.After(now), "retry waits for the backoff")
+
+		// Not due again until the backoff has passed
+		n, err = RunDue(context.Background(), q, now, failing)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		now = jobs[0].NextRun
+	}
+
+	_, err = RunDue(context.Background(), q, now, failing)
+	require.NoError(t, err)
+	jobs, err := q.List()
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "job is dropped once its retries run out")
+}
+
+func TestRunDueDropsOnceOnPermanentError(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "schedule.json"))
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+
+	once, err := NewOnce("", spec, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, q.Add(once))
+
+	_, err = RunDue(context.Background(), q, time.Now().Add(2*time.Minute), func(ctx context.Context, job Job) error {
+		return api.ErrZoneNotFound
+	})
+	require.NoError(t, err)
+
+	jobs, err := q.List()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestRunDueSkipsJobRemovedWhileRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	q := NewQueue(path)
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+
+	first, err := NewOnce("", spec, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	second, err := NewOnce("", spec, time.Now().Add(90*time.Second))
+	require.NoError(t, err)
+	require.NoError(t, q.Add(first))
+	require.NoError(t, q.Add(second))
+
+	var ran []string
+	n, err := RunDue(context.Background(), q, time.Now().Add(2*time.Minute), func(ctx context.Context, job Job) error {
+		ran = append(ran, job.ID)
+		if job.ID == first.ID {
+			// As `cfctl scheduler rm` from another process would
+			_, err := NewQueue(path).Remove(second.ID)
+			require.NoError(t, err)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{first.ID}, ran)
+}
+
+func TestRunDueRunsJobOnceAcrossSchedulers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	spec := purgejob.Spec{Zone: "example.com", URLs: []string{"https://example.com/"}}
+	for i := 0; i < 5; i++ {
+		job, err := NewOnce("", spec, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, NewQueue(path).Add(job))
+	}
+
+	var mu sync.Mutex
+	runs := map[string]int{}
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := RunDue(context.Background(), NewQueue(path), time.Now().Add(2*time.Minute), func(ctx context.Context, job Job) error {
+				mu.Lock()
+				runs[job.ID]++
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, runs, 5)
+	for id, n := range runs {
+		assert.Equal(t, 1, n, id)
+	}
+}
+
+func TestClaimedJobIsNotDue(t *testing.T) {
+	now := time.Now()
+	until := now.Add(time.Minute)
+	job := Job{NextRun: now.Add(-time.Minute), ClaimedUntil: &until}
+	assert.False(t, job.Due(now))
+	// A claim left by a scheduler that died runs out
+	assert.True(t, job.Due(until.Add(time.Second)))
+}
@@ -0,0 +1,72 @@
+// Package session creates API clients from the stored accounts and picks
+// the Cloudflare account account-level calls work on, so the CLI and the
+// TUI resolve both the same way.
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// AmbiguousAccountError is returned by AccountID when no Cloudflare account
+// is pinned and the credential can access more than one
+type AmbiguousAccountError struct {
+	Account string // name of the stored account
+	Count   int    // number of Cloudflare accounts it can access
+}
+
+func (e *AmbiguousAccountError) Error() string {
+	return fmt.Sprintf("%s can access %d Cloudflare accounts", e.Account, e.Count)
+}
+
+// NewClient creates an API client using the credential of a stored account
+// from the system keyring
+func NewClient(cfg *config.Config, account *cloudflare.Account) (*api.Client, error) {
+	credential, err := config.GetCredential(account.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientCfg api.ClientConfig
+	if account.AuthType == "token" {
+		clientCfg = api.ClientConfig{
+			APIToken: credential,
+			Timeout:  cfg.API.Timeout,
+			Retries:  cfg.API.Retries,
+		}
+	} else {
+		clientCfg = api.ClientConfig{
+			APIKey:  credential,
+			Email:   account.Email,
+			Timeout: cfg.API.Timeout,
+			Retries: cfg.API.Retries,
+		}
+	}
+
+	return api.NewClient(clientCfg)
+}
+
+// AccountID picks the Cloudflare account to work on: override when set,
+// the one pinned to the stored account, or the only one the credential can
+// access. Otherwise it returns an *AmbiguousAccountError.
+func AccountID(ctx context.Context, client *api.Client, account *cloudflare.Account, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	if account.AccountID != "" {
+		return account.AccountID, nil
+	}
+
+	accounts, err := client.ListAccounts(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(accounts) == 1 {
+		return accounts[0].ID, nil
+	}
+	return "", &AmbiguousAccountError{Account: account.Name, Count: len(accounts)}
+}
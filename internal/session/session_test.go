@@ -0,0 +1,62 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountsClient returns a client whose account listing holds ids
+func accountsClient(t *testing.T, ids ...string) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts", r.URL.Path)
+		accounts := make([]map[string]string, len(ids))
+		for i, id := range ids {
+			accounts[i] = map[string]string{"id": id, "name": "Account " + id}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":     true,
+			"result":      accounts,
+			"result_info": map[string]int{"total_pages": 1},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(api.ClientConfig{APIToken: "test-token", BaseURL: server.URL + "/", Retries: 1})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAccountID(t *testing.T) {
+	ctx := context.Background()
+	account := &cloudflare.Account{Name: "work"}
+
+	id, err := AccountID(ctx, accountsClient(t, "a1", "a2"), account, "flag-id")
+	require.NoError(t, err)
+	assert.Equal(t, "flag-id", id)
+
+	id, err = AccountID(ctx, accountsClient(t, "a1"), account, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a1", id)
+
+	_, err = AccountID(ctx, accountsClient(t, "a1", "a2"), account, "")
+	var ambiguous *AmbiguousAccountError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, 2, ambiguous.Count)
+	assert.EqualError(t, err, "work can access 2 Cloudflare accounts")
+
+	pinned := &cloudflare.Account{Name: "work", AccountID: "pinned"}
+	id, err = AccountID(ctx, accountsClient(t, "a1", "a2"), pinned, "")
+	require.NoError(t, err)
+	assert.Equal(t, "pinned", id)
+}
@@ -0,0 +1,89 @@
+package sitemap
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/utils"
+)
+
+// lastModLayouts are the W3C datetime forms allowed in <lastmod>
+var lastModLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// Filter selects a subset of sitemap entries
+type Filter struct {
+	// Since keeps entries modified at or after this time. Entries without
+	// a <lastmod> are kept, since they may have changed.
+	Since time.Time
+	// Globs keeps entries whose URL path matches at least one pattern.
+	// "*" matches within a path segment and "**" across segments.
+	Globs []string
+}
+
+// Apply returns the entries matching the filter
+func (f Filter) Apply(entries []Entry) ([]Entry, error) {
+	patterns := make([]*regexp.Regexp, len(f.Globs))
+	for i, g := range f.Globs {
+		if !strings.HasPrefix(g, "/") {
+			g = "/" + g
+		}
+		re, err := utils.CompileGlob(g)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path glob %q: %w", g, err)
+		}
+		patterns[i] = re
+	}
+
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if !f.Since.IsZero() && e.LastMod != "" {
+			modified, err := ParseLastMod(e.LastMod)
+			if err == nil && modified.Before(f.Since) {
+				continue
+			}
+		}
+
+		if len(patterns) > 0 && !matchesAny(patterns, e.Loc) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// ParseLastMod parses a <lastmod> value
+func ParseLastMod(value string) (time.Time, error) {
+	for _, layout := range lastModLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid lastmod %q", value)
+}
+
+func matchesAny(patterns []*regexp.Regexp, loc string) bool {
+	path := loc
+	if u, err := url.Parse(loc); err == nil {
+		path = u.Path
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	for _, re := range patterns {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxDepth limits how deep nested sitemap indexes are followed
+const maxDepth = 3
+
+// MaxSize is the largest sitemap document read, before and after gzip
+// decompression. The sitemap protocol caps sitemaps at 50 MB uncompressed.
+const MaxSize = 50 << 20
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Entry is a single <url> entry of a sitemap
+type Entry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type urlSet struct {
+	URLs []Entry `xml:"url"`
+}
+
+type sitemapIndex struct {
+	Sitemaps []Entry `xml:"sitemap"`
+}
+
+// Load reads a sitemap (or sitemap index) from a URL or a local file and
+// returns all page entries, following nested sitemaps of an index. Gzipped
+// sitemaps are decompressed transparently.
+func Load(ctx context.Context, client *http.Client, source string) ([]Entry, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return load(ctx, client, source, 0)
+}
+
+func load(ctx context.Context, client *http.Client, source string, depth int) ([]Entry, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("sitemap index nested too deeply at %s", source)
+	}
+
+	body, err := open(ctx, client, source)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	entries, children, err := Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse sitemap %s: %w", source, err)
+	}
+
+	for _, child := range children {
+		loc, err := resolve(source, child.Loc)
+		if err != nil {
+			return nil, err
+		}
+		nested, err := load(ctx, client, loc, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, nested...)
+	}
+
+	return entries, nil
+}
+
+// open returns the raw sitemap document from a URL or local file
+func open(ctx context.Context, client *http.Client, source string) (io.ReadCloser, error) {
+	if !isRemote(source) {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("open sitemap: %w", err)
+		}
+		return f, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sitemap: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sitemap: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetch sitemap %s: unexpected status %s", source, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// resolve works out where a child sitemap of parent is. Children of a
+// remote index are resolved against its URL and must be http(s) URLs
+// themselves, so a remote index can never point at local files. Children of
+// a local index may be URLs or paths relative to it.
+func resolve(parent, loc string) (string, error) {
+	if isRemote(parent) {
+		base, err := url.Parse(parent)
+		if err != nil {
+			return "", fmt.Errorf("sitemap %s: %w", parent, err)
+		}
+		ref, err := url.Parse(loc)
+		if err != nil {
+			return "", fmt.Errorf("sitemap %s: invalid child location %q: %w", parent, loc, err)
+		}
+		child := base.ResolveReference(ref)
+		if child.Scheme != "http" && child.Scheme != "https" {
+			return "", fmt.Errorf("sitemap %s: child location %q is not an http(s) URL", parent, loc)
+		}
+		return child.String(), nil
+	}
+
+	if isRemote(loc) || filepath.IsAbs(loc) {
+		return loc, nil
+	}
+	return filepath.Join(filepath.Dir(parent), loc), nil
+}
+
+func isRemote(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// Parse decodes a sitemap document. It returns page entries for a <urlset>
+// and child sitemap entries for a <sitemapindex>.
+func Parse(r io.Reader) (entries []Entry, children []Entry, err error) {
+	data, err := readLimited(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sitemaps are often served as .xml.gz
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		if data, err = readLimited(zr); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch root {
+	case "urlset":
+		var set urlSet
+		if err := xml.Unmarshal(data, &set); err != nil {
+			return nil, nil, err
+		}
+		return trimEntries(set.URLs), nil, nil
+	case "sitemapindex":
+		var index sitemapIndex
+		if err := xml.Unmarshal(data, &index); err != nil {
+			return nil, nil, err
+		}
+		return nil, trimEntries(index.Sitemaps), nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected root element <%s>", root)
+	}
+}
+
+// readLimited reads at most MaxSize bytes, failing on anything larger so a
+// huge document or a gzip bomb can't exhaust memory
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxSize {
+		return nil, fmt.Errorf("sitemap is larger than %d MB", MaxSize>>20)
+	}
+	return data, nil
+}
+
+// URLs returns the locations of the given entries
+func URLs(entries []Entry) []string {
+	urls := make([]string, len(entries))
+	for i, e := range entries {
+		urls[i] = e.Loc
+	}
+	return urls
+}
+
+func rootElement(data []byte) (string, error) {
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return "", fmt.Errorf("empty document")
+			}
+			return "", err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func trimEntries(entries []Entry) []Entry {
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		e.Loc = strings.TrimSpace(e.Loc)
+		e.LastMod = strings.TrimSpace(e.LastMod)
+		if e.Loc != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pagesXML = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc> https://example.com/ </loc><lastmod>2024-05-01</lastmod></url>
+  <url><loc>https://example.com/blog/post-1</loc><lastmod>2024-03-01T10:00:00+00:00</lastmod></url>
+  <url><loc>https://example.com/blog/2024/post-2</loc></url>
+</urlset>`
+
+func gzipped(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestLoadIndex(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>` + server.URL + `/pages.xml.gz</loc></sitemap></sitemapindex>`))
+		case "/pages.xml.gz":
+			_, _ = w.Write(gzipped(t, pagesXML))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	entries, err := Load(context.Background(), server.Client(), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://example.com/",
+		"https://example.com/blog/post-1",
+		"https://example.com/blog/2024/post-2",
+	}, URLs(entries))
+
+	_, err = Load(context.Background(), server.Client(), server.URL+"/missing.xml")
+	assert.Error(t, err)
+}
+
+func TestLoadLocalFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pages.xml"), []byte(pagesXML), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.xml"),
+		[]byte(`<sitemapindex><sitemap><loc>pages.xml</loc></sitemap></sitemapindex>`), 0o644))
+
+	entries, err := Load(context.Background(), nil, filepath.Join(dir, "index.xml"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestLoadIndexResolvesRelativeChildren(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemaps/index.xml":
+			_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>/pages.xml</loc></sitemap></sitemapindex>`))
+		case "/pages.xml":
+			_, _ = w.Write([]byte(pagesXML))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	entries, err := Load(context.Background(), server.Client(), server.URL+"/sitemaps/index.xml")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestLoadRemoteIndexRejectsLocalChildren(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "pages.xml")
+	require.NoError(t, os.WriteFile(secret, []byte(pagesXML), 0o644))
+
+	for _, loc := range []string{"file://" + secret, "ftp://example.com/pages.xml"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>` + loc + `</loc></sitemap></sitemapindex>`))
+		}))
+
+		_, err := Load(context.Background(), server.Client(), server.URL+"/sitemap.xml")
+		assert.ErrorContains(t, err, "not an http(s) URL", loc)
+		server.Close()
+	}
+
+	// A path is resolved against the index URL, never opened from disk
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sitemap.xml" {
+			_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>` + secret + `</loc></sitemap></sitemapindex>`))
+			return
+		}
+		assert.Equal(t, secret, r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	_, err := Load(context.Background(), server.Client(), server.URL+"/sitemap.xml")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestParseRejectsGzipBomb(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(bytes.Repeat([]byte(" "), MaxSize+1))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	_, _, err = Parse(&buf)
+	assert.ErrorContains(t, err, "larger than")
+}
+
+func TestFilter(t *testing.T) {
+	entries, _, err := Parse(bytes.NewReader([]byte(pagesXML)))
+	require.NoError(t, err)
+
+	since := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	filtered, err := Filter{Since: since}.Apply(entries)
+	require.NoError(t, err)
+	// The entry without lastmod is kept
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/blog/2024/post-2"}, URLs(filtered))
+
+	filtered, err = Filter{Globs: []string{"/blog/*"}}.Apply(entries)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/blog/post-1"}, URLs(filtered))
+
+	filtered, err = Filter{Globs: []string{"blog/**"}}.Apply(entries)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2)
+}
@@ -0,0 +1,305 @@
+package targets
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Extensions lists the file types Load understands
+var Extensions = []string{".txt", ".csv", ".json"}
+
+// maxFileSize limits the size of files loaded as purge targets
+const maxFileSize = 10 << 20
+
+// Columns returns the selectable columns of a file: the header row of a CSV
+// file or the keys of a JSON array of objects. Other files have no columns.
+func Columns(path string) ([]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open targets file: %w", err)
+		}
+		defer f.Close()
+
+		r := newCSVReader(f)
+		header, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse CSV: %w", err)
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+		return header, nil
+
+	case ".json":
+		data, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var objects []map[string]interface{}
+		if json.Unmarshal(data, &objects) != nil {
+			return nil, nil
+		}
+		seen := make(map[string]bool)
+		var keys []string
+		for _, obj := range objects {
+			for k := range obj {
+				if !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+		}
+		sort.Strings(keys)
+		return keys, nil
+	}
+	return nil, nil
+}
+
+// Load reads purge targets from a .txt, .csv or .json file.
+//
+// Text files hold one target per line (or comma-separated); blank lines and
+// lines starting with # are skipped. For CSV files column selects the
+// column by header name or 1-based index; when it is empty the first column
+// is used and a header row is skipped if its first cell isn't a target. JSON
+// files may be an array of strings, an array of objects (column names the
+// field) or an object whose column key holds an array of strings.
+func Load(path, column string) ([]string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".txt", "":
+		return loadText(path)
+	case ".csv":
+		return loadCSV(path, column)
+	case ".json":
+		return loadJSON(path, column)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (use .txt, .csv or .json)", ext)
+	}
+}
+
+func readFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("open targets file: %w", err)
+	}
+	if info.Size() > maxFileSize {
+		return nil, fmt.Errorf("targets file is larger than %d MB", maxFileSize>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read targets file: %w", err)
+	}
+	return data, nil
+}
+
+func loadText(path string) ([]string, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, part := range strings.Split(line, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				targets = append(targets, part)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read targets file: %w", err)
+	}
+	return targets, nil
+}
+
+func newCSVReader(r io.Reader) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	return cr
+}
+
+func loadCSV(path, column string) ([]string, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := newCSVReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	index, skipHeader := 0, false
+	if column != "" {
+		var ok bool
+		if index, ok = columnIndex(records[0], column); !ok {
+			return nil, fmt.Errorf("CSV column %q not found", column)
+		}
+		// A column chosen by name implies a header row
+		_, err := strconv.Atoi(column)
+		skipHeader = err != nil
+	} else {
+		skipHeader = looksLikeHeader(records[0][0])
+	}
+	if skipHeader {
+		records = records[1:]
+	}
+
+	var targets []string
+	for _, record := range records {
+		if index < len(record) {
+			if value := strings.TrimSpace(record[index]); value != "" {
+				targets = append(targets, value)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// columnIndex resolves a column given by header name or 1-based index
+func columnIndex(header []string, column string) (int, bool) {
+	if n, err := strconv.Atoi(column); err == nil {
+		return n - 1, n >= 1
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// looksLikeHeader guesses whether a first CSV cell is a column name rather
+// than a target
+func looksLikeHeader(cell string) bool {
+	cell = strings.ToLower(strings.TrimSpace(cell))
+	switch cell {
+	case "url", "urls", "host", "hosts", "hostname", "hostnames", "tag", "tags", "prefix", "prefixes", "target", "targets":
+		return true
+	}
+	return false
+}
+
+func loadJSON(path, column string) ([]string, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs []string
+	if json.Unmarshal(data, &strs) == nil {
+		return nonEmpty(strs), nil
+	}
+
+	var objects []map[string]interface{}
+	if json.Unmarshal(data, &objects) == nil {
+		if column == "" {
+			return nil, fmt.Errorf("choose a field of the JSON objects")
+		}
+		var targets []string
+		for _, obj := range objects {
+			if s, ok := obj[column].(string); ok {
+				targets = append(targets, s)
+			}
+		}
+		return nonEmpty(targets), nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+
+	keys := []string{column}
+	if column == "" {
+		// Use the only array in the object, e.g. {"urls": [...]}
+		keys = keys[:0]
+		for k := range object {
+			keys = append(keys, k)
+		}
+	}
+
+	var found []string
+	for _, k := range keys {
+		var values []string
+		if json.Unmarshal(object[k], &values) == nil && len(values) > 0 {
+			if len(found) > 0 {
+				return nil, fmt.Errorf("JSON object has several arrays; choose a key")
+			}
+			found = values
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no array of strings found in JSON file")
+	}
+	return nonEmpty(found), nil
+}
+
+func nonEmpty(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Invalid is a target that failed validation
+type Invalid struct {
+	Index int // 1-based position in the input
+	Value string
+	Err   error
+}
+
+// Report summarises the validation of a list of targets
+type Report struct {
+	Total      int
+	Valid      []string // unique valid targets, in input order
+	Invalid    []Invalid
+	Duplicates int
+}
+
+// Check validates each target, dropping duplicates
+func Check(values []string, validate func(string) error) Report {
+	report := Report{Total: len(values)}
+	seen := make(map[string]bool, len(values))
+
+	for i, v := range values {
+		if err := validate(v); err != nil {
+			report.Invalid = append(report.Invalid, Invalid{Index: i + 1, Value: v, Err: err})
+			continue
+		}
+		if seen[v] {
+			report.Duplicates++
+			continue
+		}
+		seen[v] = true
+		report.Valid = append(report.Valid, v)
+	}
+	return report
+}
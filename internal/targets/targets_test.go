@@ -0,0 +1,93 @@
+package targets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadText(t *testing.T) {
+	path := writeFile(t, "urls.txt", "# purge list\nhttps://example.com/a\n\nhttps://example.com/b, https://example.com/c\n")
+
+	got, err := Load(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}, got)
+}
+
+func TestLoadCSV(t *testing.T) {
+	path := writeFile(t, "pages.csv", "title,url\nHome,https://example.com/\nAbout,https://example.com/about\n")
+
+	columns, err := Columns(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "url"}, columns)
+
+	got, err := Load(path, "url")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/about"}, got)
+
+	got, err = Load(path, "2")
+	require.NoError(t, err)
+	assert.Len(t, got, 3, "numeric columns keep the first row")
+
+	_, err = Load(path, "missing")
+	assert.Error(t, err)
+
+	// Without a column the first one is used and a recognisable header skipped
+	path = writeFile(t, "tags.csv", "tag\nheader\nfooter\n")
+	got, err = Load(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"header", "footer"}, got)
+}
+
+func TestLoadJSON(t *testing.T) {
+	got, err := Load(writeFile(t, "a.json", `["a", " b ", ""]`), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	objects := writeFile(t, "b.json", `[{"url": "https://example.com/1", "id": 1}, {"url": "https://example.com/2"}]`)
+	columns, err := Columns(objects)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "url"}, columns)
+
+	got, err = Load(objects, "url")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/1", "https://example.com/2"}, got)
+
+	_, err = Load(objects, "")
+	assert.Error(t, err)
+
+	got, err = Load(writeFile(t, "c.json", `{"tags": ["x", "y"], "note": "hi"}`), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, got)
+
+	_, err = Load(writeFile(t, "d.json", `{"tags": ["x"], "hosts": ["y"]}`), "")
+	assert.Error(t, err)
+
+	_, err = Load(writeFile(t, "e.yaml", "a"), "")
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	report := Check([]string{"https://example.com/a", "nope", "https://example.com/a", "https://example.com/b"}, utils.ValidateURL)
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, report.Valid)
+	assert.Equal(t, 1, report.Duplicates)
+	require.Len(t, report.Invalid, 1)
+	assert.Equal(t, 2, report.Invalid[0].Index)
+
+	report = Check([]string{"x"}, func(string) error { return errors.New("bad") })
+	assert.Empty(t, report.Valid)
+}
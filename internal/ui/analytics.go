@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+type AnalyticsModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	spinner spinner.Model
+	window  int // index into api.AnalyticsWindows
+	stats   *cloudflare.CacheAnalytics
+	ctx     context.Context    // context of the latest load
+	cancel  context.CancelFunc // aborts the in-flight request
+	loading bool
+	err     error
+	width   int
+	height  int
+}
+
+type analyticsLoadedMsg struct {
+	window api.AnalyticsWindow
+	stats  *cloudflare.CacheAnalytics
+	err    error
+}
+
+func NewAnalyticsModel(cfg *config.Config, zone cloudflare.Zone) AnalyticsModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return AnalyticsModel{
+		config:  cfg,
+		zone:    zone,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		loading: true,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m AnalyticsModel) Init() tea.Cmd {
+	return tea.Batch(m.loadStats(m.ctx, api.AnalyticsWindows[m.window]), m.spinner.Tick)
+}
+
+func (m AnalyticsModel) loadStats(ctx context.Context, window api.AnalyticsWindow) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return analyticsLoadedMsg{window: window, err: err}
+		}
+
+		stats, err := client.GetCacheAnalytics(ctx, m.zone.ID, window)
+		if err != nil {
+			return analyticsLoadedMsg{window: window, err: err}
+		}
+
+		return analyticsLoadedMsg{window: window, stats: stats}
+	}
+}
+
+func (m AnalyticsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case analyticsLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		// Ignore responses for a window the user already switched away from
+		if msg.window != api.AnalyticsWindows[m.window] {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		m.stats = msg.stats
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "esc", "q":
+			cancelRequest(m.cancel)
+			model := NewZoneMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "tab", "right", "l":
+			return m.selectWindow((m.window + 1) % len(api.AnalyticsWindows))
+		case "shift+tab", "left", "h":
+			return m.selectWindow((m.window + len(api.AnalyticsWindows) - 1) % len(api.AnalyticsWindows))
+		case "1", "2", "3":
+			return m.selectWindow(int(msg.String()[0] - '1'))
+		case "r":
+			return m.selectWindow(m.window)
+		}
+
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// selectWindow aborts any load still running and starts one for the window
+func (m AnalyticsModel) selectWindow(index int) (tea.Model, tea.Cmd) {
+	cancelRequest(m.cancel)
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.window = index
+	m.loading = true
+	m.err = nil
+	return m, tea.Batch(m.loadStats(m.ctx, api.AnalyticsWindows[index]), m.spinner.Tick)
+}
+
+func (m AnalyticsModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("📊", "Cache Analytics", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	// Window tabs
+	tabs := make([]string, len(api.AnalyticsWindows))
+	for i, w := range api.AnalyticsWindows {
+		label := fmt.Sprintf(" %d:%s ", i+1, w)
+		if i == m.window {
+			tabs[i] = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Underline(true).Render(label)
+		} else {
+			tabs[i] = lipgloss.NewStyle().Foreground(MutedColor).Render(label)
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+
+	var body string
+	switch {
+	case m.loading:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " Loading analytics..."))
+	case m.err != nil:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ErrorColor).
+			Padding(1, 2).
+			Width(min(m.width-14, 60)).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("✗ Error Loading Analytics"),
+				"",
+				lipgloss.NewStyle().Foreground(MutedColor).Render(m.err.Error()),
+			))
+	case m.stats != nil:
+		body = m.renderStats(dividerWidth)
+	}
+
+	footer := MakeFooter([]KeyHint{
+		{Key: "Tab/1-3", Description: "Window", IsAction: true},
+		{Key: "r", Description: "Refresh", IsAction: false},
+		{Key: "Esc", Description: "Back", IsAction: false},
+	})
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		tabBar,
+		"",
+		body,
+		"",
+		divider,
+		footer,
+	)
+
+	containerWidth := min(m.width-6, 70)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+func (m AnalyticsModel) renderStats(width int) string {
+	s := m.stats
+	if s.Requests == 0 {
+		return lipgloss.NewStyle().Foreground(MutedColor).Render("No traffic recorded in this window.")
+	}
+
+	label := lipgloss.NewStyle().Foreground(MutedColor).Width(18)
+	value := lipgloss.NewStyle().Foreground(TextColor).Bold(true)
+
+	byteRatio := 0.0
+	if s.Bytes > 0 {
+		byteRatio = float64(s.CachedBytes) / float64(s.Bytes)
+	}
+
+	barWidth := width - 30
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	summary := lipgloss.JoinVertical(
+		lipgloss.Left,
+		label.Render("Cache hit ratio")+Bar(s.HitRatio, barWidth, SuccessColor)+" "+value.Render(fmt.Sprintf("%.1f%%", s.HitRatio*100)),
+		label.Render("Bandwidth saved")+Bar(byteRatio, barWidth, AccentColor)+" "+value.Render(fmt.Sprintf("%.1f%%", byteRatio*100)),
+		"",
+		label.Render("Requests")+value.Render(fmt.Sprintf("%d", s.Requests)),
+		label.Render("Served from cache")+value.Render(fmt.Sprintf("%d", s.CachedRequests)),
+		label.Render("Bandwidth")+value.Render(utils.FormatBytes(s.Bytes)),
+		label.Render("Cached bandwidth")+value.Render(utils.FormatBytes(s.CachedBytes)),
+	)
+
+	requests := make([]float64, len(s.Points))
+	ratios := make([]float64, len(s.Points))
+	for i, p := range s.Points {
+		requests[i] = float64(p.Requests)
+		if p.Requests > 0 {
+			ratios[i] = float64(p.CachedRequests) / float64(p.Requests)
+		}
+	}
+
+	sparkWidth := width - 18
+	charts := lipgloss.JoinVertical(
+		lipgloss.Left,
+		label.Render("Requests")+Sparkline(requests, sparkWidth, PrimaryColor),
+		label.Render("Hit ratio")+Sparkline(ratios, sparkWidth, SuccessColor),
+		lipgloss.NewStyle().Foreground(MutedColor).Render(fmt.Sprintf(
+			"%s → %s (UTC)", s.Since.Format("Jan 02 15:04"), s.Until.Format("Jan 02 15:04"))),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, summary, "", charts)
+}
@@ -0,0 +1,718 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/cacherules"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+type CacheRuleItem struct {
+	rule     cloudflare.CacheRule
+	position int
+}
+
+func (i CacheRuleItem) Title() string {
+	name := i.rule.Description
+	if name == "" {
+		name = "(unnamed rule)"
+	}
+	state := "●"
+	if !i.rule.Enabled {
+		state = "○"
+	}
+	return fmt.Sprintf("%d. %s %s", i.position, state, name)
+}
+
+func (i CacheRuleItem) Description() string {
+	return summarizeCacheSettings(i.rule.ActionParameters) + " | " + i.rule.Expression
+}
+
+func (i CacheRuleItem) FilterValue() string {
+	return i.rule.Description + " " + i.rule.Expression
+}
+
+// Editor field indexes
+const (
+	ruleFieldDescription = iota
+	ruleFieldExpression
+	ruleFieldCache
+	ruleFieldEdgeTTL
+	ruleFieldBrowserTTL
+	ruleFieldEnabled
+)
+
+type CacheRulesModel struct {
+	config     *config.Config
+	zone       cloudflare.Zone
+	list       list.Model
+	spinner    spinner.Model
+	rules      []cloudflare.CacheRule
+	inputs     []textinput.Model
+	focusIndex int
+	editing    *cloudflare.CacheRule // nil when creating a new rule
+	ctx        context.Context       // context of the latest load
+	cancel     context.CancelFunc    // aborts the in-flight request
+	step       int                   // 0: loading, 1: list, 2: edit, 3: confirm delete, 4: saving
+	saveFrom   int                   // step to return to if saving fails
+	status     string
+	err        error
+	width      int
+	height     int
+}
+
+type cacheRulesLoadedMsg struct {
+	rules []cloudflare.CacheRule
+	err   error
+}
+
+type cacheRuleSavedMsg struct {
+	status string
+	err    error
+}
+
+func NewCacheRulesModel(cfg *config.Config, zone cloudflare.Zone) CacheRulesModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := CacheRulesModel{
+		config:  cfg,
+		zone:    zone,
+		list:    l,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		step:    0,
+		width:   80,
+		height:  24,
+	}
+	m.initInputs()
+	return m
+}
+
+func (m *CacheRulesModel) initInputs() {
+	m.inputs = make([]textinput.Model, 6)
+
+	m.inputs[ruleFieldDescription] = textinput.New()
+	m.inputs[ruleFieldDescription].Prompt = "Description: "
+	m.inputs[ruleFieldDescription].Placeholder = "Cache static assets"
+	m.inputs[ruleFieldDescription].CharLimit = 200
+
+	m.inputs[ruleFieldExpression] = textinput.New()
+	m.inputs[ruleFieldExpression].Prompt = "Expression:  "
+	m.inputs[ruleFieldExpression].Placeholder = `http.request.uri.path wildcard "/static/*"`
+	m.inputs[ruleFieldExpression].CharLimit = 4096
+
+	m.inputs[ruleFieldCache] = textinput.New()
+	m.inputs[ruleFieldCache].Prompt = "Eligible (y/n): "
+	m.inputs[ruleFieldCache].Placeholder = "y"
+	m.inputs[ruleFieldCache].CharLimit = 3
+
+	m.inputs[ruleFieldEdgeTTL] = textinput.New()
+	m.inputs[ruleFieldEdgeTTL].Prompt = "Edge TTL (s): "
+	m.inputs[ruleFieldEdgeTTL].Placeholder = "blank = not overridden"
+	m.inputs[ruleFieldEdgeTTL].CharLimit = 10
+
+	m.inputs[ruleFieldBrowserTTL] = textinput.New()
+	m.inputs[ruleFieldBrowserTTL].Prompt = "Browser TTL (s): "
+	m.inputs[ruleFieldBrowserTTL].Placeholder = "blank = not overridden"
+	m.inputs[ruleFieldBrowserTTL].CharLimit = 10
+
+	m.inputs[ruleFieldEnabled] = textinput.New()
+	m.inputs[ruleFieldEnabled].Prompt = "Enabled (y/n): "
+	m.inputs[ruleFieldEnabled].Placeholder = "y"
+	m.inputs[ruleFieldEnabled].CharLimit = 3
+
+	for i := range m.inputs {
+		m.inputs[i].Width = 44
+	}
+}
+
+func (m CacheRulesModel) Init() tea.Cmd {
+	return tea.Batch(m.loadRules(m.ctx), m.spinner.Tick)
+}
+
+// reload starts a new load with its own cancelable context
+func (m *CacheRulesModel) reload() tea.Cmd {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = 0
+	m.err = nil
+	return tea.Batch(m.loadRules(m.ctx), m.spinner.Tick)
+}
+
+// startWrite gives a save, delete or move its own cancelable context
+func (m *CacheRulesModel) startWrite(saveFrom int) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.step = 4
+	m.saveFrom = saveFrom
+	m.err = nil
+	return ctx
+}
+
+func (m CacheRulesModel) loadRules(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return cacheRulesLoadedMsg{err: err}
+		}
+
+		rules, err := client.ListCacheRules(ctx, m.zone.ID)
+		if err != nil {
+			return cacheRulesLoadedMsg{err: err}
+		}
+
+		return cacheRulesLoadedMsg{rules: rules}
+	}
+}
+
+func (m CacheRulesModel) saveRule(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		rule, err := m.ruleFromInputs()
+		if err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+
+		if m.editing == nil {
+			if err := client.CreateCacheRule(ctx, m.zone.ID, rule); err != nil {
+				return cacheRuleSavedMsg{err: err}
+			}
+			return cacheRuleSavedMsg{status: "Rule created"}
+		}
+
+		if err := client.UpdateCacheRule(ctx, m.zone.ID, rule); err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+		return cacheRuleSavedMsg{status: "Rule updated"}
+	}
+}
+
+func (m CacheRulesModel) deleteRule(ctx context.Context, ruleID string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+
+		if err := client.DeleteCacheRule(ctx, m.zone.ID, ruleID); err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+		return cacheRuleSavedMsg{status: "Rule deleted"}
+	}
+}
+
+func (m CacheRulesModel) moveRule(ctx context.Context, ruleID string, position int) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+
+		if err := client.MoveCacheRule(ctx, m.zone.ID, ruleID, position); err != nil {
+			return cacheRuleSavedMsg{err: err}
+		}
+		return cacheRuleSavedMsg{status: fmt.Sprintf("Rule moved to position %d", position)}
+	}
+}
+
+// ruleFromInputs builds a rule from the editor fields, preserving any action
+// parameters the editor does not expose
+func (m CacheRulesModel) ruleFromInputs() (cloudflare.CacheRule, error) {
+	rule := cloudflare.CacheRule{Enabled: true}
+	params := map[string]interface{}{}
+	if m.editing != nil {
+		rule.ID = m.editing.ID
+		for k, v := range m.editing.ActionParameters {
+			params[k] = v
+		}
+	}
+
+	rule.Description = strings.TrimSpace(m.inputs[ruleFieldDescription].Value())
+	rule.Expression = strings.TrimSpace(m.inputs[ruleFieldExpression].Value())
+	if rule.Expression == "" {
+		return rule, fmt.Errorf("expression is required")
+	}
+
+	eligible, err := parseYesNo(m.inputs[ruleFieldCache].Value(), true)
+	if err != nil {
+		return rule, fmt.Errorf("eligible: %w", err)
+	}
+	params["cache"] = eligible
+
+	if err := cacherules.SetTTL(params, "edge_ttl", m.inputs[ruleFieldEdgeTTL].Value()); err != nil {
+		return rule, err
+	}
+	if err := cacherules.SetTTL(params, "browser_ttl", m.inputs[ruleFieldBrowserTTL].Value()); err != nil {
+		return rule, err
+	}
+
+	if !eligible {
+		delete(params, "edge_ttl")
+		delete(params, "browser_ttl")
+	}
+
+	rule.Enabled, err = parseYesNo(m.inputs[ruleFieldEnabled].Value(), true)
+	if err != nil {
+		return rule, fmt.Errorf("enabled: %w", err)
+	}
+
+	rule.ActionParameters = params
+	return rule, nil
+}
+
+// startEditing fills the editor with the given rule, or clears it for a new rule
+func (m *CacheRulesModel) startEditing(rule *cloudflare.CacheRule) tea.Cmd {
+	m.editing = rule
+	m.err = nil
+	m.status = ""
+	for i := range m.inputs {
+		m.inputs[i].SetValue("")
+	}
+
+	if rule != nil {
+		m.inputs[ruleFieldDescription].SetValue(rule.Description)
+		m.inputs[ruleFieldExpression].SetValue(rule.Expression)
+		m.inputs[ruleFieldCache].SetValue(formatYesNo(cacheEligible(rule.ActionParameters)))
+		if ttl, ok := cacherules.TTLSeconds(rule.ActionParameters, "edge_ttl"); ok {
+			m.inputs[ruleFieldEdgeTTL].SetValue(strconv.Itoa(ttl))
+		}
+		if ttl, ok := cacherules.TTLSeconds(rule.ActionParameters, "browser_ttl"); ok {
+			m.inputs[ruleFieldBrowserTTL].SetValue(strconv.Itoa(ttl))
+		}
+		m.inputs[ruleFieldEnabled].SetValue(formatYesNo(rule.Enabled))
+	}
+
+	m.step = 2
+	m.focusIndex = 0
+	return m.updateFocus()
+}
+
+func (m *CacheRulesModel) updateFocus() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := range m.inputs {
+		if i == m.focusIndex {
+			cmds[i] = m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m CacheRulesModel) selectedRule() (*cloudflare.CacheRule, int) {
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return nil, 0
+	}
+	item := selected.(CacheRuleItem)
+	rule := item.rule
+	return &rule, item.position
+}
+
+func (m CacheRulesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-14, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case cacheRulesLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.step = 1
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+
+		m.rules = msg.rules
+		items := make([]list.Item, len(msg.rules))
+		for i, rule := range msg.rules {
+			items[i] = CacheRuleItem{rule: rule, position: i + 1}
+		}
+		index := m.list.Index()
+		m.list.SetItems(items)
+		if index < len(items) {
+			m.list.Select(index)
+		}
+		return m, nil
+
+	case cacheRuleSavedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			// Return to the editor so the user can fix the input
+			m.step = m.saveFrom
+			if m.step == 2 {
+				return m, m.updateFocus()
+			}
+			return m, nil
+		}
+		m.status = msg.status
+		return m, m.reload()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0, 4:
+			if msg.String() != "esc" {
+				return m, nil
+			}
+			cancelRequest(m.cancel)
+			if m.step == 4 {
+				m.step = m.saveFrom
+				m.err = errChangeCanceled
+				if m.step == 2 {
+					return m, m.updateFocus()
+				}
+				return m, nil
+			}
+			return m.back()
+
+		case 1:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "n":
+				return m, m.startEditing(nil)
+			case "enter", "e":
+				if rule, _ := m.selectedRule(); rule != nil {
+					return m, m.startEditing(rule)
+				}
+				return m, nil
+			case "d", "delete":
+				if rule, _ := m.selectedRule(); rule != nil {
+					m.step = 3
+					m.err = nil
+				}
+				return m, nil
+			case "r":
+				return m, m.reload()
+			case "K", "shift+up":
+				if rule, pos := m.selectedRule(); rule != nil && pos > 1 {
+					ctx := m.startWrite(1)
+					m.list.Select(pos - 2)
+					return m, tea.Batch(m.moveRule(ctx, rule.ID, pos-1), m.spinner.Tick)
+				}
+				return m, nil
+			case "J", "shift+down":
+				if rule, pos := m.selectedRule(); rule != nil && pos < len(m.rules) {
+					ctx := m.startWrite(1)
+					m.list.Select(pos)
+					return m, tea.Batch(m.moveRule(ctx, rule.ID, pos+1), m.spinner.Tick)
+				}
+				return m, nil
+			}
+
+		case 2:
+			switch msg.String() {
+			case "esc":
+				m.step = 1
+				m.err = nil
+				return m, nil
+			case "ctrl+s":
+				if _, err := m.ruleFromInputs(); err != nil {
+					m.err = err
+					return m, nil
+				}
+				ctx := m.startWrite(2)
+				return m, tea.Batch(m.saveRule(ctx), m.spinner.Tick)
+			case "tab", "down", "enter":
+				m.focusIndex = (m.focusIndex + 1) % len(m.inputs)
+				return m, m.updateFocus()
+			case "shift+tab", "up":
+				m.focusIndex--
+				if m.focusIndex < 0 {
+					m.focusIndex = len(m.inputs) - 1
+				}
+				return m, m.updateFocus()
+			}
+
+			var cmd tea.Cmd
+			m.inputs[m.focusIndex], cmd = m.inputs[m.focusIndex].Update(msg)
+			return m, cmd
+
+		case 3:
+			switch msg.String() {
+			case "y", "Y":
+				if rule, _ := m.selectedRule(); rule != nil {
+					ctx := m.startWrite(1)
+					return m, tea.Batch(m.deleteRule(ctx, rule.ID), m.spinner.Tick)
+				}
+				m.step = 1
+				return m, nil
+			case "n", "N", "esc":
+				m.step = 1
+				return m, nil
+			}
+			return m, nil
+		}
+
+	default:
+		if m.step == 0 || m.step == 4 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.step == 1 {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m CacheRulesModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m CacheRulesModel) View() string {
+	// Responsive sizing
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("📜", "Cache Rules", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().
+			Foreground(ErrorColor).
+			Render("✗ " + m.err.Error())
+	}
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0, 4:
+		label := "Loading cache rules..."
+		if m.step == 4 {
+			label = "Saving changes..."
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " " + label))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case 1:
+		if len(m.rules) == 0 && m.err == nil {
+			body = lipgloss.NewStyle().Foreground(MutedColor).Render("No cache rules configured. Press 'n' to create one.")
+		} else {
+			body = m.list.View()
+		}
+
+		var status string
+		if m.status != "" {
+			status = lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + m.status)
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, body, "", status, errorMsg)
+
+		footerHints = []KeyHint{
+			{Key: "n", Description: "New", IsAction: true},
+			{Key: "Enter", Description: "Edit", IsAction: false},
+			{Key: "d", Description: "Delete", IsAction: false},
+			{Key: "K/J", Description: "Move", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 2:
+		heading := "New Cache Rule"
+		if m.editing != nil {
+			heading = "Edit Cache Rule"
+		}
+
+		fields := make([]string, len(m.inputs))
+		for i := range m.inputs {
+			style := InputStyle
+			if i == m.focusIndex {
+				style = FocusedInputStyle
+			}
+			fields[i] = style.Width(min(m.width-14, 64)).Render(m.inputs[i].View())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(heading),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, fields...),
+			"",
+			errorMsg,
+		)
+
+		footerHints = []KeyHint{
+			{Key: "Ctrl+S", Description: "Save", IsAction: true},
+			{Key: "Tab", Description: "Next Field", IsAction: false},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 3:
+		rule, _ := m.selectedRule()
+		name := ""
+		if rule != nil {
+			name = rule.Description
+			if name == "" {
+				name = rule.Expression
+			}
+		}
+
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ErrorColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("⚠ Delete this cache rule?"),
+				"",
+				lipgloss.NewStyle().Foreground(TextColor).Render(name),
+			))
+
+		footerHints = []KeyHint{
+			{Key: "Y", Description: "Delete", IsAction: true},
+			{Key: "N", Description: "Cancel", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// summarizeCacheSettings renders the most relevant cache settings of a rule
+func summarizeCacheSettings(params map[string]interface{}) string {
+	if !cacheEligible(params) {
+		return "Bypass cache"
+	}
+
+	parts := []string{"Eligible"}
+	if ttl, ok := cacherules.TTLSeconds(params, "edge_ttl"); ok {
+		parts = append(parts, fmt.Sprintf("edge %ds", ttl))
+	}
+	if ttl, ok := cacherules.TTLSeconds(params, "browser_ttl"); ok {
+		parts = append(parts, fmt.Sprintf("browser %ds", ttl))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func cacheEligible(params map[string]interface{}) bool {
+	eligible, ok := params["cache"].(bool)
+	return !ok || eligible
+}
+
+func parseYesNo(value string, fallback bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return fallback, nil
+	case "y", "yes", "true":
+		return true, nil
+	case "n", "no", "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected y or n")
+}
+
+func formatYesNo(b bool) string {
+	if b {
+		return "y"
+	}
+	return "n"
+}
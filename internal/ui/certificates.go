@@ -0,0 +1,379 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/certs"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// certExpiryWarning is how close to expiry a certificate is highlighted
+const certExpiryWarning = 30 * 24 * time.Hour
+
+type CertificatePackItem struct {
+	pack cloudflare.CertificatePack
+	now  time.Time
+}
+
+func (i CertificatePackItem) Title() string {
+	state := "●"
+	if i.pack.Status != "active" {
+		state = "○"
+	}
+	return fmt.Sprintf("%s %s (%s)", state, i.pack.Type, i.pack.Status)
+}
+
+func (i CertificatePackItem) Description() string {
+	expires := "not issued"
+	if e := i.pack.Expires(); !e.IsZero() {
+		expires = fmt.Sprintf("expires %s (%d days)", e.Local().Format("2006-01-02"), certs.DaysLeft(e, i.now))
+		if e.Sub(i.now) < certExpiryWarning {
+			expires = "⚠ " + expires
+		}
+	}
+	return i.pack.Issuer() + " | " + expires + " | " + strings.Join(i.pack.Hosts, ", ")
+}
+
+func (i CertificatePackItem) FilterValue() string { return strings.Join(i.pack.Hosts, " ") }
+
+// CertificatesModel shows the SSL/TLS settings and edge certificate packs
+// of a zone
+type CertificatesModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	list    list.Model
+	spinner spinner.Model
+	ssl     *cloudflare.SSLSettings
+	ctx     context.Context    // context of the latest load
+	cancel  context.CancelFunc // aborts the in-flight request
+	step    int                // 0: loading, 1: list, 2: pack details
+	err     error
+	width   int
+	height  int
+}
+
+type certificatesLoadedMsg struct {
+	ssl   *cloudflare.SSLSettings
+	packs []cloudflare.CertificatePack
+	err   error
+}
+
+func NewCertificatesModel(cfg *config.Config, zone cloudflare.Zone) CertificatesModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return CertificatesModel{
+		config:  cfg,
+		zone:    zone,
+		list:    l,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m CertificatesModel) Init() tea.Cmd {
+	return tea.Batch(m.load(m.ctx), m.spinner.Tick)
+}
+
+// reload starts a new load with its own cancelable context
+func (m *CertificatesModel) reload() tea.Cmd {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = 0
+	m.err = nil
+	return tea.Batch(m.load(m.ctx), m.spinner.Tick)
+}
+
+func (m CertificatesModel) load(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return certificatesLoadedMsg{err: err}
+		}
+
+		ssl, err := client.GetSSLSettings(ctx, m.zone.ID)
+		if err != nil {
+			return certificatesLoadedMsg{err: err}
+		}
+		packs, err := client.ListCertificatePacks(ctx, m.zone.ID)
+		if err != nil {
+			return certificatesLoadedMsg{ssl: ssl, err: err}
+		}
+		certs.SortByExpiry(packs)
+		return certificatesLoadedMsg{ssl: ssl, packs: packs}
+	}
+}
+
+func (m CertificatesModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m CertificatesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-18, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case certificatesLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.step = 1
+		m.err = msg.err
+		m.ssl = msg.ssl
+
+		now := time.Now()
+		items := make([]list.Item, len(msg.packs))
+		for i, pack := range msg.packs {
+			items[i] = CertificatePackItem{pack: pack, now: now}
+		}
+		m.list.SetItems(items)
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.step == 0 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			if msg.String() == "esc" {
+				cancelRequest(m.cancel)
+				return m.back()
+			}
+			return m, nil
+
+		case 1:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "r":
+				return m, m.reload()
+			case "enter":
+				if _, ok := m.list.SelectedItem().(CertificatePackItem); ok {
+					m.step = 2
+				}
+				return m, nil
+			}
+
+		case 2:
+			switch msg.String() {
+			case "esc", "q", "enter":
+				m.step = 1
+			}
+			return m, nil
+		}
+	}
+
+	if m.step == 1 {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// renderSSL summarises the zone's SSL/TLS settings
+func (m CertificatesModel) renderSSL() string {
+	if m.ssl == nil {
+		return ""
+	}
+
+	label := lipgloss.NewStyle().Foreground(MutedColor)
+	value := lipgloss.NewStyle().Foreground(TextColor).Bold(true)
+	row := func(name, v string) string {
+		return label.Render(fmt.Sprintf("%-20s", name)) + value.Render(v)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		row("SSL mode", certs.SSLModeLabel(m.ssl.Mode)),
+		row("Minimum TLS", m.ssl.MinTLSVersion),
+		row("TLS 1.3", m.ssl.TLS13),
+		row("Always Use HTTPS", onOff(m.ssl.AlwaysUseHTTPS)),
+		row("HTTPS Rewrites", onOff(m.ssl.AutomaticHTTPSRewrites)),
+	)
+}
+
+// onOff renders a toggle setting the way the dashboard does
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// renderPack shows every certificate of the selected pack
+func (m CertificatesModel) renderPack(width int) string {
+	item, ok := m.list.SelectedItem().(CertificatePackItem)
+	if !ok {
+		return ""
+	}
+	pack := item.pack
+
+	muted := lipgloss.NewStyle().Foreground(MutedColor)
+	rows := []string{
+		lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(fmt.Sprintf("%s pack (%s)", pack.Type, pack.Status)),
+		muted.Render("ID: " + pack.ID),
+		muted.Width(width).Render("Hosts: " + strings.Join(pack.Hosts, ", ")),
+	}
+	if pack.CertificateAuthority != "" {
+		rows = append(rows, muted.Render("Certificate authority: "+pack.CertificateAuthority))
+	}
+	if len(pack.Certificates) == 0 {
+		rows = append(rows, "", muted.Render("No certificate has been issued yet"))
+	}
+	for _, cert := range pack.Certificates {
+		expires := "unknown expiry"
+		if !cert.ExpiresOn.IsZero() {
+			expires = fmt.Sprintf("expires %s (%d days)", cert.ExpiresOn.Local().Format("2006-01-02"), certs.DaysLeft(cert.ExpiresOn, item.now))
+		}
+		rows = append(rows,
+			"",
+			lipgloss.NewStyle().Foreground(TextColor).Render(fmt.Sprintf("%s, %s, %s", cert.Issuer, cert.Signature, cert.Status)),
+			muted.Render(expires),
+		)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m CertificatesModel) View() string {
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🔒", "SSL/TLS Certificates", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case 0:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " Loading certificates..."))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case 1:
+		var packs string
+		switch {
+		case len(m.list.Items()) > 0:
+			packs = m.list.View()
+		case m.err == nil:
+			packs = lipgloss.NewStyle().Foreground(MutedColor).Render("No edge certificates for this zone.")
+		}
+
+		var errorMsg string
+		if m.err != nil {
+			errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ " + m.err.Error())
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, m.renderSSL(), "", packs, errorMsg)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Details", IsAction: true},
+			{Key: "r", Description: "Refresh", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 2:
+		body = m.renderPack(dividerWidth)
+		footerHints = []KeyHint{{Key: "Esc", Description: "Back", IsAction: false}}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
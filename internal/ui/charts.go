@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters. When there
+// are more values than width, neighbouring values are averaged together.
+func Sparkline(values []float64, width int, color lipgloss.Color) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	values = resample(values, width)
+
+	maxVal := 0.0
+	for _, v := range values {
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxVal > 0 {
+			idx = int(v / maxVal * float64(len(sparkBlocks)-1))
+		}
+		sb.WriteRune(sparkBlocks[idx])
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Render(sb.String())
+}
+
+// Bar renders a horizontal bar filled to ratio (0..1) of width
+func Bar(ratio float64, width int, color lipgloss.Color) string {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	filled := int(ratio*float64(width) + 0.5)
+	return lipgloss.NewStyle().Foreground(color).Render(repeatStr("█", filled)) +
+		lipgloss.NewStyle().Foreground(SubtleBorder).Render(repeatStr("░", width-filled))
+}
+
+// resample averages values into at most n buckets
+func resample(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		start := i * len(values) / n
+		end := (i + 1) * len(values) / n
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
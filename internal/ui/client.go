@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/session"
+)
+
+// newAPIClient builds an API client for the default account using the
+// credential stored in the system keyring
+func newAPIClient(cfg *config.Config) (*api.Client, error) {
+	account, err := cfg.GetDefaultAccount()
+	if err != nil {
+		return nil, err
+	}
+	return session.NewClient(cfg, account)
+}
+
+// defaultAccountID picks the Cloudflare account account-level screens work
+// on: the one pinned to the stored account, or the only one the credential
+// can access
+func defaultAccountID(ctx context.Context, cfg *config.Config, client *api.Client) (string, error) {
+	account, err := cfg.GetDefaultAccount()
+	if err != nil {
+		return "", err
+	}
+
+	accountID, err := session.AccountID(ctx, client, account, "")
+	var ambiguous *session.AmbiguousAccountError
+	if errors.As(err, &ambiguous) {
+		return "", fmt.Errorf("%w; pin one under Manage Domains → Accounts", err)
+	}
+	return accountID, err
+}
+
+// errChangeCanceled is shown when the user aborts a write. The request may
+// already have reached Cloudflare, so the change is not assumed undone.
+var errChangeCanceled = errors.New("request canceled; the change may still have been applied")
+
+// cancelRequest aborts a model's in-flight request. Models own the cancel
+// func of the context their request runs with and call this on Esc or
+// Ctrl+C; cancel may be nil when nothing was started.
+func cancelRequest(cancel context.CancelFunc) {
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// isCanceled reports whether err comes from a request the user aborted.
+// Results of such requests arrive after the model has moved on and are
+// dropped.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
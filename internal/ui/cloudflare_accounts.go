@@ -0,0 +1,309 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// CloudflareAccountItem is a Cloudflare account the stored credential can
+// access. The item with an empty ID stands for every account.
+type CloudflareAccountItem struct {
+	info    cloudflare.AccountInfo
+	current bool // the domain list is showing this account
+	pinned  bool // the stored account lists this one by default
+}
+
+func (i CloudflareAccountItem) Title() string {
+	prefix := "  "
+	if i.current {
+		prefix = "✓ "
+	}
+	title := prefix + i.info.Name
+	if i.pinned {
+		title += " (pinned)"
+	}
+	return title
+}
+
+func (i CloudflareAccountItem) Description() string {
+	if i.info.ID == "" {
+		return "Zones of every account"
+	}
+	return fmt.Sprintf("%s | %s", i.info.ID, i.info.Type)
+}
+
+func (i CloudflareAccountItem) FilterValue() string {
+	return i.info.Name + " " + i.info.ID
+}
+
+// CloudflareAccountModel picks the Cloudflare account the domain list shows
+// zones from. Enter filters the list for this session; p also pins the
+// account to the stored account.
+type CloudflareAccountModel struct {
+	config    *config.Config
+	list      list.Model
+	spinner   spinner.Model
+	accountID string          // account the domain list was showing
+	ctx       context.Context // aborts the account listing when the picker is left
+	cancel    context.CancelFunc
+	loading   bool
+	err       error
+	width     int
+	height    int
+}
+
+type cloudflareAccountsMsg struct {
+	accounts []cloudflare.AccountInfo
+	err      error
+}
+
+func NewCloudflareAccountModel(cfg *config.Config, accountID string) CloudflareAccountModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return CloudflareAccountModel{
+		config:    cfg,
+		list:      l,
+		spinner:   sp,
+		accountID: accountID,
+		ctx:       ctx,
+		cancel:    cancel,
+		loading:   true,
+		width:     80,
+		height:    24,
+	}
+}
+
+func (m CloudflareAccountModel) Init() tea.Cmd {
+	return tea.Batch(m.loadAccounts, m.spinner.Tick)
+}
+
+// loadAccounts fetches the Cloudflare accounts of the stored credential
+func (m CloudflareAccountModel) loadAccounts() tea.Msg {
+	client, err := newAPIClient(m.config)
+	if err != nil {
+		return cloudflareAccountsMsg{err: err}
+	}
+	accounts, err := client.ListAccounts(m.ctx)
+	return cloudflareAccountsMsg{accounts: accounts, err: err}
+}
+
+// pinnedID returns the Cloudflare account pinned to the stored account
+func (m CloudflareAccountModel) pinnedID() string {
+	if account, err := m.config.GetDefaultAccount(); err == nil {
+		return account.AccountID
+	}
+	return ""
+}
+
+// showDomains opens the domain list for a Cloudflare account
+func (m CloudflareAccountModel) showDomains(accountID string) (tea.Model, tea.Cmd) {
+	cancelRequest(m.cancel)
+	model := newDomainListForAccount(m.config, accountID)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return updated, updated.Init()
+}
+
+func (m CloudflareAccountModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 60)
+		listHeight := min(msg.Height-12, 12)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case cloudflareAccountsMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+
+		pinned := m.pinnedID()
+		all := cloudflare.AccountInfo{Name: "All accounts"}
+		items := []list.Item{CloudflareAccountItem{info: all, current: m.accountID == "", pinned: pinned == ""}}
+		selected := 0
+		for i, a := range msg.accounts {
+			items = append(items, CloudflareAccountItem{info: a, current: a.ID == m.accountID, pinned: a.ID == pinned})
+			if a.ID == m.accountID {
+				selected = i + 1
+			}
+		}
+		cmd := m.list.SetItems(items)
+		m.list.Select(selected)
+		return m, cmd
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+		if m.loading || m.err != nil {
+			if msg.String() == "esc" || msg.String() == "q" {
+				return m.showDomains(m.accountID)
+			}
+			return m, nil
+		}
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return m.showDomains(m.accountID)
+		case "enter":
+			if item, ok := m.list.SelectedItem().(CloudflareAccountItem); ok {
+				return m.showDomains(item.info.ID)
+			}
+		case "p":
+			if item, ok := m.list.SelectedItem().(CloudflareAccountItem); ok {
+				account, err := m.config.GetDefaultAccount()
+				if err == nil {
+					err = m.config.SetAccountID(account.Name, item.info.ID)
+				}
+				if err != nil {
+					m.err = fmt.Errorf("pin account: %w", err)
+					return m, nil
+				}
+				return m.showDomains(item.info.ID)
+			}
+		}
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m CloudflareAccountModel) View() string {
+	// Responsive sizing
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 25 {
+		dividerWidth = 25
+	}
+
+	title := MakeSectionHeader("🏢", "Cloudflare Accounts", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	var credentialBadge string
+	if account, err := m.config.GetDefaultAccount(); err == nil {
+		credentialBadge = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Credential: "),
+			InfoStatusBadge.Render(account.Name),
+		)
+	}
+
+	var body string
+	var footerHints []KeyHint
+	switch {
+	case m.loading:
+		body = lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+			fmt.Sprintf("%s Loading accounts...", m.spinner.View()))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case m.err != nil:
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("✗ Account Error"),
+			"",
+			lipgloss.NewStyle().Foreground(MutedColor).Width(dividerWidth).Render(m.err.Error()),
+		)
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	default:
+		body = m.list.View()
+		footerHints = []KeyHint{
+			{Key: "↑↓", Description: "Navigate", IsAction: false},
+			{Key: "Enter", Description: "Show zones", IsAction: true},
+			{Key: "p", Description: "Pin as default", IsAction: false},
+			{Key: "/", Description: "Filter", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		credentialBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-10, 68)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
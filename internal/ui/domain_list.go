@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/siyamsarker/cfctl/internal/api"
 	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
 	"github.com/siyamsarker/cfctl/pkg/cloudflare"
 )
 
@@ -31,25 +32,47 @@ func (i DomainItem) FilterValue() string {
 }
 
 type DomainListModel struct {
-	config  *config.Config
-	list    list.Model
-	spinner spinner.Model
-	zones   []cloudflare.Zone
-	loading bool
-	err     error
-	width   int
-	height  int
-	status  string
+	config     *config.Config
+	list       list.Model
+	spinner    spinner.Model
+	zones      []cloudflare.Zone
+	pages      [][]cloudflare.Zone // zones by page, filled as pages stream in
+	stream     <-chan api.ZonePage
+	totalPages int
+	accountID  string          // Cloudflare account zones are listed from, empty for all
+	ctx        context.Context // aborts the zone listing when the list is left
+	cancel     context.CancelFunc
+	loading    bool // no page has arrived yet
+	fetching   bool // pages are still streaming in
+	details    bool // show the detail pane of the highlighted zone
+	err        error
+	width      int
+	height     int
+	status     string
 }
 
-type zonesLoadedMsg struct {
-	zones []cloudflare.Zone
-	err   error
+type zonesStreamMsg struct {
+	stream <-chan api.ZonePage
+	err    error
 }
 
-type zonesTimeoutMsg struct{}
+type zonePageMsg struct {
+	page api.ZonePage
+	done bool // the stream is closed
+}
 
 func NewDomainListModel(cfg *config.Config) DomainListModel {
+	// Start from the Cloudflare account pinned to the stored account, if any
+	var accountID string
+	if account, err := cfg.GetDefaultAccount(); err == nil {
+		accountID = account.AccountID
+	}
+	return newDomainListForAccount(cfg, accountID)
+}
+
+// newDomainListForAccount creates a domain list showing the zones of one
+// Cloudflare account, or of every account when accountID is empty
+func newDomainListForAccount(cfg *config.Config, accountID string) DomainListModel {
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
 		Foreground(PrimaryColor).
@@ -76,74 +99,77 @@ func NewDomainListModel(cfg *config.Config) DomainListModel {
 	l.SetShowHelp(false)
 	l.SetShowPagination(false)
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return DomainListModel{
-		config:  cfg,
-		list:    l,
-		spinner: sp,
-		loading: true,
-		status:  "Authenticating...",
-		width:   80,
-		height:  24,
+		config:    cfg,
+		list:      l,
+		spinner:   sp,
+		accountID: accountID,
+		ctx:       ctx,
+		cancel:    cancel,
+		loading:   true,
+		status:    "Authenticating...",
+		width:     80,
+		height:    24,
 	}
 }
 
+// loadZones starts streaming the account's zones
 func (m DomainListModel) loadZones() tea.Msg {
-	// Get default account
-	account, err := m.config.GetDefaultAccount()
+	client, err := newAPIClient(m.config)
 	if err != nil {
-		return zonesLoadedMsg{err: err}
+		return zonesStreamMsg{err: err}
 	}
 
-	// Get credential
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return zonesLoadedMsg{err: fmt.Errorf("failed to get credentials: %w", err)}
-	}
-
-	// Create API client
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
-		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
-		}
-	}
+	// Each page has its own timeout; Esc cancels the whole listing
+	return zonesStreamMsg{stream: client.StreamZones(m.ctx, m.accountID)}
+}
 
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return zonesLoadedMsg{err: err}
+// waitForZonePage reads the next page of a zone stream
+func waitForZonePage(stream <-chan api.ZonePage) tea.Cmd {
+	return func() tea.Msg {
+		page, ok := <-stream
+		return zonePageMsg{page: page, done: !ok}
 	}
+}
 
-	// Verify token first (update status)
-	// We can't update visual status here directly without a cmd, but we can assume success if we proceed
-	// or we properly chain msgs. For now, we just proceed to ListZones which is the main blocking call.
+func (m DomainListModel) Init() tea.Cmd {
+	return tea.Batch(m.loadZones, m.spinner.Tick)
+}
 
-	// List zones
-	ctx := context.Background()
-	zones, err := client.ListZones(ctx)
-	if err != nil {
-		return zonesLoadedMsg{err: err}
+// addPage stores a page of zones and refreshes the list, keeping zones in
+// the order the API lists them
+func (m *DomainListModel) addPage(page api.ZonePage) tea.Cmd {
+	for len(m.pages) < page.Page {
+		m.pages = append(m.pages, nil)
+	}
+	m.pages[page.Page-1] = page.Zones
+	if page.TotalPages > 0 {
+		m.totalPages = page.TotalPages
 	}
 
-	return zonesLoadedMsg{zones: zones}
-}
+	m.zones = m.zones[:0]
+	for _, zones := range m.pages {
+		m.zones = append(m.zones, zones...)
+	}
 
-func (m DomainListModel) Init() tea.Cmd {
-	return tea.Batch(m.loadZones, m.zonesTimeout(), m.spinner.Tick)
+	items := make([]list.Item, len(m.zones))
+	for i, zone := range m.zones {
+		items[i] = DomainItem{zone: zone}
+	}
+	return m.list.SetItems(items)
 }
 
-func (m DomainListModel) zonesTimeout() tea.Cmd {
-	return tea.Tick(20*time.Second, func(time.Time) tea.Msg {
-		return zonesTimeoutMsg{}
-	})
+// pagesLoaded returns how many pages have arrived
+func (m DomainListModel) pagesLoaded() int {
+	n := 0
+	for _, zones := range m.pages {
+		if zones != nil {
+			n++
+		}
+	}
+	return n
 }
 
 func (m DomainListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -164,64 +190,100 @@ func (m DomainListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.SetHeight(listHeight)
 		return m, nil
 
-	case zonesLoadedMsg:
-		m.loading = false
+	case zonesStreamMsg:
 		if msg.err != nil {
+			m.loading = false
 			m.err = msg.err
 			return m, nil
 		}
+		m.stream = msg.stream
+		m.fetching = true
+		return m, waitForZonePage(m.stream)
 
-		m.zones = msg.zones
-		if len(m.zones) == 0 {
-			m.err = fmt.Errorf("no zones found. Ensure your API token has Zone.Zone.Read permission and access to at least one zone")
+	case zonePageMsg:
+		if msg.done {
+			m.fetching = false
+			m.loading = false
+			if len(m.zones) == 0 && m.err == nil && m.ctx.Err() == nil {
+				m.err = fmt.Errorf("no zones found. Ensure your API token has Zone.Zone.Read permission and access to at least one zone")
+			}
 			return m, nil
 		}
-		items := make([]list.Item, len(msg.zones))
-		for i, zone := range msg.zones {
-			items[i] = DomainItem{zone: zone}
+		if msg.page.Err != nil {
+			if !isCanceled(msg.page.Err) {
+				m.loading = false
+				m.err = msg.page.Err
+			}
+			return m, waitForZonePage(m.stream)
 		}
-		m.list.SetItems(items)
-		return m, nil
 
-	case zonesTimeoutMsg:
-		if m.loading {
-			m.loading = false
-			m.err = fmt.Errorf("timeout fetching zones. Check network connectivity and ensure your API token has Zone.Zone.Read permission")
-			return m, nil
-		}
-		return m, nil
+		m.loading = false
+		cmd := m.addPage(msg.page)
+		return m, tea.Batch(cmd, waitForZonePage(m.stream))
 
 	case tea.KeyMsg:
 		if m.loading {
-			if msg.String() == "esc" || msg.String() == "q" {
+			switch msg.String() {
+			case "esc", "q":
+				m.cancel()
 				model := NewMainMenuModel(m.config)
 				model.applySize(m.width, m.height)
 				return model, nil
+			case "ctrl+c":
+				m.cancel()
+				return m, tea.Quit
 			}
 			return m, nil
 		}
 
 		switch msg.String() {
 		case "esc", "q":
+			m.cancel()
 			model := NewMainMenuModel(m.config)
 			model.applySize(m.width, m.height)
 			return model, nil
+		case "ctrl+c":
+			m.cancel()
+			return m, tea.Quit
+		case "i":
+			if m.list.FilterState() != list.Filtering {
+				m.details = !m.details
+				return m, nil
+			}
+		case "n":
+			if m.list.FilterState() != list.Filtering {
+				m.cancel()
+				model := NewZoneCreateModel(m.config, m.accountID)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			}
+		case "a":
+			if m.list.FilterState() != list.Filtering {
+				m.cancel()
+				model, _ := NewCloudflareAccountModel(m.config, m.accountID).
+					Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+				return model, model.Init()
+			}
 		case "enter":
 			selected := m.list.SelectedItem()
 			if selected != nil {
+				// Pages still streaming in are no longer needed
+				m.cancel()
 				item := selected.(DomainItem)
-				model := NewPurgeMenuModel(m.config, item.zone)
+				model := NewZoneMenuModel(m.config, item.zone)
 				model.width = m.width
 				model.height = m.height
 				return model, nil
 			}
 		}
-	default:
-		if m.loading {
+	case spinner.TickMsg:
+		if m.loading || m.fetching {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
 		}
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -289,7 +351,7 @@ func (m DomainListModel) View() string {
 		)
 	}
 
-	if m.err != nil {
+	if m.err != nil && len(m.zones) == 0 {
 		errorCard := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(ErrorColor).
@@ -304,6 +366,8 @@ func (m DomainListModel) View() string {
 			)
 
 		footerHints := []KeyHint{
+			{Key: "n", Description: "Add zone", IsAction: false},
+			{Key: "a", Description: "Accounts", IsAction: false},
 			{Key: "Esc", Description: "Return to menu", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -349,35 +413,67 @@ func (m DomainListModel) View() string {
 			lipgloss.NewStyle().Foreground(MutedColor).Render("Account: "),
 			lipgloss.NewStyle().Foreground(TextColor).Bold(true).Render(account.Name),
 		)
+		if name := m.accountFilterName(); name != "" {
+			infoBadge = lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				infoBadge,
+				lipgloss.NewStyle().Foreground(MutedColor).Render(" › "),
+				lipgloss.NewStyle().Foreground(AccentColor).Render(utils.TruncateString(name, 24)),
+			)
+		}
+	}
+
+	detailsHint := "Details"
+	if m.details {
+		detailsHint = "Hide details"
 	}
 
 	// Modern footer
 	footerHints := []KeyHint{
 		{Key: "↑↓", Description: "Navigate", IsAction: false},
-		{Key: "Enter", Description: "Purge", IsAction: true},
+		{Key: "Enter", Description: "Open", IsAction: true},
+		{Key: "i", Description: detailsHint, IsAction: false},
+		{Key: "n", Description: "Add zone", IsAction: false},
+		{Key: "a", Description: "Accounts", IsAction: false},
 		{Key: "/", Description: "Filter", IsAction: false},
 		{Key: "Esc", Description: "Back", IsAction: false},
 	}
 	footer := MakeFooter(footerHints)
 
+	// Wide terminals show the detail pane beside the list; narrow ones
+	// show it in place of the list
+	body := m.list.View()
+	containerWidth := min(m.width-10, 68)
+	if m.details {
+		if selected, ok := m.list.SelectedItem().(DomainItem); ok {
+			if m.width >= 120 {
+				pane := renderZoneDetails(selected.zone, 52)
+				body = lipgloss.JoinHorizontal(lipgloss.Top, body, "  ", pane)
+				containerWidth = min(m.width-10, lipgloss.Width(body)+4)
+			} else {
+				body = renderZoneDetails(selected.zone, dividerWidth)
+			}
+		}
+	}
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
 		lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 		"",
 		infoBadge,
+		m.renderLoadStatus(dividerWidth),
 		"",
-		m.list.View(),
+		body,
 		"",
 		lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 		footer,
 	)
 
 	// Polished container
-	containerWidth := min(m.width-10, 68)
-	if containerWidth < 54 {
-		containerWidth = 54
-	}
 	container := lipgloss.NewStyle().
 		Width(containerWidth).
 		Padding(1, 2).
@@ -391,3 +487,94 @@ func (m DomainListModel) View() string {
 		container,
 	)
 }
+
+// accountFilterName names the Cloudflare account the list is limited to,
+// or returns "" when zones of every account are shown
+func (m DomainListModel) accountFilterName() string {
+	if m.accountID == "" {
+		return ""
+	}
+	for _, z := range m.zones {
+		if z.Account.ID == m.accountID && z.Account.Name != "" {
+			return z.Account.Name
+		}
+	}
+	return m.accountID
+}
+
+// renderLoadStatus shows the progress of pages still streaming in, or why
+// the list is incomplete
+func (m DomainListModel) renderLoadStatus(width int) string {
+	switch {
+	case m.err != nil:
+		return lipgloss.NewStyle().Foreground(ErrorColor).Render(
+			utils.TruncateString("✗ Some zones could not be loaded: "+m.err.Error(), width))
+	case m.fetching && m.totalPages > 0:
+		return lipgloss.NewStyle().Foreground(MutedColor).Render(fmt.Sprintf(
+			"%s Loaded %d of %d pages...", m.spinner.View(), m.pagesLoaded(), m.totalPages))
+	case m.fetching:
+		return lipgloss.NewStyle().Foreground(MutedColor).Render(m.spinner.View() + " Loading more zones...")
+	}
+	return ""
+}
+
+// renderZoneDetails shows the metadata of a zone as a bordered pane
+func renderZoneDetails(z cloudflare.Zone, width int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(MutedColor).Width(14)
+	valueStyle := lipgloss.NewStyle().Foreground(TextColor)
+	valueWidth := width - 18
+
+	row := func(label, value string) string {
+		if value == "" {
+			value = "—"
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			labelStyle.Render(label),
+			valueStyle.Render(utils.TruncateString(value, valueWidth)))
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	}
+
+	devMode := "off"
+	if z.DevelopmentModeOn() {
+		devMode = "on, " + (time.Duration(z.DevelopmentMode) * time.Second).String() + " left"
+	}
+
+	account := z.Account.Name
+	if account == "" {
+		account = z.Account.ID
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Render(utils.TruncateString(z.Name, width-4)),
+		"",
+		row("Status", zoneStatus(z)),
+		row("Plan", z.Plan.Name),
+		row("Type", z.Type),
+		row("Account", account),
+		row("Dev mode", devMode),
+		row("Registrar", z.OriginalRegistrar),
+		row("Created", formatTime(z.CreatedOn)),
+		row("Modified", formatTime(z.ModifiedOn)),
+		row("Activated", formatTime(z.ActivatedOn)),
+	}
+	for i, ns := range z.NameServers {
+		label := ""
+		if i == 0 {
+			label = "Name servers"
+		}
+		rows = append(rows, row(label, ns))
+	}
+	rows = append(rows, row("Zone ID", z.ID))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
@@ -0,0 +1,814 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/firewall"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// Firewall screen tabs
+const (
+	firewallTabRules = iota
+	firewallTabAccess
+)
+
+var firewallTabNames = []string{"Custom Rules", "IP Access Rules"}
+
+type FirewallRuleItem struct {
+	rule     cloudflare.FirewallRule
+	position int
+}
+
+func (i FirewallRuleItem) Title() string {
+	name := i.rule.Description
+	if name == "" {
+		name = "(unnamed rule)"
+	}
+	state := "●"
+	if !i.rule.Enabled {
+		state = "○"
+	}
+	return fmt.Sprintf("%d. %s %s", i.position, state, name)
+}
+
+func (i FirewallRuleItem) Description() string {
+	return i.rule.Action + " | " + i.rule.Expression
+}
+
+func (i FirewallRuleItem) FilterValue() string {
+	return i.rule.Description + " " + i.rule.Expression
+}
+
+type AccessRuleItem struct {
+	rule cloudflare.AccessRule
+}
+
+func (i AccessRuleItem) Title() string {
+	return fmt.Sprintf("%s %s", firewall.ModeLabel(i.rule.Mode), i.rule.Value)
+}
+
+func (i AccessRuleItem) Description() string {
+	desc := i.rule.Target
+	if i.rule.Scope != "" {
+		desc += " | " + i.rule.Scope
+	}
+	if i.rule.Notes != "" {
+		desc += " | " + i.rule.Notes
+	}
+	return desc
+}
+
+func (i AccessRuleItem) FilterValue() string { return i.rule.Value + " " + i.rule.Notes }
+
+// Editor field indexes
+const (
+	firewallFieldDescription = iota
+	firewallFieldExpression
+	firewallFieldAction
+	firewallFieldEnabled
+	firewallFieldValue
+	firewallFieldMode
+	firewallFieldNotes
+)
+
+// FirewallModel lists and edits the WAF custom rules and IP Access rules of
+// a zone, or of the account that owns it
+type FirewallModel struct {
+	config        *config.Config
+	zone          cloudflare.Zone
+	list          list.Model
+	spinner       spinner.Model
+	tab           int
+	account       bool // show account-level rules instead of the zone's
+	rules         []cloudflare.FirewallRule
+	accessRules   []cloudflare.AccessRule
+	inputs        []textinput.Model
+	focusIndex    int                      // index into editorFields()
+	editingRule   *cloudflare.FirewallRule // rule being edited, nil when creating
+	editingAccess *cloudflare.AccessRule   // access rule being edited, nil when creating
+	ctx           context.Context          // context of the latest load
+	cancel        context.CancelFunc       // aborts the in-flight request
+	step          int                      // 0: loading, 1: list, 2: edit, 3: confirm delete, 4: saving
+	saveFrom      int                      // step to return to if saving fails
+	status        string
+	err           error
+	width         int
+	height        int
+}
+
+type firewallLoadedMsg struct {
+	rules       []cloudflare.FirewallRule
+	accessRules []cloudflare.AccessRule
+	err         error
+}
+
+type firewallSavedMsg struct {
+	status string
+	err    error
+}
+
+func NewFirewallModel(cfg *config.Config, zone cloudflare.Zone) FirewallModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := FirewallModel{
+		config:  cfg,
+		zone:    zone,
+		list:    l,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		width:   80,
+		height:  24,
+	}
+	m.initInputs()
+	return m
+}
+
+func (m *FirewallModel) initInputs() {
+	m.inputs = make([]textinput.Model, 7)
+
+	m.inputs[firewallFieldDescription] = textinput.New()
+	m.inputs[firewallFieldDescription].Prompt = "Description: "
+	m.inputs[firewallFieldDescription].Placeholder = "Block bad bots"
+	m.inputs[firewallFieldDescription].CharLimit = 200
+
+	m.inputs[firewallFieldExpression] = textinput.New()
+	m.inputs[firewallFieldExpression].Prompt = "Expression:  "
+	m.inputs[firewallFieldExpression].Placeholder = `http.request.uri.path eq "/login"`
+	m.inputs[firewallFieldExpression].CharLimit = 4096
+
+	m.inputs[firewallFieldAction] = textinput.New()
+	m.inputs[firewallFieldAction].Prompt = "Action:      "
+	m.inputs[firewallFieldAction].Placeholder = "block, managed_challenge, log, skip..."
+	m.inputs[firewallFieldAction].CharLimit = 20
+
+	m.inputs[firewallFieldEnabled] = textinput.New()
+	m.inputs[firewallFieldEnabled].Prompt = "Enabled (y/n): "
+	m.inputs[firewallFieldEnabled].Placeholder = "y"
+	m.inputs[firewallFieldEnabled].CharLimit = 3
+
+	m.inputs[firewallFieldValue] = textinput.New()
+	m.inputs[firewallFieldValue].Prompt = "IP/CIDR/ASN/country: "
+	m.inputs[firewallFieldValue].Placeholder = "198.51.100.0/24"
+	m.inputs[firewallFieldValue].CharLimit = 64
+
+	m.inputs[firewallFieldMode] = textinput.New()
+	m.inputs[firewallFieldMode].Prompt = "Mode:        "
+	m.inputs[firewallFieldMode].Placeholder = "block, managed_challenge, challenge or allow"
+	m.inputs[firewallFieldMode].CharLimit = 20
+
+	m.inputs[firewallFieldNotes] = textinput.New()
+	m.inputs[firewallFieldNotes].Prompt = "Notes:       "
+	m.inputs[firewallFieldNotes].Placeholder = "why this rule exists"
+	m.inputs[firewallFieldNotes].CharLimit = 500
+
+	for i := range m.inputs {
+		m.inputs[i].Width = 44
+	}
+}
+
+func (m FirewallModel) Init() tea.Cmd {
+	return tea.Batch(m.loadRules(m.ctx), m.spinner.Tick)
+}
+
+// reload starts loading the rules again and shows the spinner
+func (m *FirewallModel) reload() tea.Cmd {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = 0
+	return tea.Batch(m.loadRules(m.ctx), m.spinner.Tick)
+}
+
+// scope returns the zone or account the screen shows
+func (m FirewallModel) scope() api.Scope {
+	if m.account {
+		return api.AccountScope(m.zone.Account.ID)
+	}
+	return api.ZoneScope(m.zone.ID)
+}
+
+func (m FirewallModel) loadRules(ctx context.Context) tea.Cmd {
+	scope := m.scope()
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return firewallLoadedMsg{err: err}
+		}
+
+		rules, err := client.ListFirewallRules(ctx, scope)
+		if err != nil {
+			return firewallLoadedMsg{err: err}
+		}
+		accessRules, err := client.ListAccessRules(ctx, scope)
+		if err != nil {
+			return firewallLoadedMsg{err: err}
+		}
+		return firewallLoadedMsg{rules: rules, accessRules: accessRules}
+	}
+}
+
+func (m FirewallModel) save(ctx context.Context) tea.Cmd {
+	scope := m.scope()
+	tab := m.tab
+	rule, ruleErr := m.ruleFromInputs()
+	access, accessErr := m.accessRuleFromInputs()
+	editingRule, editingAccess := m.editingRule, m.editingAccess
+
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return firewallSavedMsg{err: err}
+		}
+
+		if tab == firewallTabAccess {
+			if accessErr != nil {
+				return firewallSavedMsg{err: accessErr}
+			}
+			if editingAccess == nil {
+				_, err := client.CreateAccessRule(ctx, scope, access)
+				return firewallSavedMsg{status: "Access rule created", err: err}
+			}
+			return firewallSavedMsg{status: "Access rule updated", err: client.UpdateAccessRule(ctx, scope, access)}
+		}
+
+		if ruleErr != nil {
+			return firewallSavedMsg{err: ruleErr}
+		}
+		if editingRule == nil {
+			_, err := client.CreateFirewallRule(ctx, scope, rule)
+			return firewallSavedMsg{status: "Rule created", err: err}
+		}
+		return firewallSavedMsg{status: "Rule updated", err: client.UpdateFirewallRule(ctx, scope, rule)}
+	}
+}
+
+func (m FirewallModel) deleteSelected(ctx context.Context) tea.Cmd {
+	scope := m.scope()
+	selected := m.list.SelectedItem()
+
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return firewallSavedMsg{err: err}
+		}
+
+		switch item := selected.(type) {
+		case FirewallRuleItem:
+			return firewallSavedMsg{status: "Rule deleted", err: client.DeleteFirewallRule(ctx, scope, item.rule.ID)}
+		case AccessRuleItem:
+			return firewallSavedMsg{status: "Access rule deleted", err: client.DeleteAccessRule(ctx, scope, item.rule.ID)}
+		}
+		return firewallSavedMsg{err: fmt.Errorf("nothing selected")}
+	}
+}
+
+// ruleFromInputs builds a custom rule from the editor fields, preserving the
+// fields the editor doesn't show of the rule being edited. Its action
+// parameters are kept only while the action stays the same.
+func (m FirewallModel) ruleFromInputs() (cloudflare.FirewallRule, error) {
+	rule := cloudflare.FirewallRule{}
+	if m.editingRule != nil {
+		rule.ID = m.editingRule.ID
+		rule.Raw = m.editingRule.Raw
+	}
+
+	rule.Description = strings.TrimSpace(m.inputs[firewallFieldDescription].Value())
+	rule.Expression = strings.TrimSpace(m.inputs[firewallFieldExpression].Value())
+	if rule.Expression == "" {
+		return rule, fmt.Errorf("expression is required")
+	}
+
+	rule.Action = strings.ToLower(strings.TrimSpace(m.inputs[firewallFieldAction].Value()))
+	if err := firewall.ValidateAction(rule.Action); err != nil {
+		return rule, err
+	}
+	if m.editingRule != nil && m.editingRule.Action == rule.Action {
+		rule.ActionParameters = m.editingRule.ActionParameters
+	}
+
+	var err error
+	if rule.Enabled, err = parseYesNo(m.inputs[firewallFieldEnabled].Value(), true); err != nil {
+		return rule, fmt.Errorf("enabled: %w", err)
+	}
+	return rule, nil
+}
+
+// accessRuleFromInputs builds an IP Access rule from the editor fields. The
+// value of an existing rule can't be changed.
+func (m FirewallModel) accessRuleFromInputs() (cloudflare.AccessRule, error) {
+	rule := cloudflare.AccessRule{}
+	if m.editingAccess != nil {
+		rule = *m.editingAccess
+	} else {
+		target, value, err := firewall.ParseTarget(m.inputs[firewallFieldValue].Value())
+		if err != nil {
+			return rule, err
+		}
+		rule.Target = target
+		rule.Value = value
+	}
+
+	mode, err := firewall.ParseMode(m.inputs[firewallFieldMode].Value())
+	if err != nil {
+		return rule, err
+	}
+	rule.Mode = mode
+	rule.Notes = strings.TrimSpace(m.inputs[firewallFieldNotes].Value())
+	return rule, nil
+}
+
+// editorFields returns the editor fields of the current tab in focus order
+func (m FirewallModel) editorFields() []int {
+	if m.tab == firewallTabRules {
+		return []int{firewallFieldDescription, firewallFieldExpression, firewallFieldAction, firewallFieldEnabled}
+	}
+	if m.editingAccess != nil {
+		return []int{firewallFieldMode, firewallFieldNotes}
+	}
+	return []int{firewallFieldValue, firewallFieldMode, firewallFieldNotes}
+}
+
+// startEditing fills the editor with the selected item, or clears it when
+// creating one
+func (m *FirewallModel) startEditing(create bool) tea.Cmd {
+	m.editingRule = nil
+	m.editingAccess = nil
+	m.err = nil
+	m.status = ""
+	for i := range m.inputs {
+		m.inputs[i].SetValue("")
+	}
+
+	if !create {
+		switch item := m.list.SelectedItem().(type) {
+		case FirewallRuleItem:
+			rule := item.rule
+			m.editingRule = &rule
+			m.inputs[firewallFieldDescription].SetValue(rule.Description)
+			m.inputs[firewallFieldExpression].SetValue(rule.Expression)
+			m.inputs[firewallFieldAction].SetValue(rule.Action)
+			m.inputs[firewallFieldEnabled].SetValue(formatYesNo(rule.Enabled))
+		case AccessRuleItem:
+			if item.rule.Scope != "" && item.rule.Scope != m.scope().String() {
+				m.err = fmt.Errorf("this rule is defined on the %s; press 'a' to switch scope", item.rule.Scope)
+				return nil
+			}
+			rule := item.rule
+			m.editingAccess = &rule
+			m.inputs[firewallFieldMode].SetValue(firewall.ModeLabel(rule.Mode))
+			m.inputs[firewallFieldNotes].SetValue(rule.Notes)
+		default:
+			return nil
+		}
+	}
+
+	m.step = 2
+	m.focusIndex = 0
+	return m.updateFocus()
+}
+
+func (m *FirewallModel) updateFocus() tea.Cmd {
+	focused := m.editorFields()[m.focusIndex]
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := range m.inputs {
+		if i == focused {
+			cmds[i] = m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// showTab fills the list with the items of a tab
+func (m *FirewallModel) showTab(tab int) {
+	if tab != m.tab {
+		m.list.Select(0)
+	}
+	m.tab = tab
+
+	var items []list.Item
+	if tab == firewallTabRules {
+		for i, rule := range m.rules {
+			items = append(items, FirewallRuleItem{rule: rule, position: i + 1})
+		}
+	} else {
+		for _, rule := range m.accessRules {
+			items = append(items, AccessRuleItem{rule: rule})
+		}
+	}
+
+	index := m.list.Index()
+	m.list.SetItems(items)
+	if index < len(items) {
+		m.list.Select(index)
+	}
+}
+
+func (m FirewallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-16, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case firewallLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.step = 1
+		m.rules = msg.rules
+		m.accessRules = msg.accessRules
+		m.showTab(m.tab)
+		if msg.err != nil {
+			m.err = msg.err
+		}
+		return m, nil
+
+	case firewallSavedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			// Return to the editor so the user can fix the input
+			m.step = m.saveFrom
+			if m.step == 2 {
+				return m, m.updateFocus()
+			}
+			return m, nil
+		}
+		m.status = msg.status
+		m.err = nil
+		return m, m.reload()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0, 4:
+			if msg.String() != "esc" {
+				return m, nil
+			}
+			cancelRequest(m.cancel)
+			if m.step == 4 {
+				m.step = m.saveFrom
+				m.err = errChangeCanceled
+				if m.step == 2 {
+					return m, m.updateFocus()
+				}
+				return m, nil
+			}
+			return m.back()
+
+		case 1:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "tab", "right", "l", "shift+tab", "left", "h":
+				m.err = nil
+				m.showTab(1 - m.tab)
+				return m, nil
+			case "1", "2":
+				m.err = nil
+				m.showTab(int(msg.String()[0] - '1'))
+				return m, nil
+			case "a":
+				if m.zone.Account.ID == "" {
+					m.err = fmt.Errorf("the zone's account is unknown")
+					return m, nil
+				}
+				m.account = !m.account
+				m.err = nil
+				m.status = ""
+				m.list.Select(0)
+				return m, m.reload()
+			case "r":
+				m.err = nil
+				return m, m.reload()
+			case "n":
+				return m, m.startEditing(true)
+			case "enter", "e":
+				return m, m.startEditing(false)
+			case "d", "delete":
+				if m.list.SelectedItem() != nil {
+					m.step = 3
+					m.err = nil
+				}
+				return m, nil
+			}
+
+		case 2:
+			fields := m.editorFields()
+			switch msg.String() {
+			case "esc":
+				m.step = 1
+				m.err = nil
+				return m, nil
+			case "ctrl+s":
+				var err error
+				if m.tab == firewallTabAccess {
+					_, err = m.accessRuleFromInputs()
+				} else {
+					_, err = m.ruleFromInputs()
+				}
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 4
+				m.saveFrom = 2
+				m.err = nil
+				return m, tea.Batch(m.save(ctx), m.spinner.Tick)
+			case "tab", "down", "enter":
+				m.focusIndex = (m.focusIndex + 1) % len(fields)
+				return m, m.updateFocus()
+			case "shift+tab", "up":
+				m.focusIndex--
+				if m.focusIndex < 0 {
+					m.focusIndex = len(fields) - 1
+				}
+				return m, m.updateFocus()
+			}
+
+			field := fields[m.focusIndex]
+			var cmd tea.Cmd
+			m.inputs[field], cmd = m.inputs[field].Update(msg)
+			return m, cmd
+
+		case 3:
+			switch msg.String() {
+			case "y", "Y":
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 4
+				m.saveFrom = 1
+				return m, tea.Batch(m.deleteSelected(ctx), m.spinner.Tick)
+			case "n", "N", "esc":
+				m.step = 1
+				return m, nil
+			}
+			return m, nil
+		}
+
+	case spinner.TickMsg:
+		if m.step == 0 || m.step == 4 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.step == 1 {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m FirewallModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m FirewallModel) View() string {
+	// Responsive sizing
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🛡", "Firewall", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	scopeLabel, scopeName := "Zone: ", m.zone.Name
+	if m.account {
+		scopeLabel, scopeName = "Account: ", m.zone.Account.Name
+		if scopeName == "" {
+			scopeName = m.zone.Account.ID
+		}
+	}
+	scopeBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render(scopeLabel),
+		InfoStatusBadge.Render(scopeName),
+	)
+
+	tabs := make([]string, len(firewallTabNames))
+	for i, name := range firewallTabNames {
+		label := fmt.Sprintf(" %d:%s ", i+1, name)
+		if i == m.tab {
+			tabs[i] = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Underline(true).Render(label)
+		} else {
+			tabs[i] = lipgloss.NewStyle().Foreground(MutedColor).Render(label)
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().
+			Foreground(ErrorColor).
+			Render("✗ " + m.err.Error())
+	}
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0, 4:
+		label := "Loading firewall rules..."
+		if m.step == 4 {
+			label = "Saving changes..."
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " " + label))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case 1:
+		switch {
+		case len(m.list.Items()) > 0:
+			body = m.list.View()
+		case m.err != nil:
+			body = ""
+		case m.tab == firewallTabRules:
+			body = lipgloss.NewStyle().Foreground(MutedColor).Render("No custom rules configured. Press 'n' to create one.")
+		default:
+			body = lipgloss.NewStyle().Foreground(MutedColor).Render("No IP Access rules. Press 'n' to create one.")
+		}
+
+		var status string
+		if m.status != "" {
+			status = lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + m.status)
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, tabBar, "", body, "", status, errorMsg)
+
+		scopeHint := "Account"
+		if m.account {
+			scopeHint = "Zone"
+		}
+		footerHints = []KeyHint{
+			{Key: "n", Description: "New", IsAction: true},
+			{Key: "Enter", Description: "Edit", IsAction: false},
+			{Key: "d", Description: "Delete", IsAction: false},
+			{Key: "Tab", Description: "Switch", IsAction: false},
+			{Key: "a", Description: scopeHint, IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 2:
+		var heading string
+		switch {
+		case m.tab == firewallTabAccess && m.editingAccess != nil:
+			heading = fmt.Sprintf("Edit Access Rule: %s %s", m.editingAccess.Target, m.editingAccess.Value)
+		case m.tab == firewallTabAccess:
+			heading = "New Access Rule"
+		case m.editingRule != nil:
+			heading = "Edit Custom Rule"
+		default:
+			heading = "New Custom Rule"
+		}
+
+		fields := m.editorFields()
+		rendered := make([]string, len(fields))
+		for i, field := range fields {
+			style := InputStyle
+			if i == m.focusIndex {
+				style = FocusedInputStyle
+			}
+			rendered[i] = style.Width(min(m.width-14, 64)).Render(m.inputs[field].View())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(heading),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, rendered...),
+			"",
+			errorMsg,
+		)
+
+		footerHints = []KeyHint{
+			{Key: "Ctrl+S", Description: "Save", IsAction: true},
+			{Key: "Tab", Description: "Next Field", IsAction: false},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 3:
+		var name string
+		switch item := m.list.SelectedItem().(type) {
+		case FirewallRuleItem:
+			name = item.rule.Description
+			if name == "" {
+				name = item.rule.Expression
+			}
+		case AccessRuleItem:
+			name = item.Title()
+		}
+
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ErrorColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("⚠ Delete this rule?"),
+				"",
+				lipgloss.NewStyle().Foreground(TextColor).Render(name),
+			))
+
+		footerHints = []KeyHint{
+			{Key: "Y", Description: "Delete", IsAction: true},
+			{Key: "N", Description: "Cancel", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		scopeBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
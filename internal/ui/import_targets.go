@@ -0,0 +1,384 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/targets"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// ImportTargetsModel loads purge targets from a .txt, .csv or .json file
+// into one of the purge textareas
+type ImportTargetsModel struct {
+	config    *config.Config
+	zone      cloudflare.Zone
+	purgeType string // url, hostname, tag or prefix
+	previous  string // textarea contents restored on cancel
+	picker    filepicker.Model
+	path      string
+	columns   []string
+	column    int
+	report    targets.Report
+	step      int // 0: pick file, 1: choose column, 2: preview
+	err       error
+	width     int
+	height    int
+}
+
+func NewImportTargetsModel(cfg *config.Config, zone cloudflare.Zone, purgeType, previous string) ImportTargetsModel {
+	fp := filepicker.New()
+	fp.AllowedTypes = targets.Extensions
+	fp.AutoHeight = false
+	fp.ShowPermissions = false
+	fp.SetHeight(10)
+	if wd, err := os.Getwd(); err == nil {
+		fp.CurrentDirectory = wd
+	}
+	fp.Styles.Cursor = lipgloss.NewStyle().Foreground(PrimaryColor)
+	fp.Styles.Selected = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true)
+	fp.Styles.Directory = lipgloss.NewStyle().Foreground(AccentColor)
+	fp.Styles.File = lipgloss.NewStyle().Foreground(TextColor)
+	fp.Styles.DisabledFile = lipgloss.NewStyle().Foreground(MutedColor)
+
+	return ImportTargetsModel{
+		config:    cfg,
+		zone:      zone,
+		purgeType: purgeType,
+		previous:  previous,
+		picker:    fp,
+		width:     80,
+		height:    24,
+	}
+}
+
+func (m ImportTargetsModel) Init() tea.Cmd {
+	return m.picker.Init()
+}
+
+// targetValidator returns the validator for a single target of a purge type
+func targetValidator(purgeType string) func(string) error {
+	switch purgeType {
+	case config.PresetHostname:
+		return utils.ValidateHostname
+	case config.PresetTag:
+		return utils.ValidateTag
+	case config.PresetPrefix:
+		return utils.ValidatePrefix
+	default:
+		return utils.ValidateURL
+	}
+}
+
+// targetNoun returns the singular and plural name of a purge type's targets
+func targetNoun(purgeType string) (string, string) {
+	switch purgeType {
+	case config.PresetHostname:
+		return "hostname", "hostnames"
+	case config.PresetTag:
+		return "tag", "tags"
+	case config.PresetPrefix:
+		return "prefix", "prefixes"
+	default:
+		return "URL", "URLs"
+	}
+}
+
+// newTextPurgeModel opens the textarea purge screen of a purge type with
+// the given input
+func newTextPurgeModel(cfg *config.Config, zone cloudflare.Zone, purgeType, value string, width, height int) (tea.Model, tea.Cmd) {
+	switch purgeType {
+	case config.PresetHostname:
+		model := NewPurgeByHostnameModel(cfg, zone)
+		model.textarea.SetValue(value)
+		model.width, model.height = width, height
+		return model, model.Init()
+	case config.PresetTag:
+		model := NewPurgeByTagModel(cfg, zone)
+		model.textarea.SetValue(value)
+		model.width, model.height = width, height
+		return model, model.Init()
+	case config.PresetPrefix:
+		model := NewPurgeByPrefixModel(cfg, zone)
+		model.textarea.SetValue(value)
+		model.width, model.height = width, height
+		return model, model.Init()
+	default:
+		model := NewPurgeByURLModel(cfg, zone)
+		model.textarea.SetValue(value)
+		model.width, model.height = width, height
+		return model, model.Init()
+	}
+}
+
+// load reads the chosen file with the current column and validates it
+func (m ImportTargetsModel) load() (tea.Model, tea.Cmd) {
+	var column string
+	if len(m.columns) > 0 {
+		column = m.columns[m.column]
+	}
+
+	values, err := targets.Load(m.path, column)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	if len(values) == 0 {
+		m.err = fmt.Errorf("no targets found in %s", filepath.Base(m.path))
+		return m, nil
+	}
+
+	m.report = targets.Check(values, targetValidator(m.purgeType))
+	m.err = nil
+	m.step = 2
+	return m, nil
+}
+
+func (m ImportTargetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.picker.SetHeight(max(m.height-18, 5))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.step {
+		case 0:
+			if msg.String() == "esc" {
+				return newTextPurgeModel(m.config, m.zone, m.purgeType, m.previous, m.width, m.height)
+			}
+
+		case 1:
+			switch msg.String() {
+			case "esc":
+				m.step = 0
+				return m, nil
+			case "up", "k":
+				if m.column > 0 {
+					m.column--
+				}
+			case "down", "j":
+				if m.column < len(m.columns)-1 {
+					m.column++
+				}
+			case "enter":
+				return m.load()
+			}
+			return m, nil
+
+		case 2:
+			switch msg.String() {
+			case "esc":
+				if len(m.columns) > 1 {
+					m.step = 1
+				} else {
+					m.step = 0
+				}
+				return m, nil
+			case "enter":
+				if len(m.report.Valid) == 0 {
+					return m, nil
+				}
+				return newTextPurgeModel(m.config, m.zone, m.purgeType, strings.Join(m.report.Valid, "\n"), m.width, m.height)
+			}
+			return m, nil
+		}
+	}
+
+	if m.step != 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.picker, cmd = m.picker.Update(msg)
+
+	if ok, path := m.picker.DidSelectFile(msg); ok {
+		m.path = path
+		m.column = 0
+
+		columns, err := targets.Columns(path)
+		if err != nil {
+			m.err = err
+			return m, cmd
+		}
+		m.columns = columns
+		if len(columns) > 1 {
+			m.column = guessColumn(columns, m.purgeType)
+			m.err = nil
+			m.step = 1
+			return m, cmd
+		}
+		return m.load()
+	}
+	if ok, path := m.picker.DidSelectDisabledFile(msg); ok {
+		m.err = fmt.Errorf("%s is not a .txt, .csv or .json file", filepath.Base(path))
+	}
+
+	return m, cmd
+}
+
+// guessColumn preselects the column whose name matches the purge type
+func guessColumn(columns []string, purgeType string) int {
+	singular, plural := targetNoun(purgeType)
+	for i, c := range columns {
+		name := strings.ToLower(strings.TrimSpace(c))
+		if name == strings.ToLower(singular) || name == strings.ToLower(plural) || name == purgeType {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m ImportTargetsModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	_, plural := targetNoun(m.purgeType)
+	title := MakeSectionHeader("📂", "Import "+plural, "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+	}
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Render(utils.TruncateString(m.picker.CurrentDirectory, dividerWidth)),
+			"",
+			m.picker.View(),
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "↑↓", Description: "Navigate", IsAction: false},
+			{Key: "Enter", Description: "Open", IsAction: true},
+			{Key: "←", Description: "Parent dir", IsAction: false},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 1:
+		rows := []string{
+			lipgloss.NewStyle().Foreground(TextColor).Render("Which column holds the " + plural + "?"),
+			"",
+		}
+		for i, c := range m.columns {
+			if i == m.column {
+				rows = append(rows, lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Render("> "+c))
+			} else {
+				rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render("  "+c))
+			}
+		}
+		rows = append(rows, "", errorMsg)
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "↑↓", Description: "Choose", IsAction: false},
+			{Key: "Enter", Description: "Load", IsAction: true},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 2:
+		body = m.renderPreview(dividerWidth)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Use valid entries", IsAction: true},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// renderPreview shows the validation summary and the first entries
+func (m ImportTargetsModel) renderPreview(width int) string {
+	singular, plural := targetNoun(m.purgeType)
+	r := m.report
+
+	summary := []string{
+		lipgloss.NewStyle().Foreground(TextColor).Render(fmt.Sprintf("%s: %s read",
+			filepath.Base(m.path), utils.FormatCount(r.Total, "entry", "entries"))),
+		lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + utils.FormatCount(len(r.Valid), "valid "+singular, "valid "+plural)),
+	}
+	if len(r.Invalid) > 0 {
+		summary = append(summary, lipgloss.NewStyle().Foreground(ErrorColor).Render(
+			"✗ "+utils.FormatCount(len(r.Invalid), "invalid entry", "invalid entries")+" (skipped)"))
+	}
+	if r.Duplicates > 0 {
+		summary = append(summary, lipgloss.NewStyle().Foreground(WarningColor).Render(
+			"• "+utils.FormatCount(r.Duplicates, "duplicate", "duplicates")+" (skipped)"))
+	}
+
+	maxRows := max(m.height-24, 3)
+	rows := append(summary, "")
+	for i, v := range r.Valid {
+		if i == maxRows {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("… and %d more", len(r.Valid)-maxRows)))
+			break
+		}
+		rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render("  "+utils.TruncateString(v, width-4)))
+	}
+
+	// Show the first few problems so the file can be fixed
+	for i, inv := range r.Invalid {
+		if i == 3 {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("  … and %d more invalid", len(r.Invalid)-3)))
+			break
+		}
+		if i == 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, lipgloss.NewStyle().Foreground(ErrorColor).Render(utils.TruncateString(
+			fmt.Sprintf("  #%d %s: %v", inv.Index, inv.Value, inv.Err), width)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
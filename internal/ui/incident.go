@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/incident"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+type incidentMsg struct {
+	snapshot *incident.Snapshot
+	err      error
+}
+
+// IncidentModel starts incident mode on a zone, or ends it when the zone
+// already has a snapshot
+type IncidentModel struct {
+	config   *config.Config
+	zone     cloudflare.Zone
+	store    *incident.Store
+	active   *incident.Snapshot // snapshot of the running incident, nil when starting one
+	profile  incident.Profile
+	spinner  spinner.Model
+	cancel   context.CancelFunc // aborts the in-flight request
+	step     int                // 0: confirm, 1: running, 2: done
+	err      error
+	storeErr error
+	width    int
+	height   int
+}
+
+func NewIncidentModel(cfg *config.Config, zone cloudflare.Zone) IncidentModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	m := IncidentModel{
+		config:  cfg,
+		zone:    zone,
+		profile: incident.ProfileFrom(cfg.Incident),
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+
+	m.store, m.storeErr = incident.DefaultStore()
+	if m.storeErr == nil {
+		m.active, m.storeErr = m.store.Get(zone.ID)
+	}
+	return m
+}
+
+func (m IncidentModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m IncidentModel) run(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return incidentMsg{err: err}
+		}
+
+		if m.active != nil {
+			snapshot, err := incident.End(ctx, client, m.store, m.zone.ID)
+			return incidentMsg{snapshot: snapshot, err: err}
+		}
+		account, err := m.config.GetDefaultAccount()
+		if err != nil {
+			return incidentMsg{err: err}
+		}
+		snapshot, err := incident.Start(ctx, client, m.store, m.zone, account.Name, m.profile)
+		return incidentMsg{snapshot: snapshot, err: err}
+	}
+}
+
+// backToZone returns to the zone menu
+func (m IncidentModel) backToZone() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m IncidentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case incidentMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			// A partly applied profile leaves a snapshot behind, so the
+			// screen switches to ending the incident
+			if m.active == nil && msg.snapshot != nil {
+				m.active = msg.snapshot
+			}
+			m.err = msg.err
+			m.step = 0
+			return m, nil
+		}
+		m.err = nil
+		m.step = 2
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "y", "enter":
+				if m.storeErr != nil {
+					return m, nil
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				m.err = nil
+				return m, tea.Batch(m.run(ctx), m.spinner.Tick)
+			case "n", "esc", "q":
+				return m.backToZone()
+			}
+		case 1:
+			if msg.String() == "esc" {
+				cancelRequest(m.cancel)
+				m.step = 0
+				m.err = errChangeCanceled
+			}
+		case 2:
+			return m.backToZone()
+		}
+	}
+
+	return m, nil
+}
+
+func (m IncidentModel) View() string {
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	heading := "Start Incident Mode"
+	if m.active != nil {
+		heading = "End Incident Mode"
+	}
+	title := MakeSectionHeader("🚨", heading, "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	muted := lipgloss.NewStyle().Foreground(MutedColor)
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case 0:
+		var rows []string
+		if m.active != nil {
+			rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render(
+				"Started "+m.active.StartedAt.Local().Format("2006-01-02 15:04")+". This restores:"))
+			for _, change := range m.active.Changes() {
+				rows = append(rows, muted.Render(fmt.Sprintf("• %s back to %s", change.Setting, change.From)))
+			}
+			for _, rule := range m.active.Rules {
+				rows = append(rows, muted.Render("• rule "+rule.Label()+" back to disabled"))
+			}
+		} else {
+			rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render("This saves the current settings and sets:"))
+			for _, id := range m.profile.SettingIDs() {
+				rows = append(rows, muted.Render(fmt.Sprintf("• %s to %v", id, m.profile.Settings[id])))
+			}
+			for _, rule := range m.profile.EnableRules {
+				rows = append(rows, muted.Render("• rule "+rule+" enabled"))
+			}
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(WarningColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+
+		for _, err := range []error{m.storeErr, m.err} {
+			if err != nil {
+				body = lipgloss.JoinVertical(lipgloss.Left, body, "",
+					lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ "+err.Error()))
+			}
+		}
+		footerHints = []KeyHint{
+			{Key: "y", Description: "Confirm", IsAction: true},
+			{Key: "n", Description: "Cancel", IsAction: false},
+		}
+	case 1:
+		body = lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+			fmt.Sprintf("%s Applying...", m.spinner.View()))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case 2:
+		done := "✓ Incident mode started on " + m.zone.Name
+		hint := "End the incident from this menu to restore the saved settings"
+		if m.active != nil {
+			done = "✓ Incident mode ended on " + m.zone.Name
+			hint = "The settings saved when the incident started were restored"
+		}
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(done),
+			"",
+			muted.Width(dividerWidth).Render(hint),
+		)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Continue", IsAction: true},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// incidentActive reports whether the zone has an incident snapshot
+func incidentActive(zoneID string) bool {
+	store, err := incident.DefaultStore()
+	if err != nil {
+		return false
+	}
+	snapshot, err := store.Get(zoneID)
+	return err == nil && snapshot != nil
+}
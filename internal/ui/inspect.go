@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/inspect"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// InspectModel requests a URL and explains whether it is served from cache
+type InspectModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	input   textinput.Model
+	spinner spinner.Model
+	report  *inspect.Report
+	cancel  context.CancelFunc // aborts the in-flight request
+	step    int                // 0: input, 1: inspecting, 2: report
+	err     error
+	width   int
+	height  int
+}
+
+type inspectResultMsg struct {
+	report *inspect.Report
+	err    error
+}
+
+func NewInspectModel(cfg *config.Config, zone cloudflare.Zone) InspectModel {
+	ti := textinput.New()
+	ti.Placeholder = "https://" + zone.Name + "/path"
+	ti.Prompt = "URL: "
+	ti.CharLimit = 2048
+	ti.Width = 50
+	ti.SetValue("https://" + zone.Name + "/")
+	ti.Focus()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return InspectModel{
+		config:  cfg,
+		zone:    zone,
+		input:   ti,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m InspectModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m InspectModel) runInspect(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		url := strings.TrimSpace(m.input.Value())
+		if err := utils.ValidateURL(url); err != nil {
+			return inspectResultMsg{err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		report, err := inspect.URL(ctx, url, inspect.Options{Headers: m.config.Warmup.Headers})
+		return inspectResultMsg{report: report, err: err}
+	}
+}
+
+func (m InspectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case inspectResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 0
+			m.input.Focus()
+			return m, textinput.Blink
+		}
+		m.report = msg.report
+		m.step = 2
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "esc":
+				return m.back()
+			case "enter":
+				return m.start()
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		case 1:
+			if msg.String() == "esc" {
+				cancelRequest(m.cancel)
+				m.step = 0
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+		case 2:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "enter", "r":
+				// Re-request the same URL, e.g. to see a MISS become a HIT
+				return m.start()
+			case "n":
+				m.step = 0
+				m.report = nil
+				m.input.Focus()
+				return m, textinput.Blink
+			}
+		}
+		return m, nil
+
+	default:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m InspectModel) start() (tea.Model, tea.Cmd) {
+	m.step = 1
+	m.err = nil
+	m.input.Blur()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	return m, tea.Batch(m.runInspect(ctx), m.spinner.Tick)
+}
+
+func (m InspectModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m InspectModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🔍", "Inspect Cache Status", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		var errorMsg string
+		if m.err != nil {
+			errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Request a URL and explain whether it is served from cache:"),
+			"",
+			FocusedInputStyle.Render(m.input.View()),
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Inspect", IsAction: true},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " Requesting URL..."))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case 2:
+		body = m.renderReport(dividerWidth)
+		footerHints = []KeyHint{
+			{Key: "r", Description: "Request again", IsAction: true},
+			{Key: "n", Description: "New URL", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+func (m InspectModel) renderReport(width int) string {
+	r := m.report
+	label := lipgloss.NewStyle().Foreground(MutedColor).Width(18)
+	value := lipgloss.NewStyle().Foreground(TextColor)
+
+	verdict := lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("✗ Not cached")
+	if r.Cached {
+		verdict = lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Cached")
+	}
+
+	status := r.CacheStatus
+	if status == "" {
+		status = "—"
+	}
+
+	rows := []string{
+		verdict,
+		"",
+		label.Render("URL") + value.Render(utils.TruncateString(r.URL, width-18)),
+		label.Render("HTTP status") + value.Render(fmt.Sprintf("%d", r.StatusCode)),
+		label.Render("CF-Cache-Status") + value.Bold(true).Render(status),
+	}
+
+	add := func(name, v string) {
+		if v != "" {
+			rows = append(rows, label.Render(name)+value.Render(utils.TruncateString(v, width-18)))
+		}
+	}
+	if r.Age > 0 {
+		add("Age", (time.Duration(r.Age) * time.Second).String())
+	}
+	add("CF-Ray", r.Ray)
+	add("Cache-Control", r.CacheControl)
+	add("CDN-Cache-Control", r.CDNCacheControl)
+	add("Vary", r.Vary)
+	add("Cache tags", strings.Join(r.CacheTags, ", "))
+
+	rows = append(rows, "")
+	reason := lipgloss.NewStyle().Foreground(MutedColor).Width(width - 2)
+	for _, text := range r.Reasons {
+		rows = append(rows, reason.Render("• "+text))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
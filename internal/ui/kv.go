@@ -0,0 +1,887 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/kv"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// KV browser steps
+const (
+	kvStepLoading = iota
+	kvStepNamespaces
+	kvStepKeys
+	kvStepValue
+	kvStepEdit
+	kvStepConfirmDelete
+	kvStepSaving
+)
+
+// kvPageSize is how many keys the browser lists per page
+const kvPageSize = 50
+
+type KVNamespaceItem struct {
+	ns cloudflare.KVNamespace
+}
+
+func (i KVNamespaceItem) Title() string       { return i.ns.Title }
+func (i KVNamespaceItem) Description() string { return i.ns.ID }
+func (i KVNamespaceItem) FilterValue() string { return i.ns.Title }
+
+type KVKeyItem struct {
+	key cloudflare.KVKey
+	now time.Time
+}
+
+func (i KVKeyItem) Title() string { return i.key.Name }
+
+func (i KVKeyItem) Description() string {
+	desc := "no expiration"
+	if i.key.Expiration != 0 {
+		desc = "expires " + kv.FormatExpiration(i.key.Expiration, i.now)
+	}
+	if len(i.key.Metadata) > 0 {
+		desc += " | " + utils.TruncateString(string(i.key.Metadata), 40)
+	}
+	return desc
+}
+
+func (i KVKeyItem) FilterValue() string { return i.key.Name }
+
+// KVModel browses the Workers KV namespaces of the default account: keys
+// by prefix a page at a time, their values, and quick edits of text values
+type KVModel struct {
+	config     *config.Config
+	list       list.Model
+	spinner    spinner.Model
+	prefix     textinput.Model
+	keyInput   textinput.Model
+	editor     textarea.Model
+	accountID  string
+	namespaces []cloudflare.KVNamespace
+	namespace  cloudflare.KVNamespace // namespace being browsed
+	search     string                 // prefix the keys are listed with
+	keys       []cloudflare.KVKey     // keys of the current page
+	cursors    []string               // cursor of each page up to the current one; the first page has none
+	nextCursor string                 // empty on the last page
+	key        cloudflare.KVKey       // key shown, edited or deleted
+	value      []byte
+	creating   bool               // the editor adds a new key
+	step       int                // one of the kvStep constants
+	loadFrom   int                // step Esc returns to while loading
+	saveFrom   int                // step to return to if saving fails
+	label      string             // what is loading or saving
+	ctx        context.Context    // context of the latest request
+	cancel     context.CancelFunc // aborts the in-flight request
+	status     string
+	err        error
+	width      int
+	height     int
+}
+
+type kvNamespacesMsg struct {
+	accountID  string
+	namespaces []cloudflare.KVNamespace
+	err        error
+}
+
+type kvKeysMsg struct {
+	cursors []string
+	page    *cloudflare.KVKeyPage
+	err     error
+}
+
+type kvValueMsg struct {
+	key   *cloudflare.KVKey
+	value []byte
+	err   error
+}
+
+type kvSavedMsg struct {
+	status string
+	err    error
+}
+
+func NewKVModel(cfg *config.Config) KVModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	prefix := textinput.New()
+	prefix.Prompt = "Prefix: "
+	prefix.Placeholder = "all keys (press / to search)"
+	prefix.CharLimit = kv.MaxKeyLength
+	prefix.Width = 44
+
+	keyInput := textinput.New()
+	keyInput.Prompt = "Key: "
+	keyInput.Placeholder = "feature/dark-mode"
+	keyInput.CharLimit = kv.MaxKeyLength
+	keyInput.Width = 44
+
+	editor := textarea.New()
+	editor.Placeholder = "Value"
+	editor.CharLimit = 0
+	editor.ShowLineNumbers = false
+	editor.SetWidth(60)
+	editor.SetHeight(10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return KVModel{
+		config:   cfg,
+		list:     l,
+		spinner:  sp,
+		prefix:   prefix,
+		keyInput: keyInput,
+		editor:   editor,
+		step:     kvStepLoading,
+		loadFrom: kvStepLoading,
+		label:    "Loading namespaces...",
+		ctx:      ctx,
+		cancel:   cancel,
+		width:    80,
+		height:   24,
+	}
+}
+
+func (m KVModel) Init() tea.Cmd {
+	return tea.Batch(m.loadNamespaces(m.ctx), m.spinner.Tick)
+}
+
+// request starts a new request with its own cancelable context. Esc while
+// it runs returns to the from step.
+func (m *KVModel) request(label string, from int) context.Context {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = kvStepLoading
+	m.loadFrom = from
+	m.label = label
+	m.err = nil
+	return m.ctx
+}
+
+// write starts a write or delete with its own cancelable context. Esc while
+// it runs returns to the from step, though the change may already have
+// been applied.
+func (m *KVModel) write(label string, from int) context.Context {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = kvStepSaving
+	m.saveFrom = from
+	m.label = label
+	m.err = nil
+	return m.ctx
+}
+
+func (m KVModel) loadNamespaces(ctx context.Context) tea.Cmd {
+	accountID := m.accountID
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return kvNamespacesMsg{err: err}
+		}
+
+		if accountID == "" {
+			if accountID, err = defaultAccountID(ctx, m.config, client); err != nil {
+				return kvNamespacesMsg{err: err}
+			}
+		}
+		namespaces, err := client.ListKVNamespaces(ctx, accountID)
+		return kvNamespacesMsg{accountID: accountID, namespaces: namespaces, err: err}
+	}
+}
+
+// loadKeys fetches the page whose cursor is last in cursors
+func (m KVModel) loadKeys(ctx context.Context, cursors []string) tea.Cmd {
+	accountID, namespaceID, prefix := m.accountID, m.namespace.ID, m.search
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return kvKeysMsg{err: err}
+		}
+		page, err := client.ListKVKeys(ctx, accountID, namespaceID, prefix, cursors[len(cursors)-1], kvPageSize)
+		return kvKeysMsg{cursors: cursors, page: page, err: err}
+	}
+}
+
+func (m KVModel) loadValue(ctx context.Context, name string) tea.Cmd {
+	accountID, namespaceID := m.accountID, m.namespace.ID
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return kvValueMsg{err: err}
+		}
+		value, err := client.GetKVValue(ctx, accountID, namespaceID, name)
+		if err != nil {
+			return kvValueMsg{err: err}
+		}
+		key, err := client.GetKVKey(ctx, accountID, namespaceID, name)
+		return kvValueMsg{key: key, value: value, err: err}
+	}
+}
+
+// save writes the editor's value. An edited key keeps its metadata and
+// expiration.
+func (m KVModel) save(ctx context.Context) tea.Cmd {
+	accountID, namespaceID := m.accountID, m.namespace.ID
+	name := m.key.Name
+	var opts api.KVWriteOptions
+	if m.creating {
+		name = strings.TrimSpace(m.keyInput.Value())
+	} else {
+		opts.Metadata = m.key.Metadata
+		if m.key.Expiration != 0 {
+			// KV refuses expirations less than a minute away
+			opts.Expiration = max(m.key.Expiration, time.Now().Add(kv.MinTTL).Unix())
+		}
+	}
+	value := []byte(m.editor.Value())
+
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return kvSavedMsg{err: err}
+		}
+		if err := client.PutKVValue(ctx, accountID, namespaceID, name, value, opts); err != nil {
+			return kvSavedMsg{err: err}
+		}
+		return kvSavedMsg{status: "Saved " + name}
+	}
+}
+
+func (m KVModel) deleteKey(ctx context.Context, name string) tea.Cmd {
+	accountID, namespaceID := m.accountID, m.namespace.ID
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return kvSavedMsg{err: err}
+		}
+		if err := client.DeleteKVValue(ctx, accountID, namespaceID, name); err != nil {
+			return kvSavedMsg{err: err}
+		}
+		return kvSavedMsg{status: "Deleted " + name}
+	}
+}
+
+// enter switches to a step, filling the list when the step shows one
+func (m *KVModel) enter(step int) {
+	m.step = step
+	var items []list.Item
+	selected := 0
+	switch step {
+	case kvStepNamespaces:
+		for i, ns := range m.namespaces {
+			items = append(items, KVNamespaceItem{ns: ns})
+			if ns.ID == m.namespace.ID {
+				selected = i
+			}
+		}
+	case kvStepKeys:
+		now := time.Now()
+		for i, key := range m.keys {
+			items = append(items, KVKeyItem{key: key, now: now})
+			if key.Name == m.key.Name {
+				selected = i
+			}
+		}
+	default:
+		return
+	}
+	m.list.SetItems(items)
+	m.list.Select(selected)
+}
+
+// startEditing opens the editor for the shown key, or for a new key
+func (m *KVModel) startEditing(creating bool) tea.Cmd {
+	m.creating = creating
+	m.err = nil
+	m.status = ""
+	m.keyInput.SetValue("")
+	m.editor.SetValue("")
+	if !creating {
+		m.editor.SetValue(string(m.value))
+	}
+	m.step = kvStepEdit
+
+	if creating {
+		m.editor.Blur()
+		return m.keyInput.Focus()
+	}
+	m.keyInput.Blur()
+	return m.editor.Focus()
+}
+
+func (m KVModel) back() (tea.Model, tea.Cmd) {
+	cancelRequest(m.cancel)
+	model := NewMainMenuModel(m.config)
+	model.applySize(m.width, m.height)
+	return model, nil
+}
+
+func (m KVModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-20, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		m.editor.SetWidth(listWidth - 4)
+		m.editor.SetHeight(max(min(msg.Height-22, 14), 4))
+		return m, nil
+
+	case kvNamespacesMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.accountID != "" {
+			m.accountID = msg.accountID
+		}
+		if msg.err == nil {
+			m.namespaces = msg.namespaces
+		}
+		m.enter(kvStepNamespaces)
+		return m, nil
+
+	case kvKeysMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.enter(m.loadFrom)
+			return m, nil
+		}
+		m.err = nil
+		m.cursors = msg.cursors
+		m.keys = msg.page.Keys
+		m.nextCursor = msg.page.Cursor
+		m.enter(kvStepKeys)
+		return m, nil
+
+	case kvValueMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.enter(m.loadFrom)
+			return m, nil
+		}
+		m.key = *msg.key
+		m.value = msg.value
+		m.step = kvStepValue
+		return m, nil
+
+	case kvSavedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = m.saveFrom
+			if m.step == kvStepEdit {
+				if m.creating && !m.editor.Focused() {
+					return m, m.keyInput.Focus()
+				}
+				return m, m.editor.Focus()
+			}
+			return m, nil
+		}
+		// Listings are eventually consistent, so the change may take a
+		// moment to show up
+		m.status = msg.status
+		ctx := m.request("Loading keys...", kvStepKeys)
+		return m, tea.Batch(m.loadKeys(ctx, m.cursors), m.spinner.Tick)
+
+	case spinner.TickMsg:
+		if m.step == kvStepLoading || m.step == kvStepSaving {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case kvStepLoading:
+			if msg.String() == "esc" {
+				if m.loadFrom == kvStepLoading {
+					return m.back()
+				}
+				cancelRequest(m.cancel)
+				m.enter(m.loadFrom)
+			}
+			return m, nil
+
+		case kvStepSaving:
+			if msg.String() != "esc" {
+				return m, nil
+			}
+			cancelRequest(m.cancel)
+			m.err = errChangeCanceled
+			m.step = m.saveFrom
+			if m.step == kvStepEdit {
+				if m.creating && !m.editor.Focused() {
+					return m, m.keyInput.Focus()
+				}
+				return m, m.editor.Focus()
+			}
+			return m, nil
+
+		case kvStepNamespaces:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "r":
+				ctx := m.request("Loading namespaces...", kvStepNamespaces)
+				return m, tea.Batch(m.loadNamespaces(ctx), m.spinner.Tick)
+			case "enter":
+				if item, ok := m.list.SelectedItem().(KVNamespaceItem); ok {
+					m.namespace = item.ns
+					m.key = cloudflare.KVKey{}
+					m.status = ""
+					m.search = ""
+					m.prefix.SetValue("")
+					ctx := m.request("Loading keys...", kvStepNamespaces)
+					return m, tea.Batch(m.loadKeys(ctx, []string{""}), m.spinner.Tick)
+				}
+				return m, nil
+			}
+
+		case kvStepKeys:
+			if m.prefix.Focused() {
+				switch msg.String() {
+				case "enter":
+					m.prefix.Blur()
+					m.search = m.prefix.Value()
+					m.status = ""
+					ctx := m.request("Searching keys...", kvStepKeys)
+					return m, tea.Batch(m.loadKeys(ctx, []string{""}), m.spinner.Tick)
+				case "esc":
+					m.prefix.Blur()
+					m.prefix.SetValue(m.search)
+					return m, nil
+				}
+				var cmd tea.Cmd
+				m.prefix, cmd = m.prefix.Update(msg)
+				return m, cmd
+			}
+
+			switch msg.String() {
+			case "esc", "q":
+				m.status = ""
+				m.err = nil
+				m.enter(kvStepNamespaces)
+				return m, nil
+			case "/":
+				return m, m.prefix.Focus()
+			case "enter":
+				if item, ok := m.list.SelectedItem().(KVKeyItem); ok {
+					m.key = item.key
+					ctx := m.request("Loading value...", kvStepKeys)
+					return m, tea.Batch(m.loadValue(ctx, item.key.Name), m.spinner.Tick)
+				}
+				return m, nil
+			case "a":
+				return m, m.startEditing(true)
+			case "d", "delete":
+				if item, ok := m.list.SelectedItem().(KVKeyItem); ok {
+					m.key = item.key
+					m.saveFrom = kvStepKeys
+					m.err = nil
+					m.step = kvStepConfirmDelete
+				}
+				return m, nil
+			case "n", "right":
+				if m.nextCursor != "" {
+					cursors := append(append([]string{}, m.cursors...), m.nextCursor)
+					ctx := m.request("Loading keys...", kvStepKeys)
+					return m, tea.Batch(m.loadKeys(ctx, cursors), m.spinner.Tick)
+				}
+				return m, nil
+			case "p", "left":
+				if len(m.cursors) > 1 {
+					ctx := m.request("Loading keys...", kvStepKeys)
+					return m, tea.Batch(m.loadKeys(ctx, m.cursors[:len(m.cursors)-1]), m.spinner.Tick)
+				}
+				return m, nil
+			case "r":
+				m.status = ""
+				ctx := m.request("Loading keys...", kvStepKeys)
+				return m, tea.Batch(m.loadKeys(ctx, m.cursors), m.spinner.Tick)
+			}
+
+		case kvStepValue:
+			switch msg.String() {
+			case "esc", "q":
+				m.err = nil
+				m.enter(kvStepKeys)
+				return m, nil
+			case "e", "enter":
+				if !kv.IsText(m.value) {
+					m.err = fmt.Errorf("binary values can only be changed with `cfctl kv put --file`")
+					return m, nil
+				}
+				return m, m.startEditing(false)
+			case "d", "delete":
+				m.saveFrom = kvStepValue
+				m.err = nil
+				m.step = kvStepConfirmDelete
+				return m, nil
+			}
+			return m, nil
+
+		case kvStepEdit:
+			switch msg.String() {
+			case "esc":
+				m.err = nil
+				if m.creating {
+					m.enter(kvStepKeys)
+				} else {
+					m.step = kvStepValue
+				}
+				return m, nil
+			case "ctrl+s":
+				if m.creating {
+					if err := kv.ValidateKey(strings.TrimSpace(m.keyInput.Value())); err != nil {
+						m.err = err
+						return m, nil
+					}
+				}
+				ctx := m.write("Saving...", kvStepEdit)
+				return m, tea.Batch(m.save(ctx), m.spinner.Tick)
+			case "tab", "shift+tab":
+				if m.creating {
+					if m.keyInput.Focused() {
+						m.keyInput.Blur()
+						return m, m.editor.Focus()
+					}
+					m.editor.Blur()
+					return m, m.keyInput.Focus()
+				}
+			}
+
+			var cmd tea.Cmd
+			if m.keyInput.Focused() {
+				if msg.String() == "enter" {
+					m.keyInput.Blur()
+					return m, m.editor.Focus()
+				}
+				m.keyInput, cmd = m.keyInput.Update(msg)
+			} else {
+				m.editor, cmd = m.editor.Update(msg)
+			}
+			return m, cmd
+
+		case kvStepConfirmDelete:
+			switch msg.String() {
+			case "y", "Y":
+				ctx := m.write("Deleting...", m.saveFrom)
+				return m, tea.Batch(m.deleteKey(ctx, m.key.Name), m.spinner.Tick)
+			case "n", "N", "esc":
+				m.step = m.saveFrom
+				return m, nil
+			}
+			return m, nil
+		}
+
+	default:
+		if m.step == kvStepKeys && m.prefix.Focused() {
+			var cmd tea.Cmd
+			m.prefix, cmd = m.prefix.Update(msg)
+			return m, cmd
+		}
+		if m.step == kvStepEdit {
+			var cmd tea.Cmd
+			if m.keyInput.Focused() {
+				m.keyInput, cmd = m.keyInput.Update(msg)
+			} else {
+				m.editor, cmd = m.editor.Update(msg)
+			}
+			return m, cmd
+		}
+	}
+
+	if m.step == kvStepNamespaces || m.step == kvStepKeys {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// renderKeys shows the prefix search, the keys of the current page and
+// where the page is in the listing
+func (m KVModel) renderKeys(width int) string {
+	muted := lipgloss.NewStyle().Foreground(MutedColor)
+
+	style := InputStyle
+	if m.prefix.Focused() {
+		style = FocusedInputStyle
+	}
+	search := style.Width(width).Render(m.prefix.View())
+
+	var keys string
+	switch {
+	case len(m.keys) > 0:
+		keys = m.list.View()
+	case m.search != "":
+		keys = muted.Render("No keys start with " + m.search)
+	default:
+		keys = muted.Render("This namespace is empty. Press 'a' to add a key.")
+	}
+
+	page := fmt.Sprintf("Page %d", len(m.cursors))
+	if m.nextCursor != "" {
+		page += " · more keys follow"
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, search, "", keys, muted.Render(page))
+}
+
+// renderValue shows a key with its expiration, metadata and value
+func (m KVModel) renderValue(width int) string {
+	label := lipgloss.NewStyle().Foreground(MutedColor)
+	value := lipgloss.NewStyle().Foreground(TextColor).Bold(true)
+	row := func(name, v string) string {
+		return label.Render(fmt.Sprintf("%-12s", name)) + value.Render(v)
+	}
+
+	metadata := "none"
+	if len(m.key.Metadata) > 0 {
+		metadata = utils.TruncateString(string(m.key.Metadata), width-12)
+	}
+	rows := []string{
+		lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(utils.TruncateString(m.key.Name, width)),
+		"",
+		row("Expires", kv.FormatExpiration(m.key.Expiration, time.Now())),
+		row("Metadata", metadata),
+		row("Size", fmt.Sprintf("%d bytes", len(m.value))),
+		"",
+	}
+
+	if !kv.IsText(m.value) {
+		rows = append(rows, label.Render("Binary value; read it with `cfctl kv get`."))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	maxLines := max(min(m.height-24, 14), 4)
+	lines := strings.Split(string(m.value), "\n")
+	text := lipgloss.NewStyle().Foreground(TextColor)
+	for i, line := range lines {
+		if i == maxLines {
+			rows = append(rows, label.Render(fmt.Sprintf("… %d more lines", len(lines)-maxLines)))
+			break
+		}
+		rows = append(rows, text.Render(utils.TruncateString(strings.ReplaceAll(line, "\t", "  "), width)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m KVModel) View() string {
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("▤", "Workers KV", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	badgeLabel, badgeValue := "Account: ", m.accountID
+	if m.step != kvStepNamespaces && m.namespace.ID != "" {
+		badgeLabel, badgeValue = "Namespace: ", m.namespace.Title
+	}
+	var badge string
+	if badgeValue != "" {
+		badge = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Render(badgeLabel),
+			InfoStatusBadge.Render(badgeValue),
+		)
+	}
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ " + m.err.Error())
+	}
+	var status string
+	if m.status != "" {
+		status = lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + m.status)
+	}
+
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case kvStepLoading, kvStepSaving:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " " + m.label))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case kvStepNamespaces:
+		var namespaces string
+		switch {
+		case len(m.namespaces) > 0:
+			namespaces = m.list.View()
+		case m.err == nil:
+			namespaces = lipgloss.NewStyle().Foreground(MutedColor).Render("No KV namespaces in this account.")
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, namespaces, errorMsg)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Browse", IsAction: true},
+			{Key: "r", Description: "Refresh", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case kvStepKeys:
+		body = lipgloss.JoinVertical(lipgloss.Left, m.renderKeys(dividerWidth), "", status, errorMsg)
+		if m.prefix.Focused() {
+			footerHints = []KeyHint{
+				{Key: "Enter", Description: "Search", IsAction: true},
+				{Key: "Esc", Description: "Cancel", IsAction: false},
+			}
+		} else {
+			footerHints = []KeyHint{
+				{Key: "Enter", Description: "Open", IsAction: true},
+				{Key: "/", Description: "Prefix", IsAction: false},
+				{Key: "n/p", Description: "Page", IsAction: false},
+				{Key: "a", Description: "Add", IsAction: false},
+				{Key: "d", Description: "Delete", IsAction: false},
+				{Key: "Esc", Description: "Back", IsAction: false},
+			}
+		}
+
+	case kvStepValue:
+		body = lipgloss.JoinVertical(lipgloss.Left, m.renderValue(dividerWidth), "", errorMsg)
+		footerHints = []KeyHint{
+			{Key: "e", Description: "Edit", IsAction: true},
+			{Key: "d", Description: "Delete", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case kvStepEdit:
+		heading := "Edit " + m.key.Name
+		var keyField string
+		if m.creating {
+			heading = "New Key"
+			style := InputStyle
+			if m.keyInput.Focused() {
+				style = FocusedInputStyle
+			}
+			keyField = style.Width(dividerWidth).Render(m.keyInput.View())
+		} else if m.key.Expiration != 0 || len(m.key.Metadata) > 0 {
+			keyField = lipgloss.NewStyle().Foreground(MutedColor).Render("Metadata and expiration are kept.")
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(utils.TruncateString(heading, dividerWidth)),
+			"",
+			keyField,
+			m.editor.View(),
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{{Key: "Ctrl+S", Description: "Save", IsAction: true}}
+		if m.creating {
+			footerHints = append(footerHints, KeyHint{Key: "Tab", Description: "Next Field", IsAction: false})
+		}
+		footerHints = append(footerHints, KeyHint{Key: "Esc", Description: "Cancel", IsAction: false})
+
+	case kvStepConfirmDelete:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ErrorColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("⚠ Delete this key and its value?"),
+				"",
+				lipgloss.NewStyle().Foreground(TextColor).Render(utils.TruncateString(m.key.Name, dividerWidth-6)),
+			))
+		footerHints = []KeyHint{
+			{Key: "Y", Description: "Delete", IsAction: true},
+			{Key: "N", Description: "Cancel", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		badge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
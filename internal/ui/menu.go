@@ -49,6 +49,7 @@ func NewMainMenuModel(cfg *config.Config) MainMenuModel {
 		MenuItem{title: "Select Account", description: "", action: "select", icon: "◉"},
 		MenuItem{title: "Remove Account", description: "", action: "remove", icon: "✕"},
 		MenuItem{title: "Manage Domains", description: "", action: "domains", icon: "◈"},
+		MenuItem{title: "Workers KV", description: "", action: "kv", icon: "▤"},
 		MenuItem{title: "Settings", description: "", action: "settings", icon: "◐"},
 		MenuItem{title: "Help", description: "", action: "help", icon: "?"},
 		MenuItem{title: "Exit", description: "", action: "exit", icon: "→"},
@@ -134,6 +135,12 @@ func (m MainMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				domainModel.width = m.width
 				domainModel.height = m.height
 				return domainModel, domainModel.Init()
+			case "kv":
+				if len(m.config.Accounts) == 0 {
+					return m.showMessage("No Accounts", "Please configure an account first.", WarningColor)
+				}
+				model, _ := NewKVModel(m.config).Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+				return model, model.Init()
 			case "settings":
 				model := NewSettingsModel(m.config)
 				model.width = m.width
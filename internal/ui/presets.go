@@ -0,0 +1,429 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// SavePresetModel names a completed purge so it can be repeated from the
+// purge menu
+type SavePresetModel struct {
+	config *config.Config
+	zone   cloudflare.Zone
+	preset config.Preset
+	input  textinput.Model
+	step   int // 0: name input, 1: saved
+	err    error
+	width  int
+	height int
+}
+
+func NewSavePresetModel(cfg *config.Config, zone cloudflare.Zone, presetType string, targets []string) SavePresetModel {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. homepage-tags"
+	ti.Prompt = "Name: "
+	ti.CharLimit = 50
+	ti.Width = 40
+	ti.Focus()
+
+	return SavePresetModel{
+		config: cfg,
+		zone:   zone,
+		preset: config.Preset{
+			Zone:    zone.Name,
+			Type:    presetType,
+			Targets: utils.Dedupe(targets),
+		},
+		input:  ti,
+		width:  80,
+		height: 24,
+	}
+}
+
+// openSavePreset switches to the save preset screen from a purge success
+// screen
+func openSavePreset(cfg *config.Config, zone cloudflare.Zone, presetType string, targets []string, width, height int) (tea.Model, tea.Cmd) {
+	model := NewSavePresetModel(cfg, zone, presetType, targets)
+	model.width = width
+	model.height = height
+	return model, model.Init()
+}
+
+func (m SavePresetModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m SavePresetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.step == 1 {
+			return m.back()
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m.back()
+		case "enter":
+			m.preset.Name = strings.TrimSpace(m.input.Value())
+			if err := m.config.SavePreset(m.preset); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.step = 1
+			return m, nil
+		}
+	}
+
+	if m.step == 0 {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m SavePresetModel) back() (tea.Model, tea.Cmd) {
+	model := NewPurgeMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m SavePresetModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("⭐", "Save Preset", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		var note string
+		if _, err := m.config.GetPreset(strings.TrimSpace(m.input.Value())); err == nil {
+			note = lipgloss.NewStyle().Foreground(WarningColor).Render("⚠ Replaces the existing preset with this name")
+		}
+
+		var errorMsg string
+		if m.err != nil {
+			errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(TextColor).Render("Purge "+m.preset.Describe()),
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Presets appear in the purge menu and run with"),
+			lipgloss.NewStyle().Foreground(MutedColor).Render("\"cfctl purge preset <name>\"."),
+			"",
+			FocusedInputStyle.Render(m.input.View()),
+			note,
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Save", IsAction: true},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(SuccessColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+				fmt.Sprintf("✓ Saved preset %q", m.preset.Name)))
+		footerHints = []KeyHint{
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		}
+	}
+
+	return renderPresetContainer(m.width, m.height, title, divider, zoneBadge, body, footerHints)
+}
+
+// RunPresetModel confirms and runs a saved preset
+type RunPresetModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	preset  config.Preset
+	spinner spinner.Model
+	step    int                // 0: confirm, 1: purging, 2: done
+	cancel  context.CancelFunc // aborts the in-flight purge
+	err     error
+	width   int
+	height  int
+}
+
+func NewRunPresetModel(cfg *config.Config, zone cloudflare.Zone, preset config.Preset) RunPresetModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return RunPresetModel{
+		config:  cfg,
+		zone:    zone,
+		preset:  preset,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m RunPresetModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RunPresetModel) executePurge(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		spec := purgejob.FromPreset(m.preset)
+		if err := spec.Validate(); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
+
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
+
+		for _, req := range spec.Requests() {
+			if err := client.PurgeCacheBatched(ctx, m.zone.ID, req, nil); err != nil {
+				return purgeResultMsg{success: false, err: err}
+			}
+		}
+		return purgeResultMsg{success: true, targets: m.preset.Targets}
+	}
+}
+
+func (m RunPresetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.success {
+			m.step = 2
+			m.err = nil
+		} else {
+			m.step = 0
+			m.err = msg.err
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "esc", "n":
+				return m.back()
+			case "enter", "y":
+				if m.preset.Type == config.PresetEverything {
+					// Keep the typed zone name confirmation for purging everything
+					model := NewPurgeEverythingModel(m.config, m.zone)
+					model.step = 1
+					model.width = m.width
+					model.height = m.height
+					return model, textinput.Blink
+				}
+				m.err = nil
+				if len(m.preset.Targets) > api.MaxPurgeBatchSize {
+					spec := purgejob.FromPreset(m.preset)
+					if err := spec.Validate(); err != nil {
+						m.err = err
+						return m, nil
+					}
+					return startPurgeProgress(m.config, m.zone, m, spec.Requests()[0], m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				return m, tea.Batch(m.executePurge(ctx), m.spinner.Tick)
+			case "d":
+				if err := m.config.RemovePreset(m.preset.Name); err != nil {
+					m.err = err
+					return m, nil
+				}
+				return m.back()
+			}
+		case 1:
+			if msg.String() == "esc" {
+				// Abort the in-flight purge and return to the confirmation
+				cancelRequest(m.cancel)
+				m.step = 0
+				m.err = errPurgeCanceled
+			}
+		case 2:
+			if msg.String() == "w" && m.preset.Type == config.PresetURL {
+				model := NewWarmupModel(m.config, m.zone, m.preset.Targets)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			}
+			return m.back()
+		}
+		return m, nil
+
+	default:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m RunPresetModel) back() (tea.Model, tea.Cmd) {
+	model := NewPurgeMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m RunPresetModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("⭐", "Preset: "+m.preset.Name, "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		rows := []string{
+			lipgloss.NewStyle().Foreground(WarningColor).Bold(true).Render("Purge " + m.preset.Describe() + "?"),
+			"",
+		}
+		if m.preset.Type == config.PresetEverything {
+			rows = append(rows, lipgloss.NewStyle().Foreground(ErrorColor).Render("⚠ This clears ALL cached content!"))
+		}
+
+		maxRows := m.height - 20
+		if maxRows < 3 {
+			maxRows = 3
+		}
+		for i, target := range m.preset.Targets {
+			if i == maxRows {
+				rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+					fmt.Sprintf("… and %d more", len(m.preset.Targets)-maxRows)))
+				break
+			}
+			rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render("  "+utils.TruncateString(target, dividerWidth-4)))
+		}
+		if m.err != nil {
+			rows = append(rows, "", lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ "+m.err.Error()))
+		}
+
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Purge", IsAction: true},
+			{Key: "d", Description: "Delete preset", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+				m.spinner.View() + " Purging " + m.preset.Describe() + "..."))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 2:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(SuccessColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+				"✓ Purged " + m.preset.Describe() + " from cache"))
+		if m.preset.Type == config.PresetURL {
+			footerHints = append(footerHints, KeyHint{Key: "w", Description: "Warm cache", IsAction: true})
+		}
+		footerHints = append(footerHints, KeyHint{Key: "Any key", Description: "Continue", IsAction: false})
+	}
+
+	return renderPresetContainer(m.width, m.height, title, divider, zoneBadge, body, footerHints)
+}
+
+// renderPresetContainer lays out the preset screens in the bordered
+// container used by the other purge forms
+func renderPresetContainer(width, height int, title, divider, zoneBadge, body string, footerHints []KeyHint) string {
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		width, height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
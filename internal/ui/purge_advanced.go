@@ -3,7 +3,6 @@ package ui
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -11,20 +10,23 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/siyamsarker/cfctl/internal/api"
 	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
 	"github.com/siyamsarker/cfctl/internal/utils"
 	"github.com/siyamsarker/cfctl/pkg/cloudflare"
 )
 
 // PurgeByTagModel
 type PurgeByTagModel struct {
-	config   *config.Config
-	zone     cloudflare.Zone
-	textarea textarea.Model
-	err      error
-	success  bool
-	purging  bool
-	width    int
-	height   int
+	config     *config.Config
+	zone       cloudflare.Zone
+	textarea   textarea.Model
+	err        error
+	success    bool
+	purging    bool
+	confirmKey string             // Ctrl+S or Ctrl+T pressed once with invalid entries to skip
+	cancel     context.CancelFunc // aborts the in-flight purge
+	width      int
+	height     int
 }
 
 func NewPurgeByTagModel(cfg *config.Config, zone cloudflare.Zone) PurgeByTagModel {
@@ -48,60 +50,30 @@ func (m PurgeByTagModel) Init() tea.Cmd {
 	return textarea.Blink
 }
 
-func (m PurgeByTagModel) executePurge() tea.Msg {
-	input := m.textarea.Value()
-	lines := strings.Split(input, "\n")
-	var tags []string
-	for _, line := range lines {
-		parsed := utils.ParseCommaSeparated(line)
-		tags = append(tags, parsed...)
-	}
-
-	if err := utils.ValidateTags(tags); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+func (m PurgeByTagModel) executePurge(ctx context.Context) tea.Cmd {
+	// Invalid and duplicate entries are skipped; the user confirmed this
+	// before submitting
+	tags := checkTargets(m.textarea.Value(), config.PresetTag).report.Valid
 
-	account, err := m.config.GetDefaultAccount()
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+	return func() tea.Msg {
+		if err := utils.ValidateTags(tags); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
+		req := cloudflare.PurgeRequest{
+			Tags: tags,
 		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
+		if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
+			return purgeResultMsg{success: false, err: err}
 		}
-	}
-
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	ctx := context.Background()
-	req := cloudflare.PurgeRequest{
-		Tags: tags,
-	}
 
-	if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
-		return purgeResultMsg{success: false, err: err}
+		return purgeResultMsg{success: true}
 	}
-
-	return purgeResultMsg{success: true}
 }
 
 func (m PurgeByTagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -112,6 +84,9 @@ func (m PurgeByTagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
 		m.purging = false
 		if msg.success {
 			m.success = true
@@ -123,6 +98,9 @@ func (m PurgeByTagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.success {
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetTag, checkTargets(m.textarea.Value(), config.PresetTag).report.Valid, m.width, m.height)
+			}
 			model := NewPurgeMenuModel(m.config, m.zone)
 			model.width = m.width
 			model.height = m.height
@@ -131,19 +109,61 @@ func (m PurgeByTagModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "esc":
+			if m.purging {
+				// Abort the in-flight request and return to the form
+				cancelRequest(m.cancel)
+				m.purging = false
+				m.err = errPurgeCanceled
+				return m, nil
+			}
+			model := NewPurgeMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "ctrl+o":
 			if !m.purging {
-				model := NewPurgeMenuModel(m.config, m.zone)
+				model := NewImportTargetsModel(m.config, m.zone, config.PresetTag, m.textarea.Value())
 				model.width = m.width
 				model.height = m.height
-				return model, nil
+				return model, model.Init()
+			}
+		case "ctrl+t":
+			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetTag, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				spec := purgejob.Spec{Tags: valid}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
 			}
 		case "ctrl+s":
 			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetTag, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				if len(valid) > api.MaxPurgeBatchSize {
+					// Large purges run batch by batch with a progress bar
+					req := cloudflare.PurgeRequest{Tags: valid}
+					return startPurgeProgress(m.config, m.zone, m, req, m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
 				m.purging = true
-				m.err = nil
-				return m, m.executePurge
+				return m, m.executePurge(ctx)
 			}
 		}
+		m.confirmKey = ""
 	}
 
 	if !m.purging && !m.success {
@@ -194,6 +214,8 @@ func (m PurgeByTagModel) View() string {
 			zoneBadge,
 			"",
 			loadingCard,
+			"",
+			MakeFooter([]KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}),
 		)
 	} else if m.success {
 		successCard := lipgloss.NewStyle().
@@ -204,7 +226,10 @@ func (m PurgeByTagModel) View() string {
 				lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Cache purged successfully!"),
 			)
 
-		prompt := lipgloss.NewStyle().Foreground(MutedColor).Render("Press any key to continue")
+		prompt := MakeFooter([]KeyHint{
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		})
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Center,
@@ -242,6 +267,8 @@ func (m PurgeByTagModel) View() string {
 		// Modern footer
 		footerHints := []KeyHint{
 			{Key: "Ctrl+S", Description: "Submit", IsAction: true},
+			{Key: "Ctrl+T", Description: "Schedule", IsAction: false},
+			{Key: "Ctrl+O", Description: "Import file", IsAction: false},
 			{Key: "Esc", Description: "Cancel", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -258,6 +285,7 @@ func (m PurgeByTagModel) View() string {
 			"",
 			m.textarea.View(),
 			"",
+			renderTargetCheck(checkTargets(m.textarea.Value(), config.PresetTag), config.PresetTag, taWidth+4, m.confirmKey),
 			errorMsg,
 			lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 			footer,
@@ -273,14 +301,16 @@ func (m PurgeByTagModel) View() string {
 
 // PurgeByPrefixModel
 type PurgeByPrefixModel struct {
-	config   *config.Config
-	zone     cloudflare.Zone
-	textarea textarea.Model
-	err      error
-	success  bool
-	purging  bool
-	width    int
-	height   int
+	config     *config.Config
+	zone       cloudflare.Zone
+	textarea   textarea.Model
+	err        error
+	success    bool
+	purging    bool
+	confirmKey string             // Ctrl+S or Ctrl+T pressed once with invalid entries to skip
+	cancel     context.CancelFunc // aborts the in-flight purge
+	width      int
+	height     int
 }
 
 func NewPurgeByPrefixModel(cfg *config.Config, zone cloudflare.Zone) PurgeByPrefixModel {
@@ -304,60 +334,30 @@ func (m PurgeByPrefixModel) Init() tea.Cmd {
 	return textarea.Blink
 }
 
-func (m PurgeByPrefixModel) executePurge() tea.Msg {
-	input := m.textarea.Value()
-	lines := strings.Split(input, "\n")
-	var prefixes []string
-	for _, line := range lines {
-		parsed := utils.ParseCommaSeparated(line)
-		prefixes = append(prefixes, parsed...)
-	}
-
-	if err := utils.ValidatePrefixes(prefixes); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+func (m PurgeByPrefixModel) executePurge(ctx context.Context) tea.Cmd {
+	// Invalid and duplicate entries are skipped; the user confirmed this
+	// before submitting
+	prefixes := checkTargets(m.textarea.Value(), config.PresetPrefix).report.Valid
 
-	account, err := m.config.GetDefaultAccount()
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+	return func() tea.Msg {
+		if err := utils.ValidatePrefixes(prefixes); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
+		req := cloudflare.PurgeRequest{
+			Prefixes: prefixes,
 		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
+		if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
+			return purgeResultMsg{success: false, err: err}
 		}
-	}
 
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
+		return purgeResultMsg{success: true}
 	}
-
-	ctx := context.Background()
-	req := cloudflare.PurgeRequest{
-		Prefixes: prefixes,
-	}
-
-	if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	return purgeResultMsg{success: true}
 }
 
 func (m PurgeByPrefixModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -368,6 +368,9 @@ func (m PurgeByPrefixModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
 		m.purging = false
 		if msg.success {
 			m.success = true
@@ -379,6 +382,9 @@ func (m PurgeByPrefixModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.success {
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetPrefix, checkTargets(m.textarea.Value(), config.PresetPrefix).report.Valid, m.width, m.height)
+			}
 			model := NewPurgeMenuModel(m.config, m.zone)
 			model.width = m.width
 			model.height = m.height
@@ -387,19 +393,61 @@ func (m PurgeByPrefixModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "esc":
+			if m.purging {
+				// Abort the in-flight request and return to the form
+				cancelRequest(m.cancel)
+				m.purging = false
+				m.err = errPurgeCanceled
+				return m, nil
+			}
+			model := NewPurgeMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "ctrl+o":
 			if !m.purging {
-				model := NewPurgeMenuModel(m.config, m.zone)
+				model := NewImportTargetsModel(m.config, m.zone, config.PresetPrefix, m.textarea.Value())
 				model.width = m.width
 				model.height = m.height
-				return model, nil
+				return model, model.Init()
+			}
+		case "ctrl+t":
+			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetPrefix, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				spec := purgejob.Spec{Prefixes: valid}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
 			}
 		case "ctrl+s":
 			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetPrefix, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				if len(valid) > api.MaxPurgeBatchSize {
+					// Large purges run batch by batch with a progress bar
+					req := cloudflare.PurgeRequest{Prefixes: valid}
+					return startPurgeProgress(m.config, m.zone, m, req, m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
 				m.purging = true
-				m.err = nil
-				return m, m.executePurge
+				return m, m.executePurge(ctx)
 			}
 		}
+		m.confirmKey = ""
 	}
 
 	if !m.purging && !m.success {
@@ -446,6 +494,8 @@ func (m PurgeByPrefixModel) View() string {
 			zoneBadge,
 			"",
 			loadingCard,
+			"",
+			MakeFooter([]KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}),
 		)
 	} else if m.success {
 		successCard := lipgloss.NewStyle().
@@ -456,7 +506,10 @@ func (m PurgeByPrefixModel) View() string {
 				lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Cache purged successfully!"),
 			)
 
-		prompt := lipgloss.NewStyle().Foreground(MutedColor).Render("Press any key to continue")
+		prompt := MakeFooter([]KeyHint{
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		})
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Center,
@@ -494,6 +547,8 @@ func (m PurgeByPrefixModel) View() string {
 		// Modern footer
 		footerHints := []KeyHint{
 			{Key: "Ctrl+S", Description: "Submit", IsAction: true},
+			{Key: "Ctrl+T", Description: "Schedule", IsAction: false},
+			{Key: "Ctrl+O", Description: "Import file", IsAction: false},
 			{Key: "Esc", Description: "Cancel", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -509,6 +564,7 @@ func (m PurgeByPrefixModel) View() string {
 			"",
 			m.textarea.View(),
 			"",
+			renderTargetCheck(checkTargets(m.textarea.Value(), config.PresetPrefix), config.PresetPrefix, taWidth+4, m.confirmKey),
 			errorMsg,
 			lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 			footer,
@@ -527,7 +583,8 @@ type PurgeEverythingModel struct {
 	config  *config.Config
 	zone    cloudflare.Zone
 	input   textinput.Model
-	step    int // 0: first confirm, 1: type domain name, 2: purging, 3: done
+	step    int                // 0: first confirm, 1: type domain name, 2: purging, 3: done
+	cancel  context.CancelFunc // aborts the in-flight purge
 	err     error
 	success bool
 	width   int
@@ -554,48 +611,22 @@ func (m PurgeEverythingModel) Init() tea.Cmd {
 	return textinput.Blink
 }
 
-func (m PurgeEverythingModel) executePurge() tea.Msg {
-	account, err := m.config.GetDefaultAccount()
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+func (m PurgeEverythingModel) executePurge(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
+		req := cloudflare.PurgeRequest{
+			PurgeEverything: true,
 		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
+		if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
+			return purgeResultMsg{success: false, err: err}
 		}
-	}
 
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
+		return purgeResultMsg{success: true}
 	}
-
-	ctx := context.Background()
-	req := cloudflare.PurgeRequest{
-		PurgeEverything: true,
-	}
-
-	if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	return purgeResultMsg{success: true}
 }
 
 func (m PurgeEverythingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -606,6 +637,9 @@ func (m PurgeEverythingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
 		if msg.success {
 			m.success = true
 			m.err = nil
@@ -618,6 +652,9 @@ func (m PurgeEverythingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.step == 3 {
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetEverything, nil, m.width, m.height)
+			}
 			model := NewPurgeMenuModel(m.config, m.zone)
 			model.width = m.width
 			model.height = m.height
@@ -626,12 +663,20 @@ func (m PurgeEverythingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "esc":
-			if m.step != 2 {
-				model := NewPurgeMenuModel(m.config, m.zone)
-				model.width = m.width
-				model.height = m.height
-				return model, nil
+			if m.step == 2 {
+				// Abort the in-flight request and return to the confirmation
+				cancelRequest(m.cancel)
+				m.step = 1
+				m.err = errPurgeCanceled
+				return m, textinput.Blink
 			}
+			model := NewPurgeMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
 		case "enter":
 			switch m.step {
 			case 0:
@@ -639,13 +684,29 @@ func (m PurgeEverythingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, textinput.Blink
 			case 1:
 				if m.input.Value() == m.zone.Name {
+					ctx, cancel := context.WithCancel(context.Background())
+					m.cancel = cancel
 					m.step = 2
-					return m, m.executePurge
+					return m, m.executePurge(ctx)
 				} else {
 					m.err = fmt.Errorf("domain name doesn't match")
 					return m, nil
 				}
 			}
+		case "ctrl+t":
+			if m.step == 1 {
+				if m.input.Value() != m.zone.Name {
+					m.err = fmt.Errorf("domain name doesn't match")
+					return m, nil
+				}
+				spec := purgejob.Spec{Everything: true}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
+			}
 		case "n":
 			if m.step == 0 {
 				model := NewPurgeMenuModel(m.config, m.zone)
@@ -756,6 +817,7 @@ func (m PurgeEverythingModel) View() string {
 		// Modern footer
 		footerHints := []KeyHint{
 			{Key: "Enter", Description: "Confirm", IsAction: true},
+			{Key: "Ctrl+T", Description: "Schedule", IsAction: false},
 			{Key: "Esc", Description: "Cancel", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -799,6 +861,8 @@ func (m PurgeEverythingModel) View() string {
 			zoneBadge,
 			"",
 			loadingCard,
+			"",
+			MakeFooter([]KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}),
 		)
 
 	case 3:
@@ -814,7 +878,10 @@ func (m PurgeEverythingModel) View() string {
 				),
 			)
 
-		prompt := lipgloss.NewStyle().Foreground(MutedColor).Render("Press any key to continue")
+		prompt := MakeFooter([]KeyHint{
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		})
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Center,
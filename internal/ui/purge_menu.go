@@ -13,6 +13,7 @@ type PurgeMenuItem struct {
 	description string
 	purgeType   string
 	icon        string
+	preset      *config.Preset
 }
 
 func (i PurgeMenuItem) Title() string       { return i.icon + " " + i.title }
@@ -53,19 +54,40 @@ func NewPurgeMenuModel(cfg *config.Config, zone cloudflare.Zone) PurgeMenuModel
 			purgeType:   "prefix",
 			icon:        "📁",
 		},
+		PurgeMenuItem{
+			title:       "Purge by Sitemap",
+			description: "Purge every URL listed in a sitemap",
+			purgeType:   "sitemap",
+			icon:        "🗺️",
+		},
 		PurgeMenuItem{
 			title:       "Purge Everything",
 			description: "Clear entire cache (use with caution)",
 			purgeType:   "everything",
 			icon:        "🗑️",
 		},
+	}
+
+	// Saved presets for this zone are listed as quick actions
+	for _, p := range cfg.PresetsForZone(zone.Name, zone.ID) {
+		preset := p
+		items = append(items, PurgeMenuItem{
+			title:       preset.Name,
+			description: "Preset: purge " + preset.Describe(),
+			purgeType:   "preset",
+			icon:        "⭐",
+			preset:      &preset,
+		})
+	}
+
+	items = append(items,
 		PurgeMenuItem{
 			title:       "Back",
-			description: "Return to domain list",
+			description: "Return to zone menu",
 			purgeType:   "back",
 			icon:        "←",
 		},
-	}
+	)
 
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
@@ -84,13 +106,14 @@ func NewPurgeMenuModel(cfg *config.Config, zone cloudflare.Zone) PurgeMenuModel
 	// Compact spacing - no extra space between items
 	delegate.SetSpacing(0)
 
-	// Height needs to accommodate 6 items * 2 lines each = 12 lines minimum
-	l := list.New(items, delegate, 60, 18)
+	// Height needs to accommodate 7 items * 2 lines each = 14 lines minimum,
+	// plus any presets
+	l := list.New(items, delegate, 60, purgeMenuListHeight(len(items), 24))
 	l.SetShowTitle(false)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
-	l.SetShowPagination(false)
+	l.SetShowPagination(len(items) > 8)
 
 	return PurgeMenuModel{
 		config: cfg,
@@ -101,6 +124,16 @@ func NewPurgeMenuModel(cfg *config.Config, zone cloudflare.Zone) PurgeMenuModel
 	}
 }
 
+// purgeMenuListHeight fits every item (2 lines each) when the terminal is
+// tall enough. The built-in options always fit in the fixed height of 18.
+func purgeMenuListHeight(items, termHeight int) int {
+	height := max(items*2+4, 18)
+	if available := termHeight - 14; available < height {
+		height = max(available, 18)
+	}
+	return height
+}
+
 func (m PurgeMenuModel) Init() tea.Cmd {
 	return nil
 }
@@ -111,9 +144,8 @@ func (m PurgeMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
-		// Make list height accommodate all 6 items (2 lines each = 12 lines)
 		listWidth := min(msg.Width-10, 60)
-		listHeight := 18 // Fixed height to show all items
+		listHeight := purgeMenuListHeight(len(m.list.Items()), msg.Height)
 		if listWidth < 40 {
 			listWidth = 40
 		}
@@ -124,10 +156,10 @@ func (m PurgeMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc", "q":
-			domainModel := NewDomainListModel(m.config)
-			domainModel.width = m.width
-			domainModel.height = m.height
-			return domainModel, domainModel.Init()
+			model := NewZoneMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
 		case "enter":
 			selected := m.list.SelectedItem().(PurgeMenuItem)
 			switch selected.purgeType {
@@ -151,16 +183,26 @@ func (m PurgeMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				model.width = m.width
 				model.height = m.height
 				return model, nil
+			case "sitemap":
+				model := NewPurgeBySitemapModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
 			case "everything":
 				model := NewPurgeEverythingModel(m.config, m.zone)
 				model.width = m.width
 				model.height = m.height
 				return model, nil
+			case "preset":
+				model := NewRunPresetModel(m.config, m.zone, *selected.preset)
+				model.width = m.width
+				model.height = m.height
+				return model, nil
 			case "back":
-				domainModel := NewDomainListModel(m.config)
-				domainModel.width = m.width
-				domainModel.height = m.height
-				return domainModel, domainModel.Init()
+				model := NewZoneMenuModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, nil
 			}
 		}
 	}
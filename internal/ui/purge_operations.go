@@ -2,33 +2,41 @@ package ui
 
 import (
 	"context"
-	"strings"
+	"errors"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/siyamsarker/cfctl/internal/api"
 	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
 	"github.com/siyamsarker/cfctl/internal/utils"
 	"github.com/siyamsarker/cfctl/pkg/cloudflare"
 )
 
 type PurgeByURLModel struct {
-	config   *config.Config
-	zone     cloudflare.Zone
-	textarea textarea.Model
-	err      error
-	success  bool
-	purging  bool
-	width    int
-	height   int
+	config     *config.Config
+	zone       cloudflare.Zone
+	textarea   textarea.Model
+	purged     []string
+	err        error
+	success    bool
+	purging    bool
+	confirmKey string             // Ctrl+S or Ctrl+T pressed once with invalid entries to skip
+	cancel     context.CancelFunc // aborts the in-flight purge
+	width      int
+	height     int
 }
 
 type purgeResultMsg struct {
 	success bool
+	targets []string
 	err     error
 }
 
+// errPurgeCanceled is shown when Esc aborts a purge in flight
+var errPurgeCanceled = errors.New("purge canceled; it may have been applied in part")
+
 func NewPurgeByURLModel(cfg *config.Config, zone cloudflare.Zone) PurgeByURLModel {
 	ta := textarea.New()
 	ta.Placeholder = "Enter URLs, one per line\nExample: https://example.com/style.css"
@@ -50,65 +58,30 @@ func (m PurgeByURLModel) Init() tea.Cmd {
 	return textarea.Blink
 }
 
-func (m PurgeByURLModel) executePurge() tea.Msg {
-	// Parse URLs
-	input := m.textarea.Value()
-	lines := strings.Split(input, "\n")
-	var urls []string
-	for _, line := range lines {
-		parsed := utils.ParseCommaSeparated(line)
-		urls = append(urls, parsed...)
-	}
-
-	// Validate URLs
-	if err := utils.ValidateURLs(urls); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+func (m PurgeByURLModel) executePurge(ctx context.Context) tea.Cmd {
+	// Invalid and duplicate entries are skipped; the user confirmed this
+	// before submitting
+	urls := checkTargets(m.textarea.Value(), config.PresetURL).report.Valid
 
-	// Get account and credentials
-	account, err := m.config.GetDefaultAccount()
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+	return func() tea.Msg {
+		if err := utils.ValidateURLs(urls); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	// Create API client
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
+		req := cloudflare.PurgeRequest{
+			Files: urls,
 		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
+		if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
+			return purgeResultMsg{success: false, err: err}
 		}
-	}
-
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	// Execute purge
-	ctx := context.Background()
-	req := cloudflare.PurgeRequest{
-		Files: urls,
-	}
 
-	if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
-		return purgeResultMsg{success: false, err: err}
+		return purgeResultMsg{success: true, targets: urls}
 	}
-
-	return purgeResultMsg{success: true}
 }
 
 func (m PurgeByURLModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -119,9 +92,13 @@ func (m PurgeByURLModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
 		m.purging = false
 		if msg.success {
 			m.success = true
+			m.purged = msg.targets
 			m.err = nil
 		} else {
 			m.err = msg.err
@@ -130,6 +107,15 @@ func (m PurgeByURLModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.success {
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetURL, m.purged, m.width, m.height)
+			}
+			if msg.String() == "w" {
+				model := NewWarmupModel(m.config, m.zone, m.purged)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			}
 			model := NewPurgeMenuModel(m.config, m.zone)
 			model.width = m.width
 			model.height = m.height
@@ -138,19 +124,61 @@ func (m PurgeByURLModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "esc":
+			if m.purging {
+				// Abort the in-flight request and return to the form
+				cancelRequest(m.cancel)
+				m.purging = false
+				m.err = errPurgeCanceled
+				return m, nil
+			}
+			model := NewPurgeMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "ctrl+o":
 			if !m.purging {
-				model := NewPurgeMenuModel(m.config, m.zone)
+				model := NewImportTargetsModel(m.config, m.zone, config.PresetURL, m.textarea.Value())
 				model.width = m.width
 				model.height = m.height
-				return model, nil
+				return model, model.Init()
+			}
+		case "ctrl+t":
+			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetURL, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				spec := purgejob.Spec{URLs: valid}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
 			}
 		case "ctrl+s":
 			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetURL, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				if len(valid) > api.MaxPurgeBatchSize {
+					// Large purges run batch by batch with a progress bar
+					req := cloudflare.PurgeRequest{Files: valid}
+					return startPurgeProgress(m.config, m.zone, m, req, m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
 				m.purging = true
-				m.err = nil
-				return m, m.executePurge
+				return m, m.executePurge(ctx)
 			}
 		}
+		m.confirmKey = ""
 	}
 
 	if !m.purging && !m.success {
@@ -197,6 +225,8 @@ func (m PurgeByURLModel) View() string {
 			zoneBadge,
 			"",
 			loadingCard,
+			"",
+			MakeFooter([]KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}),
 		)
 	} else if m.success {
 		successCard := lipgloss.NewStyle().
@@ -207,7 +237,11 @@ func (m PurgeByURLModel) View() string {
 				lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Cache purged successfully!"),
 			)
 
-		prompt := lipgloss.NewStyle().Foreground(MutedColor).Render("Press any key to continue")
+		prompt := MakeFooter([]KeyHint{
+			{Key: "w", Description: "Warm cache", IsAction: true},
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		})
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Center,
@@ -245,6 +279,8 @@ func (m PurgeByURLModel) View() string {
 		// Modern footer
 		footerHints := []KeyHint{
 			{Key: "Ctrl+S", Description: "Submit", IsAction: true},
+			{Key: "Ctrl+T", Description: "Schedule", IsAction: false},
+			{Key: "Ctrl+O", Description: "Import file", IsAction: false},
 			{Key: "Esc", Description: "Cancel", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -260,6 +296,7 @@ func (m PurgeByURLModel) View() string {
 			"",
 			m.textarea.View(),
 			"",
+			renderTargetCheck(checkTargets(m.textarea.Value(), config.PresetURL), config.PresetURL, taWidth+4, m.confirmKey),
 			errorMsg,
 			lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 			footer,
@@ -275,14 +312,16 @@ func (m PurgeByURLModel) View() string {
 
 // Similar models for other purge types
 type PurgeByHostnameModel struct {
-	config   *config.Config
-	zone     cloudflare.Zone
-	textarea textarea.Model
-	err      error
-	success  bool
-	purging  bool
-	width    int
-	height   int
+	config     *config.Config
+	zone       cloudflare.Zone
+	textarea   textarea.Model
+	err        error
+	success    bool
+	purging    bool
+	confirmKey string             // Ctrl+S or Ctrl+T pressed once with invalid entries to skip
+	cancel     context.CancelFunc // aborts the in-flight purge
+	width      int
+	height     int
 }
 
 func NewPurgeByHostnameModel(cfg *config.Config, zone cloudflare.Zone) PurgeByHostnameModel {
@@ -306,60 +345,30 @@ func (m PurgeByHostnameModel) Init() tea.Cmd {
 	return textarea.Blink
 }
 
-func (m PurgeByHostnameModel) executePurge() tea.Msg {
-	input := m.textarea.Value()
-	lines := strings.Split(input, "\n")
-	var hostnames []string
-	for _, line := range lines {
-		parsed := utils.ParseCommaSeparated(line)
-		hostnames = append(hostnames, parsed...)
-	}
+func (m PurgeByHostnameModel) executePurge(ctx context.Context) tea.Cmd {
+	// Invalid and duplicate entries are skipped; the user confirmed this
+	// before submitting
+	hostnames := checkTargets(m.textarea.Value(), config.PresetHostname).report.Valid
 
-	if err := utils.ValidateHostnames(hostnames); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	account, err := m.config.GetDefaultAccount()
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+	return func() tea.Msg {
+		if err := utils.ValidateHostnames(hostnames); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	credential, err := config.GetCredential(account.Name)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
 
-	var cfg api.ClientConfig
-	if account.AuthType == "token" {
-		cfg = api.ClientConfig{
-			APIToken: credential,
-			Timeout:  m.config.API.Timeout,
-			Retries:  m.config.API.Retries,
+		req := cloudflare.PurgeRequest{
+			Hosts: hostnames,
 		}
-	} else {
-		cfg = api.ClientConfig{
-			APIKey:  credential,
-			Email:   account.Email,
-			Timeout: m.config.API.Timeout,
-			Retries: m.config.API.Retries,
+		if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
+			return purgeResultMsg{success: false, err: err}
 		}
-	}
-
-	client, err := api.NewClient(cfg)
-	if err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
 
-	ctx := context.Background()
-	req := cloudflare.PurgeRequest{
-		Hosts: hostnames,
+		return purgeResultMsg{success: true}
 	}
-
-	if err := client.PurgeCache(ctx, m.zone.ID, req); err != nil {
-		return purgeResultMsg{success: false, err: err}
-	}
-
-	return purgeResultMsg{success: true}
 }
 
 func (m PurgeByHostnameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -370,6 +379,9 @@ func (m PurgeByHostnameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
 		m.purging = false
 		if msg.success {
 			m.success = true
@@ -381,6 +393,9 @@ func (m PurgeByHostnameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.success {
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetHostname, checkTargets(m.textarea.Value(), config.PresetHostname).report.Valid, m.width, m.height)
+			}
 			model := NewPurgeMenuModel(m.config, m.zone)
 			model.width = m.width
 			model.height = m.height
@@ -389,19 +404,61 @@ func (m PurgeByHostnameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "esc":
+			if m.purging {
+				// Abort the in-flight request and return to the form
+				cancelRequest(m.cancel)
+				m.purging = false
+				m.err = errPurgeCanceled
+				return m, nil
+			}
+			model := NewPurgeMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "ctrl+o":
 			if !m.purging {
-				model := NewPurgeMenuModel(m.config, m.zone)
+				model := NewImportTargetsModel(m.config, m.zone, config.PresetHostname, m.textarea.Value())
 				model.width = m.width
 				model.height = m.height
-				return model, nil
+				return model, model.Init()
+			}
+		case "ctrl+t":
+			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetHostname, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				spec := purgejob.Spec{Hosts: valid}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
 			}
 		case "ctrl+s":
 			if !m.purging && m.textarea.Value() != "" {
+				valid, err := confirmTargets(m.textarea.Value(), config.PresetHostname, msg.String(), &m.confirmKey)
+				m.err = err
+				if valid == nil {
+					return m, nil
+				}
+				if len(valid) > api.MaxPurgeBatchSize {
+					// Large purges run batch by batch with a progress bar
+					req := cloudflare.PurgeRequest{Hosts: valid}
+					return startPurgeProgress(m.config, m.zone, m, req, m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
 				m.purging = true
-				m.err = nil
-				return m, m.executePurge
+				return m, m.executePurge(ctx)
 			}
 		}
+		m.confirmKey = ""
 	}
 
 	if !m.purging && !m.success {
@@ -448,6 +505,8 @@ func (m PurgeByHostnameModel) View() string {
 			zoneBadge,
 			"",
 			loadingCard,
+			"",
+			MakeFooter([]KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}),
 		)
 	} else if m.success {
 		successCard := lipgloss.NewStyle().
@@ -458,7 +517,10 @@ func (m PurgeByHostnameModel) View() string {
 				lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Cache purged successfully!"),
 			)
 
-		prompt := lipgloss.NewStyle().Foreground(MutedColor).Render("Press any key to continue")
+		prompt := MakeFooter([]KeyHint{
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		})
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Center,
@@ -496,6 +558,8 @@ func (m PurgeByHostnameModel) View() string {
 		// Modern footer
 		footerHints := []KeyHint{
 			{Key: "Ctrl+S", Description: "Submit", IsAction: true},
+			{Key: "Ctrl+T", Description: "Schedule", IsAction: false},
+			{Key: "Ctrl+O", Description: "Import file", IsAction: false},
 			{Key: "Esc", Description: "Cancel", IsAction: false},
 		}
 		footer := MakeFooter(footerHints)
@@ -511,6 +575,7 @@ func (m PurgeByHostnameModel) View() string {
 			"",
 			m.textarea.View(),
 			"",
+			renderTargetCheck(checkTargets(m.textarea.Value(), config.PresetHostname), config.PresetHostname, taWidth+4, m.confirmKey),
 			errorMsg,
 			lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
 			footer,
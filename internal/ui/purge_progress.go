@@ -0,0 +1,364 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// purgeBatch is a row of the progress results table
+type purgeBatch struct {
+	request cloudflare.PurgeRequest
+	done    bool
+	err     error
+}
+
+// PurgeProgressModel purges a request that spans several batches, showing
+// a progress bar while the batches stream in and a results table at the end
+type PurgeProgressModel struct {
+	config   *config.Config
+	zone     cloudflare.Zone
+	parent   tea.Model // screen that started the purge, resumed when leaving
+	client   *api.Client
+	targets  []string
+	batches  []purgeBatch
+	pending  []int // batch indexes of the running stream, in stream order
+	stream   <-chan api.BatchResult
+	cancel   context.CancelFunc
+	bar      progress.Model
+	step     int // 0: purging, 1: results
+	canceled bool
+	width    int
+	height   int
+}
+
+type purgeBatchMsg struct {
+	result api.BatchResult
+}
+
+type purgeStreamDoneMsg struct{}
+
+// startPurgeProgress opens the progress screen for req and starts purging.
+// parent receives a purgeResultMsg when the user leaves the results table.
+func startPurgeProgress(cfg *config.Config, zone cloudflare.Zone, parent tea.Model, req cloudflare.PurgeRequest, width, height int) (tea.Model, tea.Cmd) {
+	client, err := newAPIClient(cfg)
+	if err != nil {
+		return parent, func() tea.Msg { return purgeResultMsg{success: false, err: err} }
+	}
+
+	m := PurgeProgressModel{
+		config:  cfg,
+		zone:    zone,
+		parent:  parent,
+		client:  client,
+		targets: purgeTargets(req),
+		bar:     progress.New(progress.WithGradient(string(PrimaryColor), string(AccentColor))),
+		width:   width,
+		height:  height,
+	}
+	for _, batch := range api.SplitPurgeRequest(req, api.MaxPurgeBatchSize) {
+		m.batches = append(m.batches, purgeBatch{request: batch})
+	}
+
+	indexes := make([]int, len(m.batches))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return m.run(indexes)
+}
+
+// run starts a stream purging the batches at indexes
+func (m PurgeProgressModel) run(indexes []int) (tea.Model, tea.Cmd) {
+	requests := make([]cloudflare.PurgeRequest, len(indexes))
+	for i, index := range indexes {
+		m.batches[index] = purgeBatch{request: m.batches[index].request}
+		requests[i] = m.batches[index].request
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.pending = indexes
+	m.stream = m.client.PurgeCacheStream(ctx, m.zone.ID, requests)
+	m.canceled = false
+	m.step = 0
+	return m, waitForBatch(m.stream)
+}
+
+// waitForBatch reads the next batch result from a purge stream
+func waitForBatch(stream <-chan api.BatchResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-stream
+		if !ok {
+			return purgeStreamDoneMsg{}
+		}
+		return purgeBatchMsg{result: result}
+	}
+}
+
+// purgeTargets returns the URLs, hosts, tags or prefixes of a request
+func purgeTargets(req cloudflare.PurgeRequest) []string {
+	switch {
+	case len(req.Files) > 0:
+		return req.Files
+	case len(req.Hosts) > 0:
+		return req.Hosts
+	case len(req.Tags) > 0:
+		return req.Tags
+	default:
+		return req.Prefixes
+	}
+}
+
+// purgeNoun returns the singular and plural name of a request's targets
+func purgeNoun(req cloudflare.PurgeRequest) (string, string) {
+	switch {
+	case len(req.Hosts) > 0:
+		return targetNoun(config.PresetHostname)
+	case len(req.Tags) > 0:
+		return targetNoun(config.PresetTag)
+	case len(req.Prefixes) > 0:
+		return targetNoun(config.PresetPrefix)
+	default:
+		return targetNoun(config.PresetURL)
+	}
+}
+
+func (m PurgeProgressModel) Init() tea.Cmd {
+	return nil
+}
+
+// counts returns the number of finished and failed batches
+func (m PurgeProgressModel) counts() (done, failed int) {
+	for _, b := range m.batches {
+		if b.done {
+			done++
+			if b.err != nil {
+				failed++
+			}
+		}
+	}
+	return done, failed
+}
+
+// failed returns the indexes of the batches that failed
+func (m PurgeProgressModel) failed() []int {
+	var indexes []int
+	for i, b := range m.batches {
+		if b.err != nil {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (m PurgeProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case purgeBatchMsg:
+		if i := msg.result.Index - 1; i >= 0 && i < len(m.pending) {
+			index := m.pending[i]
+			m.batches[index].done = true
+			m.batches[index].err = msg.result.Err
+		}
+		return m, waitForBatch(m.stream)
+
+	case purgeStreamDoneMsg:
+		m.cancel()
+		m.step = 1
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.cancel()
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			if msg.String() == "esc" {
+				// The remaining batches report as canceled and the stream closes
+				m.canceled = true
+				m.cancel()
+			}
+		case 1:
+			switch msg.String() {
+			case "r":
+				if failed := m.failed(); len(failed) > 0 {
+					return m.run(failed)
+				}
+			case "enter", "esc":
+				return m.leave()
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// leave returns to the screen that started the purge with the outcome
+func (m PurgeProgressModel) leave() (tea.Model, tea.Cmd) {
+	result := purgeResultMsg{success: true, targets: m.targets}
+	if _, failed := m.counts(); failed > 0 {
+		result = purgeResultMsg{
+			success: false,
+			err:     fmt.Errorf("%d of %d batches failed", failed, len(m.batches)),
+		}
+	}
+
+	parent, cmd := m.parent.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return parent, tea.Batch(cmd, func() tea.Msg { return result })
+}
+
+func (m PurgeProgressModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🧹", "Purging Cache", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	singular, plural := purgeNoun(m.batches[0].request)
+	done, failed := m.counts()
+
+	m.bar.Width = dividerWidth
+	bar := m.bar.ViewAs(float64(done) / float64(len(m.batches)))
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		status := fmt.Sprintf("Batch %d of %d · %s",
+			min(done+1, len(m.batches)), len(m.batches), utils.FormatCount(len(m.targets), singular, plural))
+		if m.canceled {
+			status = "Canceling..."
+		}
+
+		rows := []string{
+			lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(status),
+			"",
+			bar,
+		}
+		if failed > 0 {
+			rows = append(rows, "", lipgloss.NewStyle().Foreground(ErrorColor).Render(
+				"✗ "+utils.FormatCount(failed, "batch failed", "batches failed")))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.JoinVertical(lipgloss.Left, bar, "", m.renderResults(dividerWidth, singular, plural))
+		if failed > 0 {
+			footerHints = append(footerHints, KeyHint{Key: "r", Description: "Retry failed", IsAction: true})
+		}
+		footerHints = append(footerHints, KeyHint{Key: "Enter", Description: "Continue", IsAction: failed == 0})
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// renderResults shows a summary line and a row per batch, listing failed
+// batches first when they don't all fit
+func (m PurgeProgressModel) renderResults(width int, singular, plural string) string {
+	done, failed := m.counts()
+	purged := done - failed
+
+	var summary string
+	switch {
+	case failed == 0:
+		summary = lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+			"✓ Purged " + utils.FormatCount(len(m.targets), singular, plural) + " in " +
+				utils.FormatCount(len(m.batches), "batch", "batches"))
+	case m.canceled:
+		summary = lipgloss.NewStyle().Foreground(WarningColor).Bold(true).Render(
+			fmt.Sprintf("Canceled: %d of %d batches purged", purged, len(m.batches)))
+	default:
+		summary = lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render(
+			fmt.Sprintf("✗ %d of %d batches failed", failed, len(m.batches)))
+	}
+
+	order := m.failed()
+	for i, b := range m.batches {
+		if b.err == nil {
+			order = append(order, i)
+		}
+	}
+
+	maxRows := max(m.height-24, 3)
+	rows := []string{summary, ""}
+	for n, i := range order {
+		if n == maxRows {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("… and %d more", len(order)-maxRows)))
+			break
+		}
+
+		b := m.batches[i]
+		status, color := "✓ Purged", SuccessColor
+		switch {
+		case errors.Is(b.err, context.Canceled):
+			status, color = "– Canceled", WarningColor
+		case b.err != nil:
+			status, color = "✗ "+b.err.Error(), ErrorColor
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Width(6).Render(fmt.Sprintf("#%d", i+1)),
+			lipgloss.NewStyle().Foreground(TextColor).Width(14).Render(utils.FormatCount(len(purgeTargets(b.request)), singular, plural)),
+			lipgloss.NewStyle().Foreground(color).Render(utils.TruncateString(status, width-20)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
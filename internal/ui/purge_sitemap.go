@@ -0,0 +1,436 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/api"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/sitemap"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+const (
+	sitemapFieldSource = iota
+	sitemapFieldSince
+	sitemapFieldGlobs
+	sitemapFieldCount
+)
+
+// PurgeBySitemapModel purges every URL listed in a sitemap
+type PurgeBySitemapModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	inputs  []textinput.Model
+	focus   int
+	spinner spinner.Model
+	urls    []string
+	total   int                // URLs in the sitemap before filtering
+	step    int                // 0: form, 1: loading sitemap, 2: preview, 3: purging, 4: done
+	cancel  context.CancelFunc // aborts the sitemap load or purge in flight
+	err     error
+	width   int
+	height  int
+}
+
+type sitemapLoadedMsg struct {
+	urls  []string
+	total int
+	err   error
+}
+
+func NewPurgeBySitemapModel(cfg *config.Config, zone cloudflare.Zone) PurgeBySitemapModel {
+	inputs := make([]textinput.Model, sitemapFieldCount)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.CharLimit = 2048
+		ti.Width = 44
+		inputs[i] = ti
+	}
+
+	inputs[sitemapFieldSource].Prompt = "Sitemap:        "
+	inputs[sitemapFieldSource].Placeholder = "https://" + zone.Name + "/sitemap.xml or ./sitemap.xml.gz"
+	inputs[sitemapFieldSource].SetValue("https://" + zone.Name + "/sitemap.xml")
+	inputs[sitemapFieldSince].Prompt = "Modified since: "
+	inputs[sitemapFieldSince].Placeholder = "YYYY-MM-DD (optional)"
+	inputs[sitemapFieldGlobs].Prompt = "Path globs:     "
+	inputs[sitemapFieldGlobs].Placeholder = "/blog/**, /docs/* (optional)"
+	inputs[sitemapFieldSource].Focus()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return PurgeBySitemapModel{
+		config:  cfg,
+		zone:    zone,
+		inputs:  inputs,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m PurgeBySitemapModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// filter builds a sitemap filter from the form inputs
+func (m PurgeBySitemapModel) filter() (sitemap.Filter, error) {
+	var f sitemap.Filter
+
+	if since := strings.TrimSpace(m.inputs[sitemapFieldSince].Value()); since != "" {
+		t, err := sitemap.ParseLastMod(since)
+		if err != nil {
+			return f, fmt.Errorf("modified since: expected YYYY-MM-DD")
+		}
+		f.Since = t
+	}
+
+	f.Globs = utils.ParseCommaSeparated(m.inputs[sitemapFieldGlobs].Value())
+	return f, nil
+}
+
+func (m PurgeBySitemapModel) loadSitemap(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		source := strings.TrimSpace(m.inputs[sitemapFieldSource].Value())
+		if source == "" {
+			return sitemapLoadedMsg{err: fmt.Errorf("sitemap URL or file is required")}
+		}
+
+		f, err := m.filter()
+		if err != nil {
+			return sitemapLoadedMsg{err: err}
+		}
+
+		entries, err := sitemap.Load(ctx, &http.Client{Timeout: 30 * time.Second}, source)
+		if err != nil {
+			return sitemapLoadedMsg{err: err}
+		}
+
+		filtered, err := f.Apply(entries)
+		if err != nil {
+			return sitemapLoadedMsg{err: err}
+		}
+
+		urls := utils.Dedupe(sitemap.URLs(filtered))
+		for i, u := range urls {
+			if err := utils.ValidateURL(u); err != nil {
+				return sitemapLoadedMsg{err: fmt.Errorf("sitemap URL %d: %w", i+1, err)}
+			}
+		}
+
+		return sitemapLoadedMsg{urls: urls, total: len(entries)}
+	}
+}
+
+func (m PurgeBySitemapModel) executePurge(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
+
+		req := cloudflare.PurgeRequest{Files: m.urls}
+		if err := client.PurgeCacheBatched(ctx, m.zone.ID, req, nil); err != nil {
+			return purgeResultMsg{success: false, err: err}
+		}
+
+		return purgeResultMsg{success: true, targets: m.urls}
+	}
+}
+
+func (m PurgeBySitemapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case sitemapLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 0
+			return m, textinput.Blink
+		}
+		if len(msg.urls) == 0 {
+			m.err = fmt.Errorf("no URLs matched (%d in sitemap)", msg.total)
+			m.step = 0
+			return m, textinput.Blink
+		}
+		m.urls = msg.urls
+		m.total = msg.total
+		m.step = 2
+		return m, nil
+
+	case purgeResultMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.success {
+			m.step = 4
+			m.err = nil
+		} else {
+			m.err = msg.err
+			m.step = 2
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "esc":
+				return m.back()
+			case "tab", "down":
+				return m.focusField((m.focus + 1) % sitemapFieldCount)
+			case "shift+tab", "up":
+				return m.focusField((m.focus + sitemapFieldCount - 1) % sitemapFieldCount)
+			case "enter":
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				m.err = nil
+				return m, tea.Batch(m.loadSitemap(ctx), m.spinner.Tick)
+			}
+			var cmd tea.Cmd
+			m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+			return m, cmd
+
+		case 2:
+			switch msg.String() {
+			case "esc":
+				m.step = 0
+				m.err = nil
+				return m, textinput.Blink
+			case "t":
+				spec := purgejob.Spec{URLs: m.urls}
+				next, cmd, err := openSchedule(m.config, m.zone, spec, m.width, m.height)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return next, cmd
+			case "enter", "y":
+				m.err = nil
+				if len(m.urls) > api.MaxPurgeBatchSize {
+					req := cloudflare.PurgeRequest{Files: m.urls}
+					return startPurgeProgress(m.config, m.zone, m, req, m.width, m.height)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 3
+				return m, tea.Batch(m.executePurge(ctx), m.spinner.Tick)
+			}
+
+		case 1, 3:
+			if msg.String() == "esc" {
+				// Abort the load or purge and go back a step
+				cancelRequest(m.cancel)
+				if m.step == 1 {
+					m.step = 0
+					return m, textinput.Blink
+				}
+				m.step = 2
+				m.err = errPurgeCanceled
+			}
+
+		case 4:
+			if msg.String() == "p" {
+				return openSavePreset(m.config, m.zone, config.PresetURL, m.urls, m.width, m.height)
+			}
+			if msg.String() == "w" {
+				model := NewWarmupModel(m.config, m.zone, m.urls)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			}
+			return m.back()
+		}
+		return m, nil
+
+	default:
+		if m.step == 1 || m.step == 3 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m PurgeBySitemapModel) focusField(index int) (tea.Model, tea.Cmd) {
+	m.inputs[m.focus].Blur()
+	m.focus = index
+	return m, m.inputs[m.focus].Focus()
+}
+
+func (m PurgeBySitemapModel) back() (tea.Model, tea.Cmd) {
+	model := NewPurgeMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m PurgeBySitemapModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🗺️", "Purge by Sitemap", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+	}
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		fields := make([]string, len(m.inputs))
+		for i, input := range m.inputs {
+			if i == m.focus {
+				fields[i] = FocusedInputStyle.Render(input.View())
+			} else {
+				fields[i] = InputStyle.Render(input.View())
+			}
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Sitemap indexes and .gz files are supported."),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, fields...),
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "Tab", Description: "Next field", IsAction: false},
+			{Key: "Enter", Description: "Load sitemap", IsAction: true},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 1, 3:
+		label := " Loading sitemap..."
+		if m.step == 3 {
+			batches := len(api.SplitPurgeRequest(cloudflare.PurgeRequest{Files: m.urls}, api.MaxPurgeBatchSize))
+			label = fmt.Sprintf(" Purging %s in %s...",
+				utils.FormatCount(len(m.urls), "URL", "URLs"),
+				utils.FormatCount(batches, "batch", "batches"))
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + label))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 2:
+		body = lipgloss.JoinVertical(lipgloss.Left, m.renderPreview(dividerWidth), "", errorMsg)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Purge", IsAction: true},
+			{Key: "t", Description: "Schedule", IsAction: false},
+			{Key: "Esc", Description: "Edit filters", IsAction: false},
+		}
+
+	case 4:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(SuccessColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+				"✓ Purged " + utils.FormatCount(len(m.urls), "URL", "URLs") + " from cache"))
+		footerHints = []KeyHint{
+			{Key: "w", Description: "Warm cache", IsAction: true},
+			{Key: "p", Description: "Save as preset", IsAction: false},
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+func (m PurgeBySitemapModel) renderPreview(width int) string {
+	maxRows := m.height - 20
+	if maxRows < 3 {
+		maxRows = 3
+	}
+
+	summary := fmt.Sprintf("%s will be purged", utils.FormatCount(len(m.urls), "URL", "URLs"))
+	if skipped := m.total - len(m.urls); skipped > 0 {
+		summary += fmt.Sprintf(" (%d filtered out or duplicate)", skipped)
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Foreground(WarningColor).Bold(true).Render(summary),
+		"",
+	}
+	for i, u := range m.urls {
+		if i == maxRows {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("… and %d more", len(m.urls)-maxRows)))
+			break
+		}
+		rows = append(rows, lipgloss.NewStyle().Foreground(TextColor).Render("  "+utils.TruncateString(u, width-4)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/targets"
+	"github.com/siyamsarker/cfctl/internal/utils"
+)
+
+// maxListedIssues is how many invalid lines are listed under a textarea
+const maxListedIssues = 4
+
+// lineIssue is an invalid entry on a line of a purge textarea
+type lineIssue struct {
+	line  int // 1-based textarea line
+	value string
+	err   error
+}
+
+// targetCheck is the live validation state of a purge textarea
+type targetCheck struct {
+	report targets.Report
+	issues []lineIssue
+}
+
+// checkTargets validates every entry of textarea input for a purge type,
+// remembering which line each invalid entry came from
+func checkTargets(value, purgeType string) targetCheck {
+	var values []string
+	var lineOf []int
+	for i, line := range strings.Split(value, "\n") {
+		for _, v := range utils.ParseCommaSeparated(line) {
+			values = append(values, v)
+			lineOf = append(lineOf, i+1)
+		}
+	}
+
+	check := targetCheck{report: targets.Check(values, targetValidator(purgeType))}
+	for _, inv := range check.report.Invalid {
+		check.issues = append(check.issues, lineIssue{line: lineOf[inv.Index-1], value: inv.Value, err: inv.Err})
+	}
+	return check
+}
+
+// needsConfirm reports whether submitting would skip invalid entries
+func (c targetCheck) needsConfirm() bool {
+	return len(c.report.Invalid) > 0 && len(c.report.Valid) > 0
+}
+
+// confirmTargets checks the entries of a purge textarea before the purge
+// (Ctrl+S) or schedule (Ctrl+T) bound to key runs. When invalid entries
+// would be skipped, the first press only records key in confirming and no
+// entries are returned; pressing the same key again returns the valid ones.
+func confirmTargets(value, purgeType, key string, confirming *string) ([]string, error) {
+	check := checkTargets(value, purgeType)
+	if len(check.report.Valid) == 0 {
+		_, plural := targetNoun(purgeType)
+		return nil, fmt.Errorf("no valid %s to purge", plural)
+	}
+	if check.needsConfirm() && *confirming != key {
+		*confirming = key
+		return nil, nil
+	}
+	*confirming = ""
+	return check.report.Valid, nil
+}
+
+// renderTargetCheck shows a one-line summary of the entries followed by
+// the invalid lines, highlighted. confirming is the key pressed once with
+// invalid entries to skip; it adds the prompt to press it again.
+func renderTargetCheck(c targetCheck, purgeType string, width int, confirming string) string {
+	if c.report.Total == 0 {
+		return ""
+	}
+
+	singular, plural := targetNoun(purgeType)
+	parts := []string{
+		lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + utils.FormatCount(len(c.report.Valid), "valid "+singular, "valid "+plural)),
+	}
+	if n := len(c.report.Invalid); n > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ "+fmt.Sprintf("%d invalid", n)))
+	}
+	if n := c.report.Duplicates; n > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(WarningColor).Render("• "+utils.FormatCount(n, "duplicate", "duplicates")))
+	}
+	sep := lipgloss.NewStyle().Foreground(MutedColor).Render("  ")
+	rows := []string{strings.Join(parts, sep)}
+
+	issueStyle := lipgloss.NewStyle().Foreground(ErrorColor)
+	for i, issue := range c.issues {
+		if i == maxListedIssues {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("… and %d more invalid", len(c.issues)-maxListedIssues)))
+			break
+		}
+		rows = append(rows, issueStyle.Render(utils.TruncateString(
+			fmt.Sprintf("Line %d: %s — %v", issue.line, issue.value, issue.err), width)))
+	}
+
+	var action string
+	switch confirming {
+	case "ctrl+s":
+		action = "Press Ctrl+S again to purge"
+	case "ctrl+t":
+		action = "Press Ctrl+T again to schedule"
+	}
+	if action != "" {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(WarningColor).Bold(true).Render(
+			fmt.Sprintf("%s %s and skip %d invalid",
+				action, utils.FormatCount(len(c.report.Valid), singular, plural), len(c.report.Invalid))))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,1022 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/redirects"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// Redirect screen tabs
+const (
+	redirectTabRules = iota
+	redirectTabPageRules
+	redirectTabBulk
+)
+
+var redirectTabNames = []string{"Single Redirects", "Page Rules", "Bulk Lists"}
+
+type RedirectRuleItem struct {
+	rule     cloudflare.RedirectRule
+	position int
+}
+
+func (i RedirectRuleItem) Title() string {
+	state := "●"
+	if !i.rule.Enabled {
+		state = "○"
+	}
+	return fmt.Sprintf("%d. %s %s", i.position, state, redirects.RuleSource(i.rule))
+}
+
+func (i RedirectRuleItem) Description() string {
+	return fmt.Sprintf("→ %s (%d)", redirects.RuleTarget(i.rule), i.rule.StatusCode)
+}
+
+func (i RedirectRuleItem) FilterValue() string {
+	return i.rule.Expression + " " + redirects.RuleTarget(i.rule)
+}
+
+type PageRuleItem struct {
+	rule     cloudflare.PageRule
+	position int
+}
+
+func (i PageRuleItem) Title() string {
+	state := "●"
+	if i.rule.Status != "active" {
+		state = "○"
+	}
+	return fmt.Sprintf("%d. %s %s", i.position, state, i.rule.URL)
+}
+
+func (i PageRuleItem) Description() string {
+	if target, status, ok := i.rule.Forwarding(); ok {
+		return fmt.Sprintf("→ %s (%d)", target, status)
+	}
+	ids := make([]string, len(i.rule.Actions))
+	for j, a := range i.rule.Actions {
+		ids[j] = a.ID
+	}
+	return strings.Join(ids, ", ")
+}
+
+func (i PageRuleItem) FilterValue() string { return i.rule.URL }
+
+type BulkListItem struct {
+	list cloudflare.BulkRedirectList
+}
+
+func (i BulkListItem) Title() string { return i.list.Name }
+
+func (i BulkListItem) Description() string {
+	desc := fmt.Sprintf("%d redirects", i.list.NumItems)
+	if i.list.Description != "" {
+		desc += " | " + i.list.Description
+	}
+	return desc
+}
+
+func (i BulkListItem) FilterValue() string { return i.list.Name }
+
+type BulkRedirectItem struct {
+	redirect cloudflare.BulkRedirect
+}
+
+func (i BulkRedirectItem) Title() string { return i.redirect.SourceURL }
+
+func (i BulkRedirectItem) Description() string {
+	return fmt.Sprintf("→ %s (%d)", i.redirect.TargetURL, i.redirect.StatusCode)
+}
+
+func (i BulkRedirectItem) FilterValue() string { return i.redirect.SourceURL }
+
+// Editor field indexes
+const (
+	redirectFieldSource = iota
+	redirectFieldTarget
+	redirectFieldStatus
+	redirectFieldPreserveQuery
+	redirectFieldEnabled
+	redirectFieldDescription
+)
+
+// pageRuleFields are the editor fields that apply to forwarding Page Rules
+var pageRuleFields = []int{redirectFieldSource, redirectFieldTarget, redirectFieldStatus, redirectFieldEnabled}
+
+// RedirectsModel lists and edits the Single Redirects and forwarding Page
+// Rules of a zone, and browses the Bulk Redirect lists of its account
+type RedirectsModel struct {
+	config      *config.Config
+	zone        cloudflare.Zone
+	list        list.Model
+	spinner     spinner.Model
+	tab         int
+	rules       []cloudflare.RedirectRule
+	pageRules   []cloudflare.PageRule
+	bulkLists   []cloudflare.BulkRedirectList
+	bulkErr     error                        // bulk lists failed to load, other tabs still work
+	bulkList    *cloudflare.BulkRedirectList // list whose items are shown in step 5
+	inputs      []textinput.Model
+	focusIndex  int                      // index into editorFields()
+	editingRule *cloudflare.RedirectRule // rule being edited, nil when creating
+	editingPage *cloudflare.PageRule     // Page Rule being edited, nil when creating
+	ctx         context.Context          // context of the latest load
+	cancel      context.CancelFunc       // aborts the in-flight request
+	step        int                      // 0: loading, 1: list, 2: edit, 3: confirm delete, 4: saving, 5: bulk items
+	saveFrom    int                      // step to return to if saving fails
+	status      string
+	err         error
+	width       int
+	height      int
+}
+
+type redirectsLoadedMsg struct {
+	rules     []cloudflare.RedirectRule
+	pageRules []cloudflare.PageRule
+	bulkLists []cloudflare.BulkRedirectList
+	bulkErr   error
+	err       error
+}
+
+type bulkRedirectsLoadedMsg struct {
+	items []cloudflare.BulkRedirect
+	err   error
+}
+
+type redirectSavedMsg struct {
+	status string
+	err    error
+}
+
+func NewRedirectsModel(cfg *config.Config, zone cloudflare.Zone) RedirectsModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := RedirectsModel{
+		config:  cfg,
+		zone:    zone,
+		list:    l,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		width:   80,
+		height:  24,
+	}
+	m.initInputs()
+	return m
+}
+
+func (m *RedirectsModel) initInputs() {
+	m.inputs = make([]textinput.Model, 6)
+
+	m.inputs[redirectFieldSource] = textinput.New()
+	m.inputs[redirectFieldSource].CharLimit = 4096
+
+	m.inputs[redirectFieldTarget] = textinput.New()
+	m.inputs[redirectFieldTarget].Prompt = "Target:      "
+	m.inputs[redirectFieldTarget].Placeholder = "https://example.com/new"
+	m.inputs[redirectFieldTarget].CharLimit = 4096
+
+	m.inputs[redirectFieldStatus] = textinput.New()
+	m.inputs[redirectFieldStatus].Prompt = "Status:      "
+	m.inputs[redirectFieldStatus].CharLimit = 3
+
+	m.inputs[redirectFieldPreserveQuery] = textinput.New()
+	m.inputs[redirectFieldPreserveQuery].Prompt = "Keep query (y/n): "
+	m.inputs[redirectFieldPreserveQuery].Placeholder = "n"
+	m.inputs[redirectFieldPreserveQuery].CharLimit = 3
+
+	m.inputs[redirectFieldEnabled] = textinput.New()
+	m.inputs[redirectFieldEnabled].Prompt = "Enabled (y/n): "
+	m.inputs[redirectFieldEnabled].Placeholder = "y"
+	m.inputs[redirectFieldEnabled].CharLimit = 3
+
+	m.inputs[redirectFieldDescription] = textinput.New()
+	m.inputs[redirectFieldDescription].Prompt = "Description: "
+	m.inputs[redirectFieldDescription].Placeholder = "blank = Redirect <source>"
+	m.inputs[redirectFieldDescription].CharLimit = 200
+
+	for i := range m.inputs {
+		m.inputs[i].Width = 44
+	}
+}
+
+func (m RedirectsModel) Init() tea.Cmd {
+	return tea.Batch(m.loadRedirects(m.ctx), m.spinner.Tick)
+}
+
+// reload starts loading every tab again and shows the spinner
+func (m *RedirectsModel) reload() tea.Cmd {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.step = 0
+	return tea.Batch(m.loadRedirects(m.ctx), m.spinner.Tick)
+}
+
+func (m RedirectsModel) loadRedirects(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return redirectsLoadedMsg{err: err}
+		}
+
+		rules, err := client.ListRedirectRules(ctx, m.zone.ID)
+		if err != nil {
+			return redirectsLoadedMsg{err: err}
+		}
+		pageRules, err := client.ListPageRules(ctx, m.zone.ID)
+		if err != nil {
+			return redirectsLoadedMsg{err: err}
+		}
+
+		msg := redirectsLoadedMsg{rules: rules, pageRules: pageRules}
+		if m.zone.Account.ID == "" {
+			msg.bulkErr = fmt.Errorf("the zone's account is unknown")
+		} else {
+			msg.bulkLists, msg.bulkErr = client.ListBulkRedirectLists(ctx, m.zone.Account.ID)
+		}
+		if isCanceled(msg.bulkErr) {
+			msg.err = msg.bulkErr
+		}
+		return msg
+	}
+}
+
+func (m RedirectsModel) loadBulkRedirects(ctx context.Context, listID string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return bulkRedirectsLoadedMsg{err: err}
+		}
+
+		items, err := client.ListBulkRedirects(ctx, m.zone.Account.ID, listID)
+		return bulkRedirectsLoadedMsg{items: items, err: err}
+	}
+}
+
+func (m RedirectsModel) save(ctx context.Context) tea.Cmd {
+	tab := m.tab
+	rule, ruleErr := m.redirectFromInputs()
+	page, pageErr := m.pageRuleFromInputs()
+	editingRule, editingPage := m.editingRule, m.editingPage
+
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return redirectSavedMsg{err: err}
+		}
+
+		if tab == redirectTabPageRules {
+			if pageErr != nil {
+				return redirectSavedMsg{err: pageErr}
+			}
+			if editingPage == nil {
+				return redirectSavedMsg{status: "Page Rule created", err: client.CreatePageRule(ctx, m.zone.ID, page)}
+			}
+			return redirectSavedMsg{status: "Page Rule updated", err: client.UpdatePageRule(ctx, m.zone.ID, page)}
+		}
+
+		if ruleErr != nil {
+			return redirectSavedMsg{err: ruleErr}
+		}
+		if editingRule == nil {
+			return redirectSavedMsg{status: "Redirect created", err: client.CreateRedirectRule(ctx, m.zone.ID, rule)}
+		}
+		return redirectSavedMsg{status: "Redirect updated", err: client.UpdateRedirectRule(ctx, m.zone.ID, rule)}
+	}
+}
+
+func (m RedirectsModel) deleteSelected(ctx context.Context) tea.Cmd {
+	tab := m.tab
+	selected := m.list.SelectedItem()
+
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return redirectSavedMsg{err: err}
+		}
+
+		switch item := selected.(type) {
+		case RedirectRuleItem:
+			return redirectSavedMsg{status: "Redirect deleted", err: client.DeleteRedirectRule(ctx, m.zone.ID, item.rule.ID)}
+		case PageRuleItem:
+			return redirectSavedMsg{status: "Page Rule deleted", err: client.DeletePageRule(ctx, m.zone.ID, item.rule.ID)}
+		}
+		return redirectSavedMsg{err: fmt.Errorf("nothing to delete on the %s tab", redirectTabNames[tab])}
+	}
+}
+
+// redirectFromInputs builds a Single Redirect from the editor fields. The
+// source field takes a URL, turned into a match expression, or a raw
+// expression; expressions always contain spaces and URLs never do.
+func (m RedirectsModel) redirectFromInputs() (cloudflare.RedirectRule, error) {
+	rule := cloudflare.RedirectRule{}
+	if m.editingRule != nil {
+		rule.ID = m.editingRule.ID
+		rule.Raw = m.editingRule.Raw
+	}
+
+	source := strings.TrimSpace(m.inputs[redirectFieldSource].Value())
+	if strings.ContainsAny(source, " \t") {
+		rule.Expression = source
+	} else {
+		if err := redirects.ValidateSource(source); err != nil {
+			return rule, err
+		}
+		rule.Expression = redirects.Expression(source)
+	}
+
+	target := strings.TrimSpace(m.inputs[redirectFieldTarget].Value())
+	switch {
+	case target == "":
+		return rule, fmt.Errorf("target is required")
+	case strings.Contains(target, "://") && !strings.ContainsAny(target, " \t"):
+		if err := utils.ValidateURL(target); err != nil {
+			return rule, fmt.Errorf("target: %w", err)
+		}
+		rule.TargetURL = target
+	default:
+		rule.TargetExpression = target
+	}
+
+	status, err := parseRedirectStatus(m.inputs[redirectFieldStatus].Value(), redirects.StatusCodes)
+	if err != nil {
+		return rule, err
+	}
+	rule.StatusCode = status
+
+	if rule.PreserveQueryString, err = parseYesNo(m.inputs[redirectFieldPreserveQuery].Value(), false); err != nil {
+		return rule, fmt.Errorf("keep query: %w", err)
+	}
+	if rule.Enabled, err = parseYesNo(m.inputs[redirectFieldEnabled].Value(), true); err != nil {
+		return rule, fmt.Errorf("enabled: %w", err)
+	}
+
+	rule.Description = strings.TrimSpace(m.inputs[redirectFieldDescription].Value())
+	if rule.Description == "" {
+		rule.Description = "Redirect " + redirects.RuleSource(rule)
+	}
+	return rule, nil
+}
+
+// pageRuleStatusCodes are the status codes a forwarding Page Rule accepts
+var pageRuleStatusCodes = []int{301, 302}
+
+// pageRuleFromInputs builds a forwarding Page Rule from the editor fields,
+// preserving any other actions of the rule being edited
+func (m RedirectsModel) pageRuleFromInputs() (cloudflare.PageRule, error) {
+	rule := cloudflare.PageRule{}
+	if m.editingPage != nil {
+		rule.ID = m.editingPage.ID
+		rule.Priority = m.editingPage.Priority
+		for _, a := range m.editingPage.Actions {
+			if a.ID != "forwarding_url" {
+				rule.Actions = append(rule.Actions, a)
+			}
+		}
+	}
+
+	rule.URL = strings.TrimSpace(m.inputs[redirectFieldSource].Value())
+	if rule.URL == "" {
+		return rule, fmt.Errorf("URL pattern is required")
+	}
+
+	target := strings.TrimSpace(m.inputs[redirectFieldTarget].Value())
+	if err := utils.ValidateURL(target); err != nil {
+		return rule, fmt.Errorf("target: %w", err)
+	}
+
+	status, err := parseRedirectStatus(m.inputs[redirectFieldStatus].Value(), pageRuleStatusCodes)
+	if err != nil {
+		return rule, err
+	}
+
+	enabled, err := parseYesNo(m.inputs[redirectFieldEnabled].Value(), true)
+	if err != nil {
+		return rule, fmt.Errorf("enabled: %w", err)
+	}
+	rule.Status = "disabled"
+	if enabled {
+		rule.Status = "active"
+	}
+
+	rule.Actions = append(rule.Actions, cloudflare.PageRuleAction{
+		ID: "forwarding_url",
+		Value: map[string]interface{}{
+			"url":         target,
+			"status_code": status,
+		},
+	})
+	return rule, nil
+}
+
+// parseRedirectStatus parses a status code field, blank meaning 301
+func parseRedirectStatus(value string, allowed []int) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return redirects.DefaultStatus, nil
+	}
+
+	code, err := strconv.Atoi(value)
+	if err == nil {
+		for _, c := range allowed {
+			if c == code {
+				return code, nil
+			}
+		}
+	}
+
+	codes := make([]string, len(allowed))
+	for i, c := range allowed {
+		codes[i] = strconv.Itoa(c)
+	}
+	return 0, fmt.Errorf("status must be one of %s", strings.Join(codes, ", "))
+}
+
+// editorFields returns the editor fields of the current tab in focus order
+func (m RedirectsModel) editorFields() []int {
+	if m.tab == redirectTabPageRules {
+		return pageRuleFields
+	}
+	return []int{redirectFieldSource, redirectFieldTarget, redirectFieldStatus, redirectFieldPreserveQuery, redirectFieldEnabled, redirectFieldDescription}
+}
+
+// startEditing fills the editor with the selected item, or clears it when
+// creating one
+func (m *RedirectsModel) startEditing(create bool) tea.Cmd {
+	m.editingRule = nil
+	m.editingPage = nil
+	m.err = nil
+	m.status = ""
+	for i := range m.inputs {
+		m.inputs[i].SetValue("")
+	}
+
+	if m.tab == redirectTabPageRules {
+		m.inputs[redirectFieldSource].Prompt = "URL pattern: "
+		m.inputs[redirectFieldSource].Placeholder = "example.com/old/*"
+		m.inputs[redirectFieldStatus].Placeholder = "301 or 302"
+	} else {
+		m.inputs[redirectFieldSource].Prompt = "Source:      "
+		m.inputs[redirectFieldSource].Placeholder = "example.com/old or an expression"
+		m.inputs[redirectFieldStatus].Placeholder = "301, 302, 307 or 308"
+	}
+
+	if !create {
+		switch item := m.list.SelectedItem().(type) {
+		case RedirectRuleItem:
+			rule := item.rule
+			m.editingRule = &rule
+			m.inputs[redirectFieldSource].SetValue(redirects.RuleSource(rule))
+			m.inputs[redirectFieldTarget].SetValue(redirects.RuleTarget(rule))
+			m.inputs[redirectFieldStatus].SetValue(strconv.Itoa(rule.StatusCode))
+			m.inputs[redirectFieldPreserveQuery].SetValue(formatYesNo(rule.PreserveQueryString))
+			m.inputs[redirectFieldEnabled].SetValue(formatYesNo(rule.Enabled))
+			m.inputs[redirectFieldDescription].SetValue(rule.Description)
+		case PageRuleItem:
+			target, status, ok := item.rule.Forwarding()
+			if !ok {
+				m.err = fmt.Errorf("only forwarding Page Rules can be edited here")
+				return nil
+			}
+			rule := item.rule
+			m.editingPage = &rule
+			m.inputs[redirectFieldSource].SetValue(rule.URL)
+			m.inputs[redirectFieldTarget].SetValue(target)
+			m.inputs[redirectFieldStatus].SetValue(strconv.Itoa(status))
+			m.inputs[redirectFieldEnabled].SetValue(formatYesNo(rule.Status == "active"))
+		default:
+			return nil
+		}
+	}
+
+	m.step = 2
+	m.focusIndex = 0
+	return m.updateFocus()
+}
+
+func (m *RedirectsModel) updateFocus() tea.Cmd {
+	focused := m.editorFields()[m.focusIndex]
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := range m.inputs {
+		if i == focused {
+			cmds[i] = m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// showTab fills the list with the items of a tab
+func (m *RedirectsModel) showTab(tab int) {
+	if tab != m.tab {
+		m.list.Select(0)
+	}
+	m.tab = tab
+
+	var items []list.Item
+	switch tab {
+	case redirectTabRules:
+		for i, rule := range m.rules {
+			items = append(items, RedirectRuleItem{rule: rule, position: i + 1})
+		}
+	case redirectTabPageRules:
+		for i, rule := range m.pageRules {
+			items = append(items, PageRuleItem{rule: rule, position: i + 1})
+		}
+	case redirectTabBulk:
+		for _, l := range m.bulkLists {
+			items = append(items, BulkListItem{list: l})
+		}
+	}
+
+	index := m.list.Index()
+	m.list.SetItems(items)
+	if index < len(items) {
+		m.list.Select(index)
+	}
+}
+
+func (m RedirectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-16, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case redirectsLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		m.step = 1
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+
+		m.rules = msg.rules
+		m.pageRules = msg.pageRules
+		m.bulkLists = msg.bulkLists
+		m.bulkErr = msg.bulkErr
+		m.showTab(m.tab)
+		return m, nil
+
+	case bulkRedirectsLoadedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.bulkList = nil
+			m.step = 1
+			return m, nil
+		}
+
+		items := make([]list.Item, len(msg.items))
+		for i, r := range msg.items {
+			items[i] = BulkRedirectItem{redirect: r}
+		}
+		m.list.SetItems(items)
+		m.list.Select(0)
+		m.step = 5
+		return m, nil
+
+	case redirectSavedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			// Return to the editor so the user can fix the input
+			m.step = m.saveFrom
+			if m.step == 2 {
+				return m, m.updateFocus()
+			}
+			return m, nil
+		}
+		m.status = msg.status
+		m.err = nil
+		return m, m.reload()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0, 4:
+			if msg.String() != "esc" {
+				return m, nil
+			}
+			cancelRequest(m.cancel)
+			switch {
+			case m.step == 4:
+				m.step = m.saveFrom
+				m.err = errChangeCanceled
+				if m.step == 2 {
+					return m, m.updateFocus()
+				}
+				return m, nil
+			case m.bulkList != nil:
+				m.bulkList = nil
+				m.step = 1
+				m.showTab(m.tab)
+				return m, nil
+			}
+			return m.back()
+
+		case 1:
+			switch msg.String() {
+			case "esc", "q":
+				return m.back()
+			case "tab", "right", "l":
+				m.err = nil
+				m.showTab((m.tab + 1) % len(redirectTabNames))
+				return m, nil
+			case "shift+tab", "left", "h":
+				m.err = nil
+				m.showTab((m.tab + len(redirectTabNames) - 1) % len(redirectTabNames))
+				return m, nil
+			case "1", "2", "3":
+				m.err = nil
+				m.showTab(int(msg.String()[0] - '1'))
+				return m, nil
+			case "r":
+				m.err = nil
+				return m, m.reload()
+			}
+
+			if m.tab == redirectTabBulk {
+				if msg.String() == "enter" {
+					if item, ok := m.list.SelectedItem().(BulkListItem); ok {
+						l := item.list
+						m.bulkList = &l
+						m.err = nil
+						ctx, cancel := context.WithCancel(context.Background())
+						m.cancel = cancel
+						m.step = 0
+						return m, tea.Batch(m.loadBulkRedirects(ctx, l.ID), m.spinner.Tick)
+					}
+					return m, nil
+				}
+				break
+			}
+
+			switch msg.String() {
+			case "n":
+				return m, m.startEditing(true)
+			case "enter", "e":
+				return m, m.startEditing(false)
+			case "d", "delete":
+				if m.list.SelectedItem() != nil {
+					m.step = 3
+					m.err = nil
+				}
+				return m, nil
+			}
+
+		case 2:
+			fields := m.editorFields()
+			switch msg.String() {
+			case "esc":
+				m.step = 1
+				m.err = nil
+				return m, nil
+			case "ctrl+s":
+				var err error
+				if m.tab == redirectTabPageRules {
+					_, err = m.pageRuleFromInputs()
+				} else {
+					_, err = m.redirectFromInputs()
+				}
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 4
+				m.saveFrom = 2
+				m.err = nil
+				return m, tea.Batch(m.save(ctx), m.spinner.Tick)
+			case "tab", "down", "enter":
+				m.focusIndex = (m.focusIndex + 1) % len(fields)
+				return m, m.updateFocus()
+			case "shift+tab", "up":
+				m.focusIndex--
+				if m.focusIndex < 0 {
+					m.focusIndex = len(fields) - 1
+				}
+				return m, m.updateFocus()
+			}
+
+			field := fields[m.focusIndex]
+			var cmd tea.Cmd
+			m.inputs[field], cmd = m.inputs[field].Update(msg)
+			return m, cmd
+
+		case 3:
+			switch msg.String() {
+			case "y", "Y":
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 4
+				m.saveFrom = 1
+				return m, tea.Batch(m.deleteSelected(ctx), m.spinner.Tick)
+			case "n", "N", "esc":
+				m.step = 1
+				return m, nil
+			}
+			return m, nil
+
+		case 5:
+			switch msg.String() {
+			case "esc", "q":
+				m.bulkList = nil
+				m.step = 1
+				m.showTab(m.tab)
+				return m, nil
+			}
+		}
+
+	case spinner.TickMsg:
+		if m.step == 0 || m.step == 4 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.step == 1 || m.step == 5 {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m RedirectsModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m RedirectsModel) View() string {
+	// Responsive sizing
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("↪", "Redirects", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	tabs := make([]string, len(redirectTabNames))
+	for i, name := range redirectTabNames {
+		label := fmt.Sprintf(" %d:%s ", i+1, name)
+		if i == m.tab {
+			tabs[i] = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Underline(true).Render(label)
+		} else {
+			tabs[i] = lipgloss.NewStyle().Foreground(MutedColor).Render(label)
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+
+	var errorMsg string
+	if m.err != nil {
+		errorMsg = lipgloss.NewStyle().
+			Foreground(ErrorColor).
+			Render("✗ " + m.err.Error())
+	}
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0, 4:
+		label := "Loading redirects..."
+		switch {
+		case m.step == 4:
+			label = "Saving changes..."
+		case m.bulkList != nil:
+			label = "Loading " + m.bulkList.Name + "..."
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " " + label))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+
+	case 1:
+		body = m.renderTab()
+
+		var status string
+		if m.status != "" {
+			status = lipgloss.NewStyle().Foreground(SuccessColor).Render("✓ " + m.status)
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, tabBar, "", body, "", status, errorMsg)
+
+		if m.tab == redirectTabBulk {
+			footerHints = []KeyHint{
+				{Key: "Enter", Description: "Show Redirects", IsAction: true},
+				{Key: "Tab/1-3", Description: "Switch", IsAction: false},
+				{Key: "r", Description: "Refresh", IsAction: false},
+				{Key: "Esc", Description: "Back", IsAction: false},
+			}
+		} else {
+			footerHints = []KeyHint{
+				{Key: "n", Description: "New", IsAction: true},
+				{Key: "Enter", Description: "Edit", IsAction: false},
+				{Key: "d", Description: "Delete", IsAction: false},
+				{Key: "Tab/1-3", Description: "Switch", IsAction: false},
+				{Key: "Esc", Description: "Back", IsAction: false},
+			}
+		}
+
+	case 2:
+		heading := "New Redirect"
+		switch {
+		case m.tab == redirectTabPageRules && m.editingPage != nil:
+			heading = "Edit Forwarding Page Rule"
+		case m.tab == redirectTabPageRules:
+			heading = "New Forwarding Page Rule"
+		case m.editingRule != nil:
+			heading = "Edit Redirect"
+		}
+
+		fields := m.editorFields()
+		rendered := make([]string, len(fields))
+		for i, field := range fields {
+			style := InputStyle
+			if i == m.focusIndex {
+				style = FocusedInputStyle
+			}
+			rendered[i] = style.Width(min(m.width-14, 64)).Render(m.inputs[field].View())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(heading),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, rendered...),
+			"",
+			errorMsg,
+		)
+
+		footerHints = []KeyHint{
+			{Key: "Ctrl+S", Description: "Save", IsAction: true},
+			{Key: "Tab", Description: "Next Field", IsAction: false},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 3:
+		var name string
+		switch item := m.list.SelectedItem().(type) {
+		case RedirectRuleItem:
+			name = redirects.RuleSource(item.rule)
+		case PageRuleItem:
+			name = item.rule.URL
+		}
+
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ErrorColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Foreground(ErrorColor).Bold(true).Render("⚠ Delete this redirect?"),
+				"",
+				lipgloss.NewStyle().Foreground(TextColor).Render(name),
+			))
+
+		footerHints = []KeyHint{
+			{Key: "Y", Description: "Delete", IsAction: true},
+			{Key: "N", Description: "Cancel", IsAction: false},
+		}
+
+	case 5:
+		heading := lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.bulkList.Name)
+		if len(m.list.Items()) == 0 {
+			body = lipgloss.NewStyle().Foreground(MutedColor).Render("This list has no redirects.")
+		} else {
+			body = m.list.View()
+		}
+		hint := lipgloss.NewStyle().Foreground(MutedColor).Render("Change list items with `cfctl redirects bulk import`.")
+		body = lipgloss.JoinVertical(lipgloss.Left, heading, "", body, "", hint)
+
+		footerHints = []KeyHint{
+			{Key: "↑↓", Description: "Navigate", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// renderTab renders the list of the current tab, or why it is empty
+func (m RedirectsModel) renderTab() string {
+	muted := lipgloss.NewStyle().Foreground(MutedColor)
+
+	switch {
+	case m.tab == redirectTabBulk && m.bulkErr != nil:
+		return lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ Bulk Redirect lists unavailable: " + m.bulkErr.Error())
+	case len(m.list.Items()) > 0:
+		return m.list.View()
+	case m.err != nil:
+		return ""
+	}
+
+	switch m.tab {
+	case redirectTabRules:
+		return muted.Render("No Single Redirects configured. Press 'n' to create one.")
+	case redirectTabPageRules:
+		return muted.Render("No Page Rules configured. Press 'n' to create a forwarding rule.")
+	}
+	return muted.Render("No Bulk Redirect lists in this account. Create one with `cfctl redirects bulk import`.")
+}
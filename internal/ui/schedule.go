@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/internal/schedule"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// SchedulePurgeModel queues a purge for "cfctl scheduler run" to execute
+// later, either once or on a cron schedule
+type SchedulePurgeModel struct {
+	config *config.Config
+	zone   cloudflare.Zone
+	spec   purgejob.Spec
+	input  textinput.Model
+	job    *schedule.Job
+	step   int // 0: input, 1: scheduled
+	err    error
+	width  int
+	height int
+}
+
+func NewSchedulePurgeModel(cfg *config.Config, zone cloudflare.Zone, spec purgejob.Spec) SchedulePurgeModel {
+	ti := textinput.New()
+	ti.Placeholder = "+30m, 2026-05-01 09:00 or 0 */6 * * *"
+	ti.Prompt = "When: "
+	ti.CharLimit = 64
+	ti.Width = 40
+	ti.Focus()
+
+	spec.Zone = zone.Name
+
+	return SchedulePurgeModel{
+		config: cfg,
+		zone:   zone,
+		spec:   spec,
+		input:  ti,
+		width:  80,
+		height: 24,
+	}
+}
+
+// openSchedule validates spec for zone and opens the schedule screen at the
+// size of the purge screen it was opened from. Invalid targets are returned
+// as an error so they are reported on the purge screen itself.
+func openSchedule(cfg *config.Config, zone cloudflare.Zone, spec purgejob.Spec, width, height int) (tea.Model, tea.Cmd, error) {
+	spec.Zone = zone.Name
+	if err := spec.Validate(); err != nil {
+		return nil, nil, err
+	}
+	model := NewSchedulePurgeModel(cfg, zone, spec)
+	model.width = width
+	model.height = height
+	return model, model.Init(), nil
+}
+
+func (m SchedulePurgeModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// isCronExpression reports whether value looks like a cron expression
+// rather than a time
+func isCronExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "@") || len(strings.Fields(value)) == 5
+}
+
+// buildJob turns the input into a queued job
+func (m SchedulePurgeModel) buildJob() (schedule.Job, error) {
+	value := strings.TrimSpace(m.input.Value())
+	if value == "" {
+		return schedule.Job{}, fmt.Errorf("enter a time or cron expression")
+	}
+
+	var accountName string
+	if account, err := m.config.GetDefaultAccount(); err == nil {
+		accountName = account.Name
+	}
+
+	if isCronExpression(value) {
+		return schedule.NewRecurring(accountName, m.spec, value)
+	}
+	at, err := schedule.ParseTime(value)
+	if err != nil {
+		return schedule.Job{}, err
+	}
+	return schedule.NewOnce(accountName, m.spec, at)
+}
+
+func (m SchedulePurgeModel) save() (tea.Model, tea.Cmd) {
+	job, err := m.buildJob()
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	queue, err := schedule.DefaultQueue()
+	if err == nil {
+		err = queue.Add(job)
+	}
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	m.job = &job
+	m.err = nil
+	m.step = 1
+	return m, nil
+}
+
+func (m SchedulePurgeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.step == 1 {
+			return m.back()
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m.back()
+		case "enter":
+			return m.save()
+		}
+	}
+
+	if m.step == 0 {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m SchedulePurgeModel) back() (tea.Model, tea.Cmd) {
+	model := NewPurgeMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+// preview describes when the current input would run
+func (m SchedulePurgeModel) preview() string {
+	value := strings.TrimSpace(m.input.Value())
+	if value == "" {
+		return ""
+	}
+
+	if isCronExpression(value) {
+		cron, err := schedule.ParseCron(value)
+		if err != nil {
+			return "✗ " + err.Error()
+		}
+		next := cron.Next(time.Now())
+		if next.IsZero() {
+			return "✗ expression never matches"
+		}
+		return "Repeats, next run " + next.Format("Mon 2006-01-02 15:04")
+	}
+
+	at, err := schedule.ParseTime(value)
+	if err != nil {
+		return "✗ " + err.Error()
+	}
+	return "Runs once at " + at.Local().Format("Mon 2006-01-02 15:04")
+}
+
+func (m SchedulePurgeModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("⏰", "Schedule Purge", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	summary := lipgloss.NewStyle().Foreground(TextColor).Render("Purge " + m.spec.Summary())
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		var status string
+		if preview := m.preview(); strings.HasPrefix(preview, "✗") {
+			status = lipgloss.NewStyle().Foreground(ErrorColor).Render(preview)
+		} else {
+			status = lipgloss.NewStyle().Foreground(AccentColor).Render(preview)
+		}
+
+		var errorMsg string
+		if m.err != nil {
+			errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			summary,
+			"",
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Enter a time (\"+2h\", \"2026-05-01 09:00\") or a cron"),
+			lipgloss.NewStyle().Foreground(MutedColor).Render("expression (\"0 3 * * *\", \"@daily\")."),
+			"",
+			FocusedInputStyle.Render(m.input.View()),
+			status,
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Schedule", IsAction: true},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(SuccessColor).
+				Padding(1, 2).
+				Render(lipgloss.JoinVertical(
+					lipgloss.Left,
+					lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Scheduled job "+m.job.ID),
+					lipgloss.NewStyle().Foreground(TextColor).Render("Next run: "+m.job.NextRun.Local().Format("Mon 2006-01-02 15:04")),
+				)),
+			"",
+			lipgloss.NewStyle().Foreground(MutedColor).Render(utils.TruncateString(
+				"Jobs run while \"cfctl scheduler run\" is active.", dividerWidth)),
+		)
+		footerHints = []KeyHint{
+			{Key: "Any key", Description: "Continue", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
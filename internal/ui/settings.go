@@ -130,6 +130,19 @@ func (m SettingsModel) View() string {
 		settingRow("TTL:", fmt.Sprintf("%ds", m.config.Cache.DomainsTTL), false),
 	)
 
+	// Warm-up section
+	warmupSection := lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Bold(true).
+		Render("Warm-up")
+
+	warmupSettings := lipgloss.JoinVertical(
+		lipgloss.Left,
+		settingRow("Concurrency:", fmt.Sprintf("%d", m.config.Warmup.Concurrency), false),
+		settingRow("Timeout:", fmt.Sprintf("%ds", m.config.Warmup.Timeout), false),
+		settingRow("Headers:", fmt.Sprintf("%d", len(m.config.Warmup.Headers)), false),
+	)
+
 	// Combine settings card with inner border
 	settingsCard := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -150,6 +163,9 @@ func (m SettingsModel) View() string {
 				"",
 				cacheSection,
 				cacheSettings,
+				"",
+				warmupSection,
+				warmupSettings,
 			),
 		)
 
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/sitemap"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/internal/warmup"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// WarmupModel re-fetches purged URLs so the first visitors hit a warm cache
+type WarmupModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	urls    []string
+	input   textinput.Model
+	spinner spinner.Model
+	results []warmup.Result
+	step    int                // 0: options, 1: warming, 2: done
+	cancel  context.CancelFunc // aborts the warm-up in flight
+	err     error
+	width   int
+	height  int
+}
+
+type warmupDoneMsg struct {
+	results []warmup.Result
+	err     error
+}
+
+func NewWarmupModel(cfg *config.Config, zone cloudflare.Zone, urls []string) WarmupModel {
+	ti := textinput.New()
+	ti.Placeholder = "https://example.com/sitemap.xml (optional)"
+	ti.Prompt = "Sitemap: "
+	ti.CharLimit = 2048
+	ti.Width = 50
+	ti.Focus()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return WarmupModel{
+		config:  cfg,
+		zone:    zone,
+		urls:    urls,
+		input:   ti,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m WarmupModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m WarmupModel) options() warmup.Options {
+	return warmup.Options{
+		Concurrency: m.config.Warmup.Concurrency,
+		Headers:     m.config.Warmup.Headers,
+		Timeout:     time.Duration(m.config.Warmup.Timeout) * time.Second,
+	}
+}
+
+func (m WarmupModel) runWarmup(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		urls := append([]string{}, m.urls...)
+
+		if sitemapURL := strings.TrimSpace(m.input.Value()); sitemapURL != "" {
+			if err := utils.ValidateURL(sitemapURL); err != nil {
+				return warmupDoneMsg{err: fmt.Errorf("sitemap: %w", err)}
+			}
+
+			entries, err := sitemap.Load(ctx, &http.Client{Timeout: 30 * time.Second}, sitemapURL)
+			if err != nil {
+				return warmupDoneMsg{err: err}
+			}
+			urls = append(urls, sitemap.URLs(entries)...)
+		}
+
+		if len(urls) == 0 {
+			return warmupDoneMsg{err: fmt.Errorf("no URLs to warm")}
+		}
+
+		return warmupDoneMsg{results: warmup.Warm(ctx, utils.Dedupe(urls), m.options())}
+	}
+}
+
+func (m WarmupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case warmupDoneMsg:
+		if m.step != 1 {
+			// Results of a warm-up canceled with Esc
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 0
+			return m, textinput.Blink
+		}
+		m.results = msg.results
+		m.step = 2
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "esc":
+				return m.back()
+			case "enter":
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				m.err = nil
+				return m, tea.Batch(m.runWarmup(ctx), m.spinner.Tick)
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		case 1:
+			if msg.String() == "esc" {
+				// Stop warming; URLs already fetched stay warm
+				cancelRequest(m.cancel)
+				m.step = 0
+				return m, textinput.Blink
+			}
+		case 2:
+			return m.back()
+		}
+		return m, nil
+
+	default:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m WarmupModel) back() (tea.Model, tea.Cmd) {
+	model := NewPurgeMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m WarmupModel) View() string {
+	dividerWidth := min(m.width-8, 62)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🔥", "Warm Cache", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	var body string
+	var footerHints []KeyHint
+
+	switch m.step {
+	case 0:
+		info := lipgloss.NewStyle().Foreground(MutedColor).Render(fmt.Sprintf(
+			"%s will be fetched with concurrency %d.",
+			utils.FormatCount(len(m.urls), "purged URL", "purged URLs"),
+			m.options().Concurrency,
+		))
+
+		var errorMsg string
+		if m.err != nil {
+			errorMsg = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗ " + m.err.Error())
+		}
+
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			info,
+			lipgloss.NewStyle().Foreground(MutedColor).Render("Optionally add every URL from a sitemap:"),
+			"",
+			FocusedInputStyle.Render(m.input.View()),
+			"",
+			errorMsg,
+		)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Start", IsAction: true},
+			{Key: "Esc", Description: "Skip", IsAction: false},
+		}
+
+	case 1:
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " Warming cache..."))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+
+	case 2:
+		body = m.renderResults(dividerWidth)
+		footerHints = []KeyHint{
+			{Key: "Any key", Description: "Continue", IsAction: true},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+func (m WarmupModel) renderResults(width int) string {
+	maxRows := m.height - 18
+	if maxRows < 5 {
+		maxRows = 5
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+			"✓ Warmed " + utils.FormatCount(len(m.results), "URL", "URLs")),
+		lipgloss.NewStyle().Foreground(MutedColor).Render(warmup.FormatSummary(warmup.Summary(m.results))),
+		"",
+	}
+
+	for i, r := range m.results {
+		if i == maxRows {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(
+				fmt.Sprintf("… and %d more", len(m.results)-maxRows)))
+			break
+		}
+
+		status := r.CacheStatus
+		color := InfoColor
+		switch {
+		case r.Error != "":
+			status, color = "ERROR", ErrorColor
+		case !r.OK():
+			status, color = fmt.Sprintf("HTTP %d", r.StatusCode), WarningColor
+		case status == "":
+			status, color = "NONE", MutedColor
+		case status == "HIT":
+			color = SuccessColor
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			lipgloss.NewStyle().Foreground(color).Bold(true).Width(10).Render(status),
+			lipgloss.NewStyle().Foreground(TextColor).Render(utils.TruncateString(r.URL, width-12)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
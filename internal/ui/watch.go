@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// maxWatchEntries bounds the purge log kept in memory
+const maxWatchEntries = 200
+
+// WatchPurgeFunc maps changed files to URLs and purges them, returning the
+// purged URLs
+type WatchPurgeFunc func(ctx context.Context, files []string) ([]string, error)
+
+// WatchEntry is one debounced batch of file changes and its purge outcome
+type WatchEntry struct {
+	Time  time.Time
+	Files []string
+	URLs  []string
+	Err   error
+	Done  bool
+}
+
+// WatchModel shows a live log of purges triggered by file changes
+type WatchModel struct {
+	zone    cloudflare.Zone
+	root    string
+	batches <-chan []string
+	errs    <-chan error
+	purge   WatchPurgeFunc
+	spinner spinner.Model
+	entries []WatchEntry
+	seq     int // sequence number of entries[0]
+	width   int
+	height  int
+}
+
+type watchBatchMsg struct {
+	files []string
+}
+
+type watchErrorMsg struct {
+	err error
+}
+
+type watchPurgedMsg struct {
+	seq  int
+	urls []string
+	err  error
+}
+
+func NewWatchModel(zone cloudflare.Zone, root string, batches <-chan []string, errs <-chan error, purge WatchPurgeFunc) WatchModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return WatchModel{
+		zone:    zone,
+		root:    root,
+		batches: batches,
+		errs:    errs,
+		purge:   purge,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m WatchModel) Init() tea.Cmd {
+	return tea.Batch(m.waitForBatch(), m.waitForError(), m.spinner.Tick)
+}
+
+func (m WatchModel) waitForBatch() tea.Cmd {
+	return func() tea.Msg {
+		files, ok := <-m.batches
+		if !ok {
+			return nil
+		}
+		return watchBatchMsg{files: files}
+	}
+}
+
+func (m WatchModel) waitForError() tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-m.errs
+		if !ok {
+			return nil
+		}
+		return watchErrorMsg{err: err}
+	}
+}
+
+func (m WatchModel) runPurge(seq int, files []string) tea.Cmd {
+	return func() tea.Msg {
+		urls, err := m.purge(context.Background(), files)
+		return watchPurgedMsg{seq: seq, urls: urls, err: err}
+	}
+}
+
+// append adds an entry to the log and returns its sequence number
+func (m *WatchModel) append(entry WatchEntry) int {
+	m.entries = append(m.entries, entry)
+	if len(m.entries) > maxWatchEntries {
+		drop := len(m.entries) - maxWatchEntries
+		m.entries = m.entries[drop:]
+		m.seq += drop
+	}
+	return m.seq + len(m.entries) - 1
+}
+
+func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "c":
+			m.seq += len(m.entries)
+			m.entries = nil
+		}
+		return m, nil
+
+	case watchBatchMsg:
+		seq := m.append(WatchEntry{Time: time.Now(), Files: msg.files})
+		return m, tea.Batch(m.runPurge(seq, msg.files), m.waitForBatch())
+
+	case watchErrorMsg:
+		m.append(WatchEntry{Time: time.Now(), Err: msg.err, Done: true})
+		return m, m.waitForError()
+
+	case watchPurgedMsg:
+		// The entry may have been cleared or rotated out of the log
+		if i := msg.seq - m.seq; i >= 0 && i < len(m.entries) {
+			m.entries[i].URLs = msg.urls
+			m.entries[i].Err = msg.err
+			m.entries[i].Done = true
+		}
+		return m, nil
+
+	default:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m WatchModel) View() string {
+	dividerWidth := max(m.width-4, 30)
+
+	title := MakeSectionHeader("👀", "Watching for Changes", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	info := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+		lipgloss.NewStyle().Foreground(MutedColor).Render("  Directory: "),
+		lipgloss.NewStyle().Foreground(TextColor).Render(m.root),
+	)
+
+	// Fit the newest entries into the available height
+	logHeight := max(m.height-10, 3)
+	var lines []string
+	for i := len(m.entries) - 1; i >= 0 && len(lines) < logHeight; i-- {
+		lines = append(lines, m.renderEntry(m.entries[i], dividerWidth))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(MutedColor).Render(
+			m.spinner.View()+" Waiting for file changes..."))
+	}
+	// Newest entry last, like a log
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+
+	footer := MakeFooter([]KeyHint{
+		{Key: "c", Description: "Clear log", IsAction: false},
+		{Key: "q", Description: "Stop watching", IsAction: true},
+	})
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		info,
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+		"",
+		divider,
+		footer,
+	))
+}
+
+func (m WatchModel) renderEntry(e WatchEntry, width int) string {
+	timestamp := lipgloss.NewStyle().Foreground(MutedColor).Render(e.Time.Format("15:04:05") + " ")
+
+	var status, detail string
+	switch {
+	case !e.Done:
+		status = lipgloss.NewStyle().Foreground(AccentColor).Render(m.spinner.View())
+		detail = "purging " + utils.FormatCount(len(e.Files), "changed file", "changed files") + "..."
+	case e.Err != nil && len(e.Files) == 0:
+		status = lipgloss.NewStyle().Foreground(WarningColor).Render("!")
+		detail = "watch error: " + e.Err.Error()
+	case e.Err != nil:
+		status = lipgloss.NewStyle().Foreground(ErrorColor).Render("✗")
+		detail = "purge failed: " + e.Err.Error()
+	case len(e.URLs) == 0:
+		status = lipgloss.NewStyle().Foreground(MutedColor).Render("·")
+		detail = utils.FormatCount(len(e.Files), "file", "files") + " changed, no URLs mapped"
+	default:
+		status = lipgloss.NewStyle().Foreground(SuccessColor).Render("✓")
+		detail = "purged " + summarizeURLs(e.URLs)
+	}
+
+	return timestamp + status + " " + lipgloss.NewStyle().Foreground(TextColor).Render(
+		utils.TruncateString(detail, max(width-12, 20)))
+}
+
+// summarizeURLs names the first purged URL and counts the rest
+func summarizeURLs(urls []string) string {
+	if len(urls) == 1 {
+		return urls[0]
+	}
+	return fmt.Sprintf("%s and %d more", urls[0], len(urls)-1)
+}
+
+// FormatWatchEntry renders an entry as a plain log line for non-interactive
+// output
+func FormatWatchEntry(e WatchEntry) string {
+	var b strings.Builder
+	b.WriteString(e.Time.Format("15:04:05") + " ")
+	switch {
+	case e.Err != nil && len(e.Files) == 0:
+		b.WriteString("watch error: " + e.Err.Error())
+	case e.Err != nil:
+		b.WriteString("purge failed: " + e.Err.Error())
+	case len(e.URLs) == 0:
+		b.WriteString(utils.FormatCount(len(e.Files), "file", "files") + " changed, no URLs mapped")
+	default:
+		b.WriteString("purged " + strings.Join(e.URLs, " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// Workers screen tabs
+const (
+	workersTabRoutes = iota
+	workersTabScripts
+)
+
+var workersTabNames = []string{"Routes", "Scripts"}
+
+type WorkerRouteItem struct {
+	route cloudflare.WorkerRoute
+}
+
+func (i WorkerRouteItem) Title() string { return i.route.Pattern }
+
+func (i WorkerRouteItem) Description() string {
+	if i.route.Script == "" {
+		return "Workers disabled"
+	}
+	return "→ " + i.route.Script
+}
+
+func (i WorkerRouteItem) FilterValue() string { return i.route.Pattern }
+
+type WorkerScriptItem struct {
+	script cloudflare.WorkerScript
+}
+
+func (i WorkerScriptItem) Title() string { return i.script.ID }
+
+func (i WorkerScriptItem) Description() string {
+	desc := "modified " + i.script.ModifiedOn.Local().Format("2006-01-02 15:04")
+	if len(i.script.Handlers) > 0 {
+		desc += " | " + strings.Join(i.script.Handlers, ", ")
+	}
+	return desc
+}
+
+func (i WorkerScriptItem) FilterValue() string { return i.script.ID }
+
+// WorkersModel shows the Worker routes of a zone and the scripts of the
+// account that owns it. Changes are made with "cfctl workers".
+type WorkersModel struct {
+	config    *config.Config
+	zone      cloudflare.Zone
+	list      list.Model
+	spinner   spinner.Model
+	tab       int
+	routes    []cloudflare.WorkerRoute
+	scripts   []cloudflare.WorkerScript
+	scriptErr error // scripts can fail on their own, e.g. without account access
+	ctx       context.Context
+	cancel    context.CancelFunc
+	loading   bool
+	err       error
+	width     int
+	height    int
+}
+
+type workersLoadedMsg struct {
+	routes    []cloudflare.WorkerRoute
+	scripts   []cloudflare.WorkerScript
+	scriptErr error
+	err       error
+}
+
+func NewWorkersModel(cfg *config.Config, zone cloudflare.Zone) WorkersModel {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	l := list.New([]list.Item{}, delegate, 60, 12)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return WorkersModel{
+		config:  cfg,
+		zone:    zone,
+		list:    l,
+		spinner: sp,
+		ctx:     ctx,
+		cancel:  cancel,
+		loading: true,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m WorkersModel) Init() tea.Cmd {
+	return tea.Batch(m.load(m.ctx), m.spinner.Tick)
+}
+
+// reload starts a new load with its own cancelable context
+func (m *WorkersModel) reload() tea.Cmd {
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.loading = true
+	m.err = nil
+	return tea.Batch(m.load(m.ctx), m.spinner.Tick)
+}
+
+func (m WorkersModel) load(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return workersLoadedMsg{err: err}
+		}
+
+		routes, err := client.ListWorkerRoutes(ctx, m.zone.ID)
+		if err != nil {
+			return workersLoadedMsg{err: err}
+		}
+
+		if m.zone.Account.ID == "" {
+			return workersLoadedMsg{routes: routes, scriptErr: fmt.Errorf("the zone's account is unknown")}
+		}
+		scripts, err := client.ListWorkerScripts(ctx, m.zone.Account.ID)
+		return workersLoadedMsg{routes: routes, scripts: scripts, scriptErr: err}
+	}
+}
+
+// showTab fills the list with the items of a tab
+func (m *WorkersModel) showTab(tab int) {
+	m.tab = tab
+	var items []list.Item
+	if tab == workersTabRoutes {
+		for _, route := range m.routes {
+			items = append(items, WorkerRouteItem{route: route})
+		}
+	} else {
+		for _, script := range m.scripts {
+			items = append(items, WorkerScriptItem{script: script})
+		}
+	}
+	m.list.SetItems(items)
+	m.list.Select(0)
+}
+
+func (m WorkersModel) back() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m WorkersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 70)
+		listHeight := min(msg.Height-16, 14)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		if listHeight < 6 {
+			listHeight = 6
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(listHeight)
+		return m, nil
+
+	case workersLoadedMsg:
+		if isCanceled(msg.err) || isCanceled(msg.scriptErr) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		m.routes = msg.routes
+		m.scripts = msg.scripts
+		m.scriptErr = msg.scriptErr
+		m.showTab(m.tab)
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "esc", "q":
+			cancelRequest(m.cancel)
+			return m.back()
+		}
+		if m.loading {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "tab", "right", "l", "shift+tab", "left", "h":
+			m.showTab(1 - m.tab)
+			return m, nil
+		case "1", "2":
+			m.showTab(int(msg.String()[0] - '1'))
+			return m, nil
+		case "r":
+			return m, m.reload()
+		}
+	}
+
+	if !m.loading {
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m WorkersModel) View() string {
+	dividerWidth := min(m.width-8, 66)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("⚙", "Workers", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	tabs := make([]string, len(workersTabNames))
+	for i, name := range workersTabNames {
+		label := fmt.Sprintf(" %d:%s ", i+1, name)
+		if i == m.tab {
+			tabs[i] = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Underline(true).Render(label)
+		} else {
+			tabs[i] = lipgloss.NewStyle().Foreground(MutedColor).Render(label)
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tabs...)
+
+	muted := lipgloss.NewStyle().Foreground(MutedColor)
+	var body string
+	var footerHints []KeyHint
+	if m.loading {
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(AccentColor).
+			Padding(1, 2).
+			Render(lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.spinner.View() + " Loading Workers..."))
+		footerHints = []KeyHint{{Key: "Esc", Description: "Cancel", IsAction: false}}
+	} else {
+		err := m.err
+		if m.tab == workersTabScripts && err == nil {
+			err = m.scriptErr
+		}
+
+		var content string
+		switch {
+		case err != nil:
+			content = lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ " + err.Error())
+		case len(m.list.Items()) > 0:
+			content = m.list.View()
+		case m.tab == workersTabRoutes:
+			content = muted.Render("No Worker routes. Add one with `cfctl workers routes add`.")
+		default:
+			content = muted.Render("No Workers scripts. Deploy one with `cfctl workers deploy`.")
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, tabBar, "", content)
+		footerHints = []KeyHint{
+			{Key: "Tab", Description: "Switch", IsAction: true},
+			{Key: "r", Description: "Refresh", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 76)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
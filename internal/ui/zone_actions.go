@@ -0,0 +1,519 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// Zone actions confirmed and run by ZoneActionModel
+const (
+	zoneActionPause      = "pause"
+	zoneActionResume     = "resume"
+	zoneActionActivation = "activation"
+)
+
+type zoneActionMsg struct {
+	zone *cloudflare.Zone // updated zone, nil when the action doesn't return one
+	err  error
+}
+
+// ZoneActionModel confirms and runs a zone change: pausing or resuming
+// Cloudflare, or triggering an activation check
+type ZoneActionModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	action  string
+	spinner spinner.Model
+	cancel  context.CancelFunc // aborts the in-flight request
+	step    int                // 0: confirm, 1: running, 2: done
+	err     error
+	width   int
+	height  int
+}
+
+func NewZoneActionModel(cfg *config.Config, zone cloudflare.Zone, action string) ZoneActionModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return ZoneActionModel{
+		config:  cfg,
+		zone:    zone,
+		action:  action,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m ZoneActionModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ZoneActionModel) run(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return zoneActionMsg{err: err}
+		}
+
+		switch m.action {
+		case zoneActionPause, zoneActionResume:
+			zone, err := client.SetZonePaused(ctx, m.zone.ID, m.action == zoneActionPause)
+			return zoneActionMsg{zone: zone, err: err}
+		default:
+			return zoneActionMsg{err: client.CheckActivation(ctx, m.zone.ID)}
+		}
+	}
+}
+
+// backToZone returns to the zone menu
+func (m ZoneActionModel) backToZone() (tea.Model, tea.Cmd) {
+	model := NewZoneMenuModel(m.config, m.zone)
+	model.width = m.width
+	model.height = m.height
+	return model, nil
+}
+
+func (m ZoneActionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case zoneActionMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 0
+			return m, nil
+		}
+		if msg.zone != nil {
+			m.zone = *msg.zone
+		}
+		m.err = nil
+		m.step = 2
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "y", "enter":
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				m.err = nil
+				return m, tea.Batch(m.run(ctx), m.spinner.Tick)
+			case "n", "esc", "q":
+				return m.backToZone()
+			}
+		case 1:
+			if msg.String() == "esc" {
+				cancelRequest(m.cancel)
+				m.step = 0
+				m.err = errChangeCanceled
+			}
+		case 2:
+			return m.backToZone()
+		}
+	}
+
+	return m, nil
+}
+
+// describe returns the screen title and what the action does
+func (m ZoneActionModel) describe() (string, []string) {
+	switch m.action {
+	case zoneActionPause:
+		return "Pause Cloudflare", []string{
+			"• Traffic goes straight to your origin",
+			"• Cloudflare only answers DNS queries",
+			"• Caching, WAF and SSL are bypassed",
+		}
+	case zoneActionResume:
+		return "Resume Cloudflare", []string{
+			"• Proxied traffic goes through Cloudflare again",
+			"• Caching, WAF and SSL apply again",
+		}
+	default:
+		return "Check Activation", []string{
+			"• Cloudflare re-checks the zone's name servers now",
+			"• Checks are rate limited; try again later if refused",
+		}
+	}
+}
+
+func (m ZoneActionModel) View() string {
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	heading, effects := m.describe()
+	title := MakeSectionHeader("⏯", heading, "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+		lipgloss.NewStyle().Foreground(MutedColor).Render("  "+zoneStatus(m.zone)),
+	)
+
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case 0:
+		rows := []string{lipgloss.NewStyle().Foreground(TextColor).Render("This action will:")}
+		for _, effect := range effects {
+			rows = append(rows, lipgloss.NewStyle().Foreground(MutedColor).Render(effect))
+		}
+		body = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(WarningColor).
+			Padding(1, 2).
+			Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+		if m.err != nil {
+			body = lipgloss.JoinVertical(lipgloss.Left, body, "",
+				lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ "+m.err.Error()))
+		}
+		footerHints = []KeyHint{
+			{Key: "y", Description: "Confirm", IsAction: true},
+			{Key: "n", Description: "Cancel", IsAction: false},
+		}
+	case 1:
+		body = lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+			fmt.Sprintf("%s Applying...", m.spinner.View()))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case 2:
+		var done string
+		switch m.action {
+		case zoneActionPause:
+			done = "✓ Cloudflare paused on " + m.zone.Name
+		case zoneActionResume:
+			done = "✓ Cloudflare resumed on " + m.zone.Name
+		default:
+			done = "✓ Activation check requested; it may take a few minutes"
+		}
+		body = lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(done)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Continue", IsAction: true},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
+
+// zoneStatus describes a zone's status, noting when Cloudflare is paused
+func zoneStatus(z cloudflare.Zone) string {
+	if z.Paused {
+		return z.Status + " (paused)"
+	}
+	return z.Status
+}
+
+// ZoneDeleteModel deletes a zone after the user confirms and types its name
+type ZoneDeleteModel struct {
+	config  *config.Config
+	zone    cloudflare.Zone
+	input   textinput.Model
+	spinner spinner.Model
+	step    int                // 0: first confirm, 1: type zone name, 2: deleting, 3: done
+	cancel  context.CancelFunc // aborts the in-flight delete
+	err     error
+	width   int
+	height  int
+}
+
+type zoneDeletedMsg struct {
+	err error
+}
+
+func NewZoneDeleteModel(cfg *config.Config, zone cloudflare.Zone) ZoneDeleteModel {
+	ti := textinput.New()
+	ti.Placeholder = "Type zone name to confirm"
+	ti.Focus()
+	ti.Width = 40
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return ZoneDeleteModel{
+		config:  cfg,
+		zone:    zone,
+		input:   ti,
+		spinner: sp,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m ZoneDeleteModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m ZoneDeleteModel) executeDelete(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return zoneDeletedMsg{err: err}
+		}
+		return zoneDeletedMsg{err: client.DeleteZone(ctx, m.zone.ID)}
+	}
+}
+
+func (m ZoneDeleteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case zoneDeletedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 1
+			return m, textinput.Blink
+		}
+		m.err = nil
+		m.step = 3
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.step == 2 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.step == 3 {
+			// The zone is gone, so go back to the refreshed domain list
+			model := NewDomainListModel(m.config)
+			model.width = m.width
+			model.height = m.height
+			return model, model.Init()
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		case "esc":
+			if m.step == 2 {
+				cancelRequest(m.cancel)
+				m.step = 1
+				m.err = errChangeCanceled
+				return m, textinput.Blink
+			}
+			model := NewZoneMenuModel(m.config, m.zone)
+			model.width = m.width
+			model.height = m.height
+			return model, nil
+		case "enter":
+			switch m.step {
+			case 0:
+				m.step = 1
+				return m, textinput.Blink
+			case 1:
+				if m.input.Value() != m.zone.Name {
+					m.err = fmt.Errorf("zone name doesn't match")
+					return m, nil
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 2
+				m.err = nil
+				return m, tea.Batch(m.executeDelete(ctx), m.spinner.Tick)
+			}
+		case "n":
+			if m.step == 0 {
+				model := NewZoneMenuModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, nil
+			}
+		case "y":
+			if m.step == 0 {
+				m.step = 1
+				return m, textinput.Blink
+			}
+		}
+	}
+
+	if m.step == 1 {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m ZoneDeleteModel) View() string {
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("🗑️", "Delete Zone", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	warning := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ErrorColor).
+		Foreground(ErrorColor).
+		Bold(true).
+		Padding(0, 2).
+		Render("⚠ WARNING: This deletes the zone from Cloudflare!")
+
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case 0:
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			warning,
+			"",
+			lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(WarningColor).
+				Padding(1, 2).
+				Render(lipgloss.JoinVertical(
+					lipgloss.Left,
+					lipgloss.NewStyle().Foreground(TextColor).Render("This action will:"),
+					lipgloss.NewStyle().Foreground(MutedColor).Render("• Remove all DNS records and settings"),
+					lipgloss.NewStyle().Foreground(MutedColor).Render("• Stop proxying traffic for the domain"),
+					lipgloss.NewStyle().Foreground(MutedColor).Render("• Cannot be undone"),
+				)),
+		)
+		footerHints = []KeyHint{
+			{Key: "y", Description: "Continue", IsAction: true},
+			{Key: "n", Description: "Cancel", IsAction: false},
+		}
+	case 1:
+		rows := []string{
+			warning,
+			"",
+			lipgloss.NewStyle().Foreground(TextColor).Render("Type ") +
+				lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(m.zone.Name) +
+				lipgloss.NewStyle().Foreground(TextColor).Render(" to confirm:"),
+			"",
+			m.input.View(),
+		}
+		if m.err != nil {
+			rows = append(rows, "", lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ "+m.err.Error()))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Delete", IsAction: true},
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case 2:
+		body = lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+			fmt.Sprintf("%s Deleting %s...", m.spinner.View(), m.zone.Name))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case 3:
+		body = lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render("✓ Deleted " + m.zone.Name)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Back to domains", IsAction: true},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		zoneBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
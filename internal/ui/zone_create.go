@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/internal/utils"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// ZoneCreateModel adds a zone to the Cloudflare account the domain list is
+// showing, optionally importing the domain's existing DNS records
+type ZoneCreateModel struct {
+	config    *config.Config
+	accountID string // Cloudflare account the domain list is showing, empty for all
+	input     textinput.Model
+	jumpStart bool
+	spinner   spinner.Model
+	cancel    context.CancelFunc // aborts the in-flight create
+	step      int                // 0: form, 1: creating, 2: done
+	zone      cloudflare.Zone    // the created zone
+	err       error
+	width     int
+	height    int
+}
+
+type zoneCreatedMsg struct {
+	zone *cloudflare.Zone
+	err  error
+}
+
+func NewZoneCreateModel(cfg *config.Config, accountID string) ZoneCreateModel {
+	ti := textinput.New()
+	ti.Placeholder = "example.com"
+	ti.Focus()
+	ti.Width = 40
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = SpinnerStyle
+
+	return ZoneCreateModel{
+		config:    cfg,
+		accountID: accountID,
+		input:     ti,
+		jumpStart: true,
+		spinner:   sp,
+		width:     80,
+		height:    24,
+	}
+}
+
+func (m ZoneCreateModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m ZoneCreateModel) executeCreate(ctx context.Context, name string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newAPIClient(m.config)
+		if err != nil {
+			return zoneCreatedMsg{err: err}
+		}
+
+		// Without an account to add the zone to, use the only one the
+		// credential can access
+		accountID := m.accountID
+		if accountID == "" {
+			accounts, err := client.ListAccounts(ctx)
+			if err != nil {
+				return zoneCreatedMsg{err: err}
+			}
+			if len(accounts) != 1 {
+				return zoneCreatedMsg{err: fmt.Errorf(
+					"this credential can access %d Cloudflare accounts; press a in the domain list to choose one first", len(accounts))}
+			}
+			accountID = accounts[0].ID
+		}
+
+		zone, err := client.CreateZone(ctx, accountID, name, m.jumpStart)
+		return zoneCreatedMsg{zone: zone, err: err}
+	}
+}
+
+// backToDomains returns to a refreshed domain list
+func (m ZoneCreateModel) backToDomains() (tea.Model, tea.Cmd) {
+	model := newDomainListForAccount(m.config, m.accountID)
+	model.width = m.width
+	model.height = m.height
+	return model, model.Init()
+}
+
+func (m ZoneCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case zoneCreatedMsg:
+		if isCanceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = 0
+			return m, textinput.Blink
+		}
+		m.zone = *msg.zone
+		m.err = nil
+		m.step = 2
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.step == 1 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			cancelRequest(m.cancel)
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case 0:
+			switch msg.String() {
+			case "esc":
+				return m.backToDomains()
+			case "tab":
+				m.jumpStart = !m.jumpStart
+				return m, nil
+			case "enter":
+				name := strings.ToLower(strings.TrimSpace(m.input.Value()))
+				if err := utils.ValidateHostname(name); err != nil {
+					m.err = err
+					return m, nil
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.cancel = cancel
+				m.step = 1
+				m.err = nil
+				return m, tea.Batch(m.executeCreate(ctx, name), m.spinner.Tick)
+			}
+		case 1:
+			if msg.String() == "esc" {
+				cancelRequest(m.cancel)
+				m.step = 0
+				m.err = errChangeCanceled
+				return m, textinput.Blink
+			}
+			return m, nil
+		case 2:
+			switch msg.String() {
+			case "enter":
+				model := NewZoneMenuModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, nil
+			case "esc", "q":
+				return m.backToDomains()
+			}
+			return m, nil
+		}
+	}
+
+	if m.step == 0 {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m ZoneCreateModel) View() string {
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("➕", "Add Zone", "")
+	divider := lipgloss.NewStyle().Foreground(BorderColor).Render(MakeDivider(dividerWidth, PrimaryColor))
+
+	account := "Only account of this credential"
+	if m.accountID != "" {
+		account = m.accountID
+	}
+	accountBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Account: "),
+		InfoStatusBadge.Render(account),
+	)
+
+	var body string
+	var footerHints []KeyHint
+	switch m.step {
+	case 0:
+		check := "[ ]"
+		if m.jumpStart {
+			check = "[✓]"
+		}
+		rows := []string{
+			lipgloss.NewStyle().Foreground(TextColor).Render("Domain name:"),
+			"",
+			m.input.View(),
+			"",
+			lipgloss.NewStyle().Foreground(AccentColor).Render(check) +
+				lipgloss.NewStyle().Foreground(TextColor).Render(" Import existing DNS records (jump start)"),
+		}
+		if m.err != nil {
+			rows = append(rows, "", lipgloss.NewStyle().Foreground(ErrorColor).Width(dividerWidth).Render("✗ "+m.err.Error()))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Add zone", IsAction: true},
+			{Key: "Tab", Description: "Toggle jump start", IsAction: false},
+			{Key: "Esc", Description: "Back", IsAction: false},
+		}
+	case 1:
+		body = lipgloss.NewStyle().Foreground(AccentColor).Bold(true).Render(
+			fmt.Sprintf("%s Adding %s...", m.spinner.View(), strings.TrimSpace(m.input.Value())))
+		footerHints = []KeyHint{
+			{Key: "Esc", Description: "Cancel", IsAction: false},
+		}
+	case 2:
+		rows := []string{
+			lipgloss.NewStyle().Foreground(SuccessColor).Bold(true).Render(
+				fmt.Sprintf("✓ Added %s (%s)", m.zone.Name, m.zone.Status)),
+		}
+		if len(m.zone.NameServers) > 0 {
+			rows = append(rows, "", lipgloss.NewStyle().Foreground(TextColor).Render(
+				"Point the domain at these name servers at your registrar:"))
+			for _, ns := range m.zone.NameServers {
+				rows = append(rows, lipgloss.NewStyle().Foreground(AccentColor).Render("  "+ns))
+			}
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		footerHints = []KeyHint{
+			{Key: "Enter", Description: "Open zone", IsAction: true},
+			{Key: "Esc", Description: "Back to domains", IsAction: false},
+		}
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		divider,
+		"",
+		accountBadge,
+		"",
+		body,
+		"",
+		divider,
+		MakeFooter(footerHints),
+	)
+
+	containerWidth := min(m.width-6, 72)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/siyamsarker/cfctl/internal/config"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+type ZoneMenuItem struct {
+	title       string
+	description string
+	action      string
+	icon        string
+}
+
+func (i ZoneMenuItem) Title() string       { return i.icon + " " + i.title }
+func (i ZoneMenuItem) Description() string { return i.description }
+func (i ZoneMenuItem) FilterValue() string { return i.title }
+
+type ZoneMenuModel struct {
+	config *config.Config
+	zone   cloudflare.Zone
+	list   list.Model
+	width  int
+	height int
+}
+
+func NewZoneMenuModel(cfg *config.Config, zone cloudflare.Zone) ZoneMenuModel {
+	items := []list.Item{
+		ZoneMenuItem{
+			title:       "Purge Cache",
+			description: "Invalidate cached content for this zone",
+			action:      "purge",
+			icon:        "🗑️",
+		},
+		ZoneMenuItem{
+			title:       "Cache Rules",
+			description: "Control what gets cached and for how long",
+			action:      "cache-rules",
+			icon:        "📜",
+		},
+		ZoneMenuItem{
+			title:       "Redirects",
+			description: "Single Redirects, forwarding Page Rules and Bulk Redirects",
+			action:      "redirects",
+			icon:        "↪",
+		},
+		ZoneMenuItem{
+			title:       "Firewall",
+			description: "WAF custom rules and IP Access rules",
+			action:      "firewall",
+			icon:        "🛡",
+		},
+		incidentMenuItem(zone.ID),
+		ZoneMenuItem{
+			title:       "SSL/TLS Certificates",
+			description: "Edge certificates, their expiry and the SSL mode",
+			action:      "certificates",
+			icon:        "🔒",
+		},
+		ZoneMenuItem{
+			title:       "Workers",
+			description: "Worker routes of the zone and the account's scripts",
+			action:      "workers",
+			icon:        "⚙",
+		},
+		ZoneMenuItem{
+			title:       "Cache Analytics",
+			description: "Hit ratio, bandwidth saved and cached requests",
+			action:      "analytics",
+			icon:        "📊",
+		},
+		ZoneMenuItem{
+			title:       "Inspect URL",
+			description: "Check whether a URL is served from cache and why",
+			action:      "inspect",
+			icon:        "🔍",
+		},
+	}
+
+	if zone.Paused {
+		items = append(items, ZoneMenuItem{
+			title:       "Resume Cloudflare",
+			description: "Proxy traffic through Cloudflare again",
+			action:      "resume",
+			icon:        "▶",
+		})
+	} else {
+		items = append(items, ZoneMenuItem{
+			title:       "Pause Cloudflare",
+			description: "Send traffic straight to the origin (DNS only)",
+			action:      "pause",
+			icon:        "⏸",
+		})
+	}
+	if zone.Status == "pending" {
+		items = append(items, ZoneMenuItem{
+			title:       "Check Activation",
+			description: "Re-check the name servers of this pending zone",
+			action:      "activation",
+			icon:        "🔄",
+		})
+	}
+	items = append(items,
+		ZoneMenuItem{
+			title:       "Delete Zone",
+			description: "Remove the zone and all of its configuration",
+			action:      "delete",
+			icon:        "✖",
+		},
+		ZoneMenuItem{
+			title:       "Back",
+			description: "Return to domain list",
+			action:      "back",
+			icon:        "←",
+		},
+	)
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.SelectedDesc = lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalTitle = lipgloss.NewStyle().
+		Foreground(TextColor).
+		Padding(0, 0, 0, 2)
+	delegate.Styles.NormalDesc = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 0, 0, 2)
+	delegate.SetSpacing(0)
+
+	l := list.New(items, delegate, 60, len(items)*2)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+
+	return ZoneMenuModel{
+		config: cfg,
+		zone:   zone,
+		list:   l,
+		width:  80,
+		height: 24,
+	}
+}
+
+// incidentMenuItem offers to start incident mode, or to end it when the
+// zone has an incident snapshot
+func incidentMenuItem(zoneID string) ZoneMenuItem {
+	if incidentActive(zoneID) {
+		return ZoneMenuItem{
+			title:       "End Incident",
+			description: "Restore the security settings saved when the incident started",
+			action:      "incident",
+			icon:        "🚨",
+		}
+	}
+	return ZoneMenuItem{
+		title:       "Incident Mode",
+		description: "Harden security during an attack, with rollback",
+		action:      "incident",
+		icon:        "🚨",
+	}
+}
+
+func (m ZoneMenuModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ZoneMenuModel) backToDomains() (tea.Model, tea.Cmd) {
+	domainModel := NewDomainListModel(m.config)
+	domainModel.width = m.width
+	domainModel.height = m.height
+	return domainModel, domainModel.Init()
+}
+
+func (m ZoneMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		listWidth := min(msg.Width-10, 60)
+		if listWidth < 40 {
+			listWidth = 40
+		}
+		m.list.SetWidth(listWidth)
+		m.list.SetHeight(len(m.list.Items()) * 2)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return m.backToDomains()
+		case "enter":
+			selected := m.list.SelectedItem().(ZoneMenuItem)
+			switch selected.action {
+			case "purge":
+				model := NewPurgeMenuModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, nil
+			case "cache-rules":
+				model := NewCacheRulesModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "redirects":
+				model := NewRedirectsModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "firewall":
+				model := NewFirewallModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "incident":
+				model := NewIncidentModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "certificates":
+				model := NewCertificatesModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "workers":
+				model := NewWorkersModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "analytics":
+				model := NewAnalyticsModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "inspect":
+				model := NewInspectModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "pause", "resume", "activation":
+				model := NewZoneActionModel(m.config, m.zone, selected.action)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "delete":
+				model := NewZoneDeleteModel(m.config, m.zone)
+				model.width = m.width
+				model.height = m.height
+				return model, model.Init()
+			case "back":
+				return m.backToDomains()
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m ZoneMenuModel) View() string {
+	// Responsive sizing
+	dividerWidth := min(m.width-8, 55)
+	if dividerWidth < 30 {
+		dividerWidth = 30
+	}
+
+	title := MakeSectionHeader("◈", " Zone", "")
+	divider := MakeDivider(dividerWidth, PrimaryColor)
+
+	zoneBadge := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(MutedColor).Render("Zone: "),
+		InfoStatusBadge.Render(m.zone.Name),
+	)
+
+	footerHints := []KeyHint{
+		{Key: "↑↓", Description: "Navigate", IsAction: false},
+		{Key: "Enter", Description: "Select", IsAction: true},
+		{Key: "Esc", Description: "Back", IsAction: false},
+	}
+	footer := MakeFooter(footerHints)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
+		"",
+		zoneBadge,
+		"",
+		m.list.View(),
+		"",
+		lipgloss.NewStyle().Foreground(BorderColor).Render(divider),
+		footer,
+	)
+
+	containerWidth := min(m.width-10, 66)
+	if containerWidth < 54 {
+		containerWidth = 54
+	}
+	container := lipgloss.NewStyle().
+		Width(containerWidth).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		container,
+	)
+}
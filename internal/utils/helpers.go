@@ -38,3 +38,31 @@ func PluralizeWord(count int, singular, plural string) string {
 func FormatCount(count int, singular, plural string) string {
 	return fmt.Sprintf("%d %s", count, PluralizeWord(count, singular, plural))
 }
+
+// FormatBytes formats a byte count using binary units (KiB, MiB, ...)
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// Dedupe removes duplicate entries while preserving order
+func Dedupe(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -118,6 +118,19 @@ func ValidatePrefixes(prefixes []string) error {
 	return nil
 }
 
+// ValidateTag validates a single cache tag
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+
+	if len(tag) > 1024 {
+		return fmt.Errorf("tag must be at most 1024 characters")
+	}
+
+	return nil
+}
+
 // ValidateTags validates cache tags
 func ValidateTags(tags []string) error {
 	if len(tags) == 0 {
@@ -129,8 +142,8 @@ func ValidateTags(tags []string) error {
 	}
 
 	for i, tag := range tags {
-		if tag == "" {
-			return fmt.Errorf("tag %d: tag cannot be empty", i+1)
+		if err := ValidateTag(tag); err != nil {
+			return fmt.Errorf("tag %d: %w", i+1, err)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -181,3 +182,15 @@ func TestParsCommaSeparated(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTag(t *testing.T) {
+	assert.NoError(t, ValidateTag("header"))
+	assert.Error(t, ValidateTag(""))
+	assert.Error(t, ValidateTag(strings.Repeat("a", 1025)))
+}
+
+func TestValidateTags(t *testing.T) {
+	assert.NoError(t, ValidateTags([]string{"header", "footer"}))
+	assert.EqualError(t, ValidateTags([]string{"header", ""}), "tag 2: tag cannot be empty")
+	assert.EqualError(t, ValidateTags([]string{strings.Repeat("a", 1025)}), "tag 1: tag must be at most 1024 characters")
+}
@@ -0,0 +1,152 @@
+package warmup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a warm-up run
+type Options struct {
+	Concurrency int
+	Headers     map[string]string
+	Timeout     time.Duration
+	Client      *http.Client
+}
+
+// Result is the outcome of warming a single URL
+type Result struct {
+	URL         string        `json:"url"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	CacheStatus string        `json:"cache_status,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// OK reports whether the URL was fetched successfully
+func (r Result) OK() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 400
+}
+
+// Summary counts results by CF-Cache-Status (errors are counted as "ERROR")
+func Summary(results []Result) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			counts["ERROR"]++
+		case r.CacheStatus == "":
+			counts["NONE"]++
+		default:
+			counts[r.CacheStatus]++
+		}
+	}
+	return counts
+}
+
+// FormatSummary renders a Summary as "HIT=3 MISS=2" in a stable order
+func FormatSummary(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", k, counts[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseHeaders converts "Name: value" strings into a header map
+func ParseHeaders(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q (expected \"Name: value\")", v)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// Warm fetches every URL with bounded concurrency and returns the results in
+// the same order as urls
+func Warm(ctx context.Context, urls []string, opts Options) []Result {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	client := opts.Client
+	if client == nil {
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = 15 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	results := make([]Result, len(urls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = Result{URL: u, Error: ctx.Err().Error()}
+				return
+			}
+
+			results[i] = fetch(ctx, client, u, opts.Headers)
+		}(i, u)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func fetch(ctx context.Context, client *http.Client, u string, headers map[string]string) Result {
+	result := Result{URL: u}
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req.Header.Set("User-Agent", "cfctl-warmup")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+	defer resp.Body.Close()
+
+	// Read the full body so the edge caches the complete object
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	result.Duration = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	result.CacheStatus = resp.Header.Get("CF-Cache-Status")
+	return result
+}
@@ -0,0 +1,59 @@
+package warmup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarm(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		assert.Equal(t, "preview", r.Header.Get("X-Env"))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("CF-Cache-Status", "MISS")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/missing", server.URL + "/c"}
+	results := Warm(context.Background(), urls, Options{
+		Concurrency: 2,
+		Headers:     map[string]string{"X-Env": "preview"},
+	})
+
+	require.Len(t, results, 4)
+	assert.Equal(t, urls[0], results[0].URL)
+	assert.Equal(t, "MISS", results[0].CacheStatus)
+	assert.True(t, results[0].OK())
+	assert.Equal(t, http.StatusNotFound, results[2].StatusCode)
+	assert.False(t, results[2].OK())
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	assert.Equal(t, "MISS=3 NONE=1", FormatSummary(Summary(results)))
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"Accept-Encoding: br", "X-Test:1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Accept-Encoding": "br", "X-Test": "1"}, headers)
+
+	_, err = ParseHeaders([]string{"no-colon"})
+	assert.Error(t, err)
+}
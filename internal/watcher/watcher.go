@@ -0,0 +1,186 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher watches a directory tree and reports changed files in debounced
+// batches
+type Watcher struct {
+	root     string
+	debounce time.Duration
+	fs       *fsnotify.Watcher
+}
+
+// New creates a Watcher for every directory below root. Hidden directories
+// such as .git are skipped.
+func New(root string, debounce time.Duration) (*Watcher, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("watch %s: %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("watch %s: not a directory", root)
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create watcher: %w", err)
+	}
+
+	w := &Watcher{root: root, debounce: debounce, fs: fw}
+	if err := w.addTree(root); err != nil {
+		fw.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+// Run delivers changed paths, relative to the root and sorted, on batches
+// once no further change has been seen for the debounce interval. Errors
+// are delivered on errs. Run returns when ctx is done.
+func (w *Watcher) Run(ctx context.Context, batches chan<- []string, errs chan<- error) {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			changed, err := w.handle(event, pending)
+			if err != nil {
+				send(ctx, errs, err)
+			}
+			if changed {
+				timer.Reset(w.debounce)
+			}
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			send(ctx, errs, err)
+
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]bool)
+
+			select {
+			case batches <- paths:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// handle records a file event and reports whether it should (re)start the
+// debounce timer
+func (w *Watcher) handle(event fsnotify.Event, pending map[string]bool) (bool, error) {
+	// Permission changes don't alter served content
+	if event.Op == fsnotify.Chmod {
+		return false, nil
+	}
+
+	rel, err := filepath.Rel(w.root, event.Name)
+	if err != nil || isHidden(rel) {
+		return false, nil
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			// New directories must be watched too, and files written into
+			// them before the watch was added are picked up here
+			return w.addNewDir(event.Name, pending)
+		}
+	}
+
+	pending[filepath.ToSlash(rel)] = true
+	return true, nil
+}
+
+// addNewDir watches a newly created directory and records the files it
+// already contains
+func (w *Watcher) addNewDir(dir string, pending map[string]bool) (bool, error) {
+	if err := w.addTree(dir); err != nil {
+		return false, err
+	}
+
+	changed := false
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(w.root, path)
+		if err != nil || isHidden(rel) {
+			return nil
+		}
+		pending[filepath.ToSlash(rel)] = true
+		changed = true
+		return nil
+	})
+	return changed, err
+}
+
+// addTree watches dir and every non-hidden directory below it
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != w.root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := w.fs.Add(path); err != nil {
+			return fmt.Errorf("watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+func isHidden(rel string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if strings.HasPrefix(part, ".") && part != "." && part != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func send(ctx context.Context, errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,56 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcherDebouncesChanges(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "css"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".cache"), 0o755))
+
+	w, err := New(root, 100*time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batches := make(chan []string, 1)
+	errs := make(chan error, 1)
+	go w.Run(ctx, batches, errs)
+
+	write := func(name string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(name), 0o644))
+	}
+	write("index.html")
+	write("css/site.css")
+	write("css/site.css")
+	write(".cache/ignored")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "img"), 0o755))
+	write("img/logo.svg")
+
+	select {
+	case batch := <-batches:
+		assert.Equal(t, []string{"css/site.css", "img/logo.svg", "index.html"}, batch)
+	case err := <-errs:
+		t.Fatalf("watch error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no batch received")
+	}
+}
+
+func TestNewRequiresDirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	_, err := New(file, time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,306 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+)
+
+// SignatureHeader carries the time a request was signed and the hex encoded
+// HMAC-SHA256 of that time and the request body:
+// "t=<unix seconds>,sha256=<hex of HMAC(t + "." + body)>"
+const SignatureHeader = "X-Cfctl-Signature"
+
+// SignatureTolerance is how far the signing time may be from the server's
+// clock, in either direction, before a request is rejected as a replay
+const SignatureTolerance = 5 * time.Minute
+
+// maxBodySize limits the size of webhook payloads
+const maxBodySize = 1 << 20
+
+// maxJobs is how many finished jobs are kept for status lookups
+const maxJobs = 500
+
+// Job states
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Options configures a Server
+type Options struct {
+	Secret          []byte
+	DefaultZone     string
+	AllowEverything bool
+	QueueSize       int
+	Retry           purgejob.Retry
+	Logger          *log.Logger
+}
+
+// Job is a queued purge request
+type Job struct {
+	ID         string        `json:"id"`
+	Spec       purgejob.Spec `json:"spec"`
+	Status     string        `json:"status"`
+	Attempts   int           `json:"attempts"`
+	Error      string        `json:"error,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+}
+
+// Server accepts signed purge webhooks and executes them in the background
+type Server struct {
+	purger  purgejob.Purger
+	opts    Options
+	queue   chan *Job
+	started time.Time
+
+	mu        sync.Mutex
+	jobs      map[string]*Job
+	order     []string
+	succeeded int
+	failed    int
+}
+
+// New creates a Server that purges through purger
+func New(purger purgejob.Purger, opts Options) (*Server, error) {
+	if len(opts.Secret) == 0 {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.New(io.Discard, "", 0)
+	}
+
+	return &Server{
+		purger:  purger,
+		opts:    opts,
+		queue:   make(chan *Job, opts.QueueSize),
+		started: time.Now(),
+		jobs:    make(map[string]*Job),
+	}, nil
+}
+
+// Handler returns the HTTP handler serving the webhook endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/purge", s.handlePurge)
+	mux.HandleFunc("GET /v1/jobs/{id}", s.handleJob)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	return mux
+}
+
+// Run executes queued jobs one at a time until ctx is done
+func (s *Server) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			s.execute(ctx, job)
+		}
+	}
+}
+
+func (s *Server) execute(ctx context.Context, job *Job) {
+	s.update(job, func(j *Job) { j.Status = StatusRunning })
+
+	attempts, err := purgejob.Execute(ctx, s.purger, job.Spec, s.opts.Retry)
+
+	now := time.Now()
+	s.update(job, func(j *Job) {
+		j.Attempts = attempts
+		j.FinishedAt = &now
+		if err != nil {
+			j.Status = StatusFailed
+			j.Error = err.Error()
+			s.failed++
+		} else {
+			j.Status = StatusSucceeded
+			s.succeeded++
+		}
+	})
+
+	if err != nil {
+		s.opts.Logger.Printf("job %s failed after %d attempt(s): %v", job.ID, attempts, err)
+	} else {
+		s.opts.Logger.Printf("job %s purged %s in %s", job.ID, job.Spec.Summary(), job.Spec.Zone)
+	}
+}
+
+func (s *Server) update(job *Job, fn func(*Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(job)
+}
+
+func (s *Server) handlePurge(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "payload too large")
+		return
+	}
+
+	if !Verify(s.opts.Secret, body, r.Header.Get(SignatureHeader), time.Now()) {
+		writeError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	var spec purgejob.Spec
+	if err := json.Unmarshal(body, &spec); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON payload")
+		return
+	}
+	if spec.Zone == "" {
+		spec.Zone = s.opts.DefaultZone
+	}
+	if spec.Everything && !s.opts.AllowEverything {
+		writeError(w, http.StatusForbidden, "purging everything is disabled on this server")
+		return
+	}
+	if err := spec.Validate(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	job := &Job{
+		ID:        newJobID(),
+		Spec:      spec,
+		Status:    StatusQueued,
+		CreatedAt: time.Now(),
+	}
+
+	select {
+	case s.queue <- job:
+	default:
+		writeError(w, http.StatusServiceUnavailable, "queue is full")
+		return
+	}
+
+	s.remember(job)
+	s.opts.Logger.Printf("job %s queued: %s in %s", job.ID, spec.Summary(), spec.Zone)
+	writeJSON(w, http.StatusAccepted, s.snapshot(job))
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	job, ok := s.jobs[r.PathValue("id")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.snapshot(job))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":    "ok",
+		"uptime":    time.Since(s.started).Round(time.Second).String(),
+		"queued":    len(s.queue),
+		"succeeded": s.succeeded,
+		"failed":    s.failed,
+	})
+}
+
+// remember stores a job for status lookups, forgetting the oldest ones
+func (s *Server) remember(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+	s.order = append(s.order, job.ID)
+	if len(s.order) > maxJobs {
+		delete(s.jobs, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// snapshot copies a job so it can be encoded without holding the lock
+func (s *Server) snapshot(job *Job) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *job
+}
+
+// Sign returns the signature header value for body signed at time at
+func Sign(secret, body []byte, at time.Time) string {
+	t := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + t + ",sha256=" + hex.EncodeToString(signatureOf(secret, t, body))
+}
+
+// Verify reports whether signature is a valid signature of body made
+// within SignatureTolerance of now
+func Verify(secret, body []byte, signature string, now time.Time) bool {
+	t, sig, ok := strings.Cut(signature, ",")
+	if !ok {
+		return false
+	}
+	t, ok = strings.CutPrefix(t, "t=")
+	if !ok {
+		return false
+	}
+	sig, ok = strings.CutPrefix(sig, "sha256=")
+	if !ok {
+		return false
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return false
+	}
+
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, signatureOf(secret, t, body))
+}
+
+// signatureOf computes the HMAC of a signing time and body
+func signatureOf(secret []byte, t string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/siyamsarker/cfctl/internal/purgejob"
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("s3cret")
+
+type fakePurger struct {
+	mu       sync.Mutex
+	failures int
+	requests []cloudflare.PurgeRequest
+}
+
+func (f *fakePurger) FindZone(ctx context.Context, nameOrID string) (*cloudflare.Zone, error) {
+	return &cloudflare.Zone{ID: "zone-1", Name: nameOrID}, nil
+}
+
+func (f *fakePurger) PurgeCacheBatched(ctx context.Context, zoneID string, req cloudflare.PurgeRequest, progress func(done, total int)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return &net.OpError{Op: "read", Net: "tcp", Err: errors.New("temporary failure")}
+	}
+	f.requests = append(f.requests, req)
+	return nil
+}
+
+func post(t *testing.T, handler http.Handler, payload string, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/purge", bytes.NewBufferString(payload))
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPurgeWebhook(t *testing.T) {
+	purger := &fakePurger{failures: 1}
+	srv, err := New(purger, Options{
+		Secret:      secret,
+		DefaultZone: "example.com",
+		Retry:       purgejob.Retry{Attempts: 2, Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Run(ctx)
+
+	handler := srv.Handler()
+	payload := `{"urls":["https://example.com/a","https://example.com/b"],"tags":["news"]}`
+
+	rec := post(t, handler, payload, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = post(t, handler, payload, Sign([]byte("wrong"), []byte(payload), time.Now()))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = post(t, handler, payload, Sign(secret, []byte(payload), time.Now()))
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, "example.com", job.Spec.Zone)
+
+	require.Eventually(t, func() bool {
+		req := httptest.NewRequest(http.MethodGet, "/v1/jobs/"+job.ID, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+		return job.Status == StatusSucceeded
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, job.Attempts)
+
+	purger.mu.Lock()
+	assert.Len(t, purger.requests, 2)
+	purger.mu.Unlock()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"succeeded":1`)
+}
+
+func TestPurgeWebhookValidation(t *testing.T) {
+	srv, err := New(&fakePurger{}, Options{Secret: secret})
+	require.NoError(t, err)
+	handler := srv.Handler()
+
+	tests := []struct {
+		payload string
+		status  int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{"urls":["https://example.com/"]}`, http.StatusUnprocessableEntity},
+		{`{"zone":"example.com","urls":["not-a-url"]}`, http.StatusUnprocessableEntity},
+		{`{"zone":"example.com"}`, http.StatusUnprocessableEntity},
+		{`{"zone":"example.com","everything":true}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		rec := post(t, handler, tt.payload, Sign(secret, []byte(tt.payload), time.Now()))
+		assert.Equal(t, tt.status, rec.Code, tt.payload)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"zone":"example.com","urls":["https://example.com/"]}`)
+	now := time.Unix(1735689600, 0)
+	signature := Sign(secret, body, now)
+
+	assert.True(t, Verify(secret, body, signature, now))
+	assert.True(t, Verify(secret, body, signature, now.Add(SignatureTolerance)))
+	// A captured request can't be replayed once the window has passed
+	assert.False(t, Verify(secret, body, signature, now.Add(SignatureTolerance+time.Second)))
+	assert.False(t, Verify(secret, body, signature, now.Add(-SignatureTolerance-time.Second)))
+	// Nor can its signature be moved to a fresh time
+	_, sig, _ := strings.Cut(signature, ",")
+	assert.False(t, Verify(secret, body, fmt.Sprintf("t=%d,%s", now.Add(time.Hour).Unix(), sig), now.Add(time.Hour)))
+
+	assert.False(t, Verify(secret, []byte(`{}`), signature, now))
+	assert.False(t, Verify(secret, body, strings.TrimPrefix(signature, "t="), now))
+	assert.False(t, Verify(secret, body, "sha256="+sig, now))
+}
+
+func TestPurgeWebhookRejectsStaleSignature(t *testing.T) {
+	srv, err := New(&fakePurger{}, Options{Secret: secret, DefaultZone: "example.com"})
+	require.NoError(t, err)
+
+	payload := `{"urls":["https://example.com/"]}`
+	rec := post(t, srv.Handler(), payload, Sign(secret, []byte(payload), time.Now().Add(-time.Hour)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestNewRequiresSecret(t *testing.T) {
+	_, err := New(&fakePurger{}, Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+// Package workers prepares Workers script bundles for upload and checks
+// Worker route patterns.
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/siyamsarker/cfctl/pkg/cloudflare"
+)
+
+// moduleTypes maps file extensions to the content types the upload API uses
+// to tell module kinds apart
+var moduleTypes = map[string]string{
+	".js":   "application/javascript+module",
+	".mjs":  "application/javascript+module",
+	".cjs":  "application/javascript",
+	".py":   "text/x-python",
+	".wasm": "application/wasm",
+	".map":  "application/source-map",
+	".txt":  "text/plain",
+	".html": "text/plain",
+	".json": "application/json",
+	".bin":  "application/octet-stream",
+}
+
+// ContentType returns the module content type for a file name, treating
+// unknown extensions as binary data
+func ContentType(name string) string {
+	if t, ok := moduleTypes[strings.ToLower(filepath.Ext(name))]; ok {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// LoadBundle reads the main module and the modules it imports. Modules are
+// named by their path relative to the main module's directory, which is how
+// the main module imports them. An empty compatibility date means today.
+func LoadBundle(main string, modules []string, compatibilityDate string) (cloudflare.WorkerBundle, error) {
+	if compatibilityDate == "" {
+		compatibilityDate = time.Now().UTC().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", compatibilityDate); err != nil {
+		return cloudflare.WorkerBundle{}, fmt.Errorf("invalid compatibility date %q: expected YYYY-MM-DD", compatibilityDate)
+	}
+	if ContentType(main) != "application/javascript+module" {
+		return cloudflare.WorkerBundle{}, fmt.Errorf("main module %s must be an ES module (.js or .mjs)", main)
+	}
+
+	root := filepath.Dir(main)
+	bundle := cloudflare.WorkerBundle{CompatibilityDate: compatibilityDate}
+	seen := map[string]bool{}
+
+	for _, path := range append([]string{main}, modules...) {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return cloudflare.WorkerBundle{}, fmt.Errorf("module %s must be inside %s, the main module's directory", path, root)
+		}
+		name := filepath.ToSlash(rel)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return cloudflare.WorkerBundle{}, fmt.Errorf("read module: %w", err)
+		}
+		bundle.Modules = append(bundle.Modules, cloudflare.WorkerModule{
+			Name:        name,
+			ContentType: ContentType(name),
+			Content:     content,
+		})
+	}
+
+	bundle.MainModule = bundle.Modules[0].Name
+	return bundle, nil
+}
+
+// Size returns the total size of a bundle's modules in bytes
+func Size(bundle cloudflare.WorkerBundle) int {
+	total := 0
+	for _, module := range bundle.Modules {
+		total += len(module.Content)
+	}
+	return total
+}
+
+// ValidateRoutePattern checks that a route pattern such as
+// "*.example.com/api/*" has a hostname inside the zone and no scheme or
+// query string
+func ValidateRoutePattern(pattern, zone string) error {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return fmt.Errorf("route pattern is required")
+	}
+	if strings.Contains(pattern, "://") {
+		return fmt.Errorf("route pattern %q must not include a scheme", pattern)
+	}
+	if strings.ContainsAny(pattern, " ?#") {
+		return fmt.Errorf("route pattern %q must not contain spaces, a query string or a fragment", pattern)
+	}
+
+	host, _, _ := strings.Cut(pattern, "/")
+	host = strings.TrimPrefix(host, "*")
+	host = strings.TrimPrefix(host, ".")
+	if zone != "" && host != zone && !strings.HasSuffix(host, "."+zone) {
+		return fmt.Errorf("route pattern %q is not inside zone %s", pattern, zone)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/javascript+module", ContentType("index.mjs"))
+	assert.Equal(t, "application/wasm", ContentType("lib/ADD.WASM"))
+	assert.Equal(t, "application/octet-stream", ContentType("data.dat"))
+}
+
+func TestLoadBundle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0755))
+	main := filepath.Join(dir, "index.js")
+	wasm := filepath.Join(dir, "lib", "add.wasm")
+	require.NoError(t, os.WriteFile(main, []byte("import add from './lib/add.wasm'"), 0644))
+	require.NoError(t, os.WriteFile(wasm, []byte{0, 'a', 's', 'm'}, 0644))
+
+	bundle, err := LoadBundle(main, []string{wasm, main}, "2024-09-01")
+	require.NoError(t, err)
+	assert.Equal(t, "index.js", bundle.MainModule)
+	assert.Equal(t, "2024-09-01", bundle.CompatibilityDate)
+	require.Len(t, bundle.Modules, 2)
+	assert.Equal(t, "lib/add.wasm", bundle.Modules[1].Name)
+	assert.Equal(t, "application/wasm", bundle.Modules[1].ContentType)
+	assert.Equal(t, 36, Size(bundle))
+
+	bundle, err = LoadBundle(main, nil, "")
+	require.NoError(t, err)
+	assert.Len(t, bundle.CompatibilityDate, len("2006-01-02"))
+
+	_, err = LoadBundle(main, nil, "September")
+	assert.Error(t, err)
+	_, err = LoadBundle(wasm, nil, "")
+	assert.Error(t, err)
+	_, err = LoadBundle(filepath.Join(dir, "lib", "x.js"), []string{main}, "")
+	assert.Error(t, err)
+}
+
+func TestValidateRoutePattern(t *testing.T) {
+	for _, pattern := range []string{"example.com/*", "*.example.com/api/*", "*example.com/*", "shop.example.com"} {
+		assert.NoError(t, ValidateRoutePattern(pattern, "example.com"), pattern)
+	}
+	for _, pattern := range []string{"", "https://example.com/*", "example.org/*", "badexample.com/*", "example.com/a?b=1"} {
+		assert.Error(t, ValidateRoutePattern(pattern, "example.com"), pattern)
+	}
+}
@@ -1,13 +1,36 @@
 package cloudflare
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Zone represents a Cloudflare zone/domain
 type Zone struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
-	Plan   Plan   `json:"plan"`
+	ID                string      `json:"id"`
+	Name              string      `json:"name"`
+	Status            string      `json:"status"`
+	Plan              Plan        `json:"plan"`
+	Account           ZoneAccount `json:"account"`
+	Type              string      `json:"type,omitempty"` // full, partial or secondary
+	Paused            bool        `json:"paused"`
+	NameServers       []string    `json:"name_servers,omitempty"`
+	OriginalRegistrar string      `json:"original_registrar,omitempty"`
+	DevelopmentMode   int         `json:"development_mode"` // seconds until development mode ends, <= 0 when off
+	CreatedOn         time.Time   `json:"created_on"`
+	ModifiedOn        time.Time   `json:"modified_on"`
+	ActivatedOn       time.Time   `json:"activated_on"` // zero until the zone is activated
+}
+
+// DevelopmentModeOn reports whether development mode is currently enabled
+func (z Zone) DevelopmentModeOn() bool {
+	return z.DevelopmentMode > 0
+}
+
+// ZoneAccount identifies the account that owns a zone
+type ZoneAccount struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // Plan represents a Cloudflare plan
@@ -38,12 +61,334 @@ type APIError struct {
 	Message string `json:"message"`
 }
 
-// Account represents stored account configuration
+// Account represents stored account configuration: a named credential
+// profile. One credential may reach several Cloudflare accounts, see
+// AccountInfo.
 type Account struct {
 	Name      string    `yaml:"name" mapstructure:"name"`
 	Email     string    `yaml:"email" mapstructure:"email"`
 	AuthType  string    `yaml:"auth_type" mapstructure:"auth_type"`
 	Default   bool      `yaml:"default" mapstructure:"default"`
+	AccountID string    `yaml:"account_id,omitempty" mapstructure:"account_id"` // Cloudflare account zones are filtered by, empty for all
 	CreatedAt time.Time `yaml:"created_at" mapstructure:"created_at"`
 	UpdatedAt time.Time `yaml:"updated_at" mapstructure:"updated_at"`
 }
+
+// AccountInfo describes a Cloudflare account a credential can access
+type AccountInfo struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"` // standard or enterprise
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// CacheRule represents a rule in the http_request_cache_settings ruleset phase
+type CacheRule struct {
+	ID               string                 `json:"id,omitempty" yaml:"id,omitempty"`
+	Description      string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Expression       string                 `json:"expression" yaml:"expression"`
+	Action           string                 `json:"action" yaml:"-"`
+	Enabled          bool                   `json:"enabled" yaml:"enabled"`
+	ActionParameters map[string]interface{} `json:"action_parameters,omitempty" yaml:"action_parameters,omitempty"`
+}
+
+// CacheRulesDocument is the portable YAML representation of a zone's cache rules
+type CacheRulesDocument struct {
+	Zone  string      `json:"zone" yaml:"zone"`
+	Rules []CacheRule `json:"rules" yaml:"rules"`
+}
+
+// AnalyticsPoint is one time bucket of zone HTTP traffic
+type AnalyticsPoint struct {
+	Time           time.Time `json:"time"`
+	Requests       int64     `json:"requests"`
+	CachedRequests int64     `json:"cached_requests"`
+	Bytes          int64     `json:"bytes"`
+	CachedBytes    int64     `json:"cached_bytes"`
+}
+
+// CacheAnalytics summarizes cache performance of a zone over a time window
+type CacheAnalytics struct {
+	ZoneID         string           `json:"zone_id"`
+	Window         string           `json:"window"`
+	Since          time.Time        `json:"since"`
+	Until          time.Time        `json:"until"`
+	Requests       int64            `json:"requests"`
+	CachedRequests int64            `json:"cached_requests"`
+	Bytes          int64            `json:"bytes"`
+	CachedBytes    int64            `json:"cached_bytes"`
+	HitRatio       float64          `json:"hit_ratio"`
+	Points         []AnalyticsPoint `json:"points"`
+}
+
+// PageRule is a legacy zone Page Rule. cfctl edits forwarding rules and
+// keeps the actions of other rules as they are.
+type PageRule struct {
+	ID       string           `json:"id,omitempty"`
+	URL      string           `json:"url"` // URL pattern the rule matches, * is a wildcard
+	Actions  []PageRuleAction `json:"actions"`
+	Priority int              `json:"priority,omitempty"`
+	Status   string           `json:"status"` // active or disabled
+}
+
+// PageRuleAction is one setting applied by a Page Rule
+type PageRuleAction struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Forwarding returns the target and status code of a forwarding Page Rule
+func (r PageRule) Forwarding() (string, int, bool) {
+	for _, a := range r.Actions {
+		if a.ID != "forwarding_url" {
+			continue
+		}
+		value, _ := a.Value.(map[string]interface{})
+		target, _ := value["url"].(string)
+		status, _ := value["status_code"].(float64)
+		if code, ok := value["status_code"].(int); ok {
+			status = float64(code)
+		}
+		return target, int(status), true
+	}
+	return "", 0, false
+}
+
+// RedirectRule is a Single Redirect: a rule in the zone's
+// http_request_dynamic_redirect ruleset phase
+type RedirectRule struct {
+	ID                  string `json:"id,omitempty"`
+	Description         string `json:"description,omitempty"`
+	Expression          string `json:"expression"`
+	TargetURL           string `json:"target_url,omitempty"`        // static target
+	TargetExpression    string `json:"target_expression,omitempty"` // dynamic target, used when TargetURL is empty
+	StatusCode          int    `json:"status_code"`
+	PreserveQueryString bool   `json:"preserve_query_string"`
+	Enabled             bool   `json:"enabled"`
+
+	// Raw is the rule as the API returned it. Fields the struct doesn't
+	// model, such as logging or ref, are written back from it so an
+	// update doesn't drop them.
+	Raw json.RawMessage `json:"-"`
+}
+
+// BulkRedirectList is an account-level list of URL redirects
+type BulkRedirectList struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	NumItems    int       `json:"num_items"`
+	CreatedOn   time.Time `json:"created_on"`
+	ModifiedOn  time.Time `json:"modified_on"`
+}
+
+// BulkRedirect is one source to target mapping of a Bulk Redirect list
+type BulkRedirect struct {
+	ID                  string `json:"id,omitempty"` // list item ID, set by the API
+	SourceURL           string `json:"source_url"`
+	TargetURL           string `json:"target_url"`
+	StatusCode          int    `json:"status_code,omitempty"`
+	PreserveQueryString bool   `json:"preserve_query_string,omitempty"`
+	IncludeSubdomains   bool   `json:"include_subdomains,omitempty"`
+	SubpathMatching     bool   `json:"subpath_matching,omitempty"`
+	PreservePathSuffix  bool   `json:"preserve_path_suffix,omitempty"`
+}
+
+// FirewallRule is a WAF custom rule: a rule in the
+// http_request_firewall_custom ruleset phase of a zone or account
+type FirewallRule struct {
+	ID               string                 `json:"id,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Expression       string                 `json:"expression"`
+	Action           string                 `json:"action"` // block, managed_challenge, js_challenge, challenge, log or skip
+	Enabled          bool                   `json:"enabled"`
+	ActionParameters map[string]interface{} `json:"action_parameters,omitempty"`
+
+	// Raw is the rule as the API returned it. Fields the struct doesn't
+	// model, such as logging, are written back from it so an update
+	// doesn't drop them.
+	Raw json.RawMessage `json:"-"`
+}
+
+// firewallRuleFields are the JSON keys FirewallRule models itself
+var firewallRuleFields = []string{"id", "description", "expression", "action", "enabled", "action_parameters"}
+
+// UnmarshalJSON decodes a rule and keeps its JSON in Raw
+func (r *FirewallRule) UnmarshalJSON(data []byte) error {
+	type plain FirewallRule
+	var rule plain
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	*r = FirewallRule(rule)
+	r.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes a rule, carrying over the fields of Raw the struct
+// doesn't model
+func (r FirewallRule) MarshalJSON() ([]byte, error) {
+	type plain FirewallRule
+	known, err := json.Marshal(plain(r))
+	if err != nil || len(r.Raw) == 0 {
+		return known, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(r.Raw, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range firewallRuleFields {
+		delete(fields, key)
+	}
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// AccessRule is an IP Access rule: an action applied to every request from
+// an IP address, range, ASN or country
+type AccessRule struct {
+	ID         string    `json:"id,omitempty"`
+	Mode       string    `json:"mode"`   // block, managed_challenge, js_challenge, challenge or whitelist
+	Target     string    `json:"target"` // ip, ip6, ip_range, asn or country
+	Value      string    `json:"value"`
+	Notes      string    `json:"notes,omitempty"`
+	Scope      string    `json:"scope,omitempty"` // zone, account or organization the rule is defined on
+	CreatedOn  time.Time `json:"created_on"`
+	ModifiedOn time.Time `json:"modified_on"`
+}
+
+// CertificatePack is a set of edge certificates Cloudflare serves for some
+// of a zone's hostnames, such as the Universal SSL pack
+type CertificatePack struct {
+	ID                   string            `json:"id"`
+	Type                 string            `json:"type"` // universal, advanced, total_tls, sni_custom or legacy_custom
+	Hosts                []string          `json:"hosts"`
+	Status               string            `json:"status"`
+	CertificateAuthority string            `json:"certificate_authority,omitempty"`
+	ValidityDays         int               `json:"validity_days,omitempty"`
+	PrimaryCertificate   string            `json:"primary_certificate,omitempty"`
+	Certificates         []EdgeCertificate `json:"certificates"`
+}
+
+// Expires returns when the first of the pack's certificates expires, or the
+// zero time when none has been issued yet
+func (p CertificatePack) Expires() time.Time {
+	var expires time.Time
+	for _, cert := range p.Certificates {
+		if !cert.ExpiresOn.IsZero() && (expires.IsZero() || cert.ExpiresOn.Before(expires)) {
+			expires = cert.ExpiresOn
+		}
+	}
+	return expires
+}
+
+// Issuer returns the issuer of the pack's primary certificate, falling back
+// to the certificate authority the pack was ordered from
+func (p CertificatePack) Issuer() string {
+	for _, cert := range p.Certificates {
+		if cert.Issuer != "" && (p.PrimaryCertificate == "" || cert.ID == p.PrimaryCertificate) {
+			return cert.Issuer
+		}
+	}
+	return p.CertificateAuthority
+}
+
+// EdgeCertificate is one certificate of a certificate pack
+type EdgeCertificate struct {
+	ID         string    `json:"id"`
+	Hosts      []string  `json:"hosts"`
+	Issuer     string    `json:"issuer"`
+	Signature  string    `json:"signature"` // e.g. ECDSAWithSHA256 or SHA256WithRSA
+	Status     string    `json:"status"`
+	ExpiresOn  time.Time `json:"expires_on"`
+	UploadedOn time.Time `json:"uploaded_on"`
+}
+
+// SSLSettings are the SSL/TLS settings of a zone
+type SSLSettings struct {
+	Mode                   string `json:"mode"` // off, flexible, full or strict
+	MinTLSVersion          string `json:"min_tls_version"`
+	TLS13                  string `json:"tls_1_3"` // on, off or zrt
+	AlwaysUseHTTPS         bool   `json:"always_use_https"`
+	AutomaticHTTPSRewrites bool   `json:"automatic_https_rewrites"`
+}
+
+// OriginCertificate is a certificate issued by the Cloudflare Origin CA,
+// trusted only by Cloudflare and used between Cloudflare and the origin
+type OriginCertificate struct {
+	ID                string    `json:"id"`
+	Hostnames         []string  `json:"hostnames"`
+	RequestType       string    `json:"request_type"`       // origin-rsa or origin-ecc
+	RequestedValidity int       `json:"requested_validity"` // days
+	ExpiresOn         time.Time `json:"expires_on"`
+	Certificate       string    `json:"certificate,omitempty"` // PEM encoded
+}
+
+// WorkerScript is a Workers script of an account. The script name is its ID.
+type WorkerScript struct {
+	ID                string    `json:"id"`
+	ETag              string    `json:"etag,omitempty"`
+	Handlers          []string  `json:"handlers,omitempty"` // e.g. fetch, scheduled
+	UsageModel        string    `json:"usage_model,omitempty"`
+	CompatibilityDate string    `json:"compatibility_date,omitempty"`
+	CreatedOn         time.Time `json:"created_on"`
+	ModifiedOn        time.Time `json:"modified_on"`
+}
+
+// WorkerRoute sends requests matching a URL pattern in a zone to a Workers
+// script. An empty script disables Workers for the pattern.
+type WorkerRoute struct {
+	ID      string `json:"id,omitempty"`
+	Pattern string `json:"pattern"`
+	Script  string `json:"script,omitempty"`
+}
+
+// WorkerBundle is a Workers script in ES module format ready for upload:
+// the main module and any modules it imports
+type WorkerBundle struct {
+	MainModule        string
+	CompatibilityDate string
+	Modules           []WorkerModule
+}
+
+// WorkerModule is one file of a WorkerBundle. Name is the path the other
+// modules import it by.
+type WorkerModule struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// KVNamespace is a Workers KV namespace
+type KVNamespace struct {
+	ID                  string `json:"id"`
+	Title               string `json:"title"`
+	SupportsURLEncoding bool   `json:"supports_url_encoding,omitempty"`
+}
+
+// KVKey is a key of a Workers KV namespace
+type KVKey struct {
+	Name       string          `json:"name"`
+	Expiration int64           `json:"expiration,omitempty"` // Unix time, zero when the key never expires
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+}
+
+// KVKeyPage is one page of a key listing. Cursor is empty on the last page.
+type KVKeyPage struct {
+	Keys   []KVKey `json:"keys"`
+	Cursor string  `json:"cursor,omitempty"`
+}
+
+// KVPair is a key and value written by a bulk put. Value holds base64 when
+// Base64 is set.
+type KVPair struct {
+	Key           string          `json:"key"`
+	Value         string          `json:"value"`
+	Expiration    int64           `json:"expiration,omitempty"`
+	ExpirationTTL int64           `json:"expiration_ttl,omitempty"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	Base64        bool            `json:"base64,omitempty"`
+}